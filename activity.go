@@ -0,0 +1,65 @@
+package redisboard
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// touch queues a last-activity update of userID on pipe when TrackActivity
+// is set. Returns nil otherwise.
+func (lb *Leaderboard) touch(pipe redis.Pipeliner, userID string) redis.Cmder {
+	if !lb.config.TrackActivity {
+		return nil
+	}
+	now := float64(time.Now().UnixMilli())
+	return pipe.ZAdd(lb.ctx, lb.activityKey(), redis.Z{Score: now, Member: userID})
+}
+
+// PruneInactive removes users who haven't scored for olderThan, as recorded
+// with TrackActivity, from every ranking, the entity mapping and metadata
+// (see RemoveUser). Users added before tracking was enabled have no activity
+// record and are never pruned.
+// Not atomic: a user scoring while being pruned may still be removed.
+// Returns the number of pruned users.
+// Returns error if:
+// - TrackActivity is unset
+// - olderThan is not positive
+// - Redis operation fails
+func (lb *Leaderboard) PruneInactive(olderThan time.Duration) (_ int, err error) {
+	defer lb.startOp("PruneInactive", "", "").end(&err)
+	if !lb.config.TrackActivity {
+		return 0, fmt.Errorf("activity tracking disabled")
+	}
+	if olderThan <= 0 {
+		return 0, fmt.Errorf("invalid inactivity period: %v", olderThan)
+	}
+
+	cutoff := time.Now().Add(-olderThan).UnixMilli()
+	activityKey := lb.activityKey()
+
+	pruned := 0
+	for {
+		// RemoveUser drops each pruned user's activity record, so every
+		// batch starts from the oldest remaining one
+		userIDs, err := lb.client.ZRangeByScore(lb.ctx, activityKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   "(" + strconv.FormatInt(cutoff, 10),
+			Count: int64(lb.config.BatchSize),
+		}).Result()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to fetch inactive users: %w", err)
+		}
+		if len(userIDs) == 0 {
+			return pruned, nil
+		}
+		for _, userID := range userIDs {
+			if err := lb.RemoveUser(userID); err != nil {
+				return pruned, err
+			}
+			pruned++
+		}
+	}
+}
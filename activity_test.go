@@ -0,0 +1,61 @@
+package redisboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestPruneInactive(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", TrackActivity: true, EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"name": "Ann"}})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u3", Score: 300})
+	lb.IncrementScores([]ScoreUpdate{{UserID: "u4", Entity: "UK", Delta: 10}})
+
+	// age u1 and u4 by 48 hours
+	old := float64(time.Now().Add(-48 * time.Hour).UnixMilli())
+	lb.client.ZAdd(lb.ctx, lb.activityKey(), redis.Z{Score: old, Member: "u1"}, redis.Z{Score: old, Member: "u4"})
+
+	pruned, err := lb.PruneInactive(24 * time.Hour)
+	if err != nil || pruned != 2 {
+		t.Fatalf("expected 2 pruned, got %d (err: %v)", pruned, err)
+	}
+	for _, id := range []string{"u1", "u4"} {
+		if _, err := lb.GetUserScore(id); err == nil {
+			t.Errorf("expected %s to be pruned", id)
+		}
+	}
+	if members, _ := lb.GetEntityMembers("US"); len(members) != 1 || members[0] != "u2" {
+		t.Errorf("expected US members [u2], got %v", members)
+	}
+	if n, _ := rawClient(lb).HLen(lb.ctx, lb.metaKey()).Result(); n != 0 {
+		t.Errorf("expected metadata cleaned, %d entries left", n)
+	}
+	if n, _ := lb.client.ZCard(lb.ctx, lb.activityKey()).Result(); n != 2 {
+		t.Errorf("expected 2 activity records left, got %d", n)
+	}
+
+	// scoring refreshes activity
+	lb.client.ZAdd(lb.ctx, lb.activityKey(), redis.Z{Score: old, Member: "u2"})
+	lb.IncrementScore("u2", "US", 5)
+	if pruned, _ := lb.PruneInactive(24 * time.Hour); pruned != 0 {
+		t.Errorf("expected active u2 to be kept, pruned %d", pruned)
+	}
+}
+
+func TestPruneInactiveDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	if n, _ := lb.client.Exists(lb.ctx, lb.activityKey()).Result(); n != 0 {
+		t.Error("expected no activity writes without TrackActivity")
+	}
+	if _, err := lb.PruneInactive(time.Hour); err == nil {
+		t.Error("expected error without TrackActivity")
+	}
+}
@@ -0,0 +1,118 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AddOption selects when AddUserWithOption writes, after the ZADD flags.
+type AddOption int
+
+const (
+	AddAlways       AddOption = iota // always write, like AddUser
+	AddOnlyIfNew                     // only add new users (NX)
+	AddOnlyIfExists                  // only update existing users (XX)
+	AddOnlyIfHigher                  // add new users, update only to a higher score (GT)
+	AddOnlyIfLower                   // add new users, update only to a lower score (LT)
+)
+
+// admits reports whether the option lets a write of score proceed, given
+// the user's current score if exists.
+func (opt AddOption) admits(exists bool, current, score float64) bool {
+	switch opt {
+	case AddOnlyIfNew:
+		return !exists
+	case AddOnlyIfExists:
+		return exists
+	case AddOnlyIfHigher:
+		return !exists || score > current
+	case AddOnlyIfLower:
+		return !exists || score < current
+	}
+	return true
+}
+
+// AddUserWithOption is AddUser writing only when opt allows, e.g.
+// AddOnlyIfHigher to keep each user's best score. The option is checked
+// against the global score, and the global and entity rankings, entity
+// mapping, metadata and name are then written together, so the rankings
+// never disagree as separate ZADD NX/XX/GT/LT calls could when a user
+// changes entity.
+// Runs as an optimistic WATCH/MULTI transaction, retried on conflict.
+// Reports whether the user was added or their score or entity changed;
+// nothing is written when opt rejects the write.
+// Returns error if:
+// - the user is invalid, as for AddUser
+// - opt is unknown
+// - eviction policies or MaxUsersPerEntity are set (capped rankings admit users through their own script)
+// - entities are sharded (ErrShardingUnsupported)
+// - concurrent writes keep conflicting (ErrConflict)
+// - publishing the rank change fails (PublishRankChanges; the write is applied)
+// - recording the best rank fails (TrackBestRank; the write is applied)
+// - Redis operation fails
+func (lb *Leaderboard) AddUserWithOption(user User, opt AddOption) (changed bool, err error) {
+	defer lb.startOp("AddUserWithOption", user.ID, user.Entity).end(&err)
+	user.Entity = lb.normalizeEntity(user.Entity)
+	if opt < AddAlways || opt > AddOnlyIfLower {
+		return false, fmt.Errorf("invalid add option %d", opt)
+	}
+	if err := lb.unsharded(); err != nil {
+		return false, err
+	}
+	if lb.capped(user.Entity) {
+		return false, fmt.Errorf("add options can't be combined with eviction policies or user caps")
+	}
+	score, meta, err := lb.prepareUser(user)
+	if err != nil {
+		return false, err
+	}
+	defer lb.topKCache.invalidate()
+
+	before, err := lb.rankBefore(user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	globalKey := lb.userGlobalKey(user.ID)
+	entitiesKey := lb.entitiesKey()
+	write := func(tx *redis.Tx) error {
+		changed = false
+		oldEntity, err := tx.HGet(lb.ctx, entitiesKey, user.ID).Result()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to get user entity: %w", err)
+		}
+		current, err := tx.ZScore(lb.ctx, globalKey, lb.memberFor(user.ID, oldEntity)).Result()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to get user score: %w", err)
+		}
+		exists := err == nil
+		if !opt.admits(exists, current, score) {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(lb.ctx, func(pipe redis.Pipeliner) error {
+			return lb.queueAddUser(pipe, pipe, user, score, meta, true)
+		})
+		if err != nil {
+			if err != redis.TxFailedErr {
+				err = fmt.Errorf("failed to add user: %w", conflictErr(err))
+			}
+			return err
+		}
+		changed = !exists || current != score || oldEntity != user.Entity
+		return nil
+	}
+
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		err := lb.client.Watch(lb.ctx, write, globalKey, entitiesKey)
+		if err == redis.TxFailedErr {
+			continue // watched key changed, retry with fresh data
+		}
+		if err != nil || !changed {
+			return false, err
+		}
+		return true, lb.afterWrite(user.ID, before)
+	}
+	return false, fmt.Errorf("failed to add user: %w after %d attempts", ErrConflict, maxTxRetries)
+}
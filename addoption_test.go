@@ -0,0 +1,57 @@
+package redisboard
+
+import "testing"
+
+func TestAddUserWithOption(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	steps := []struct {
+		opt     AddOption
+		score   float64
+		changed bool
+		want    float64
+	}{
+		{AddOnlyIfExists, 50, false, 0}, // not added
+		{AddOnlyIfNew, 100, true, 100},
+		{AddOnlyIfNew, 200, false, 100},
+		{AddOnlyIfHigher, 90, false, 100},
+		{AddOnlyIfHigher, 150, true, 150},
+		{AddOnlyIfLower, 160, false, 150},
+		{AddOnlyIfLower, 120, true, 120},
+		{AddOnlyIfExists, 130, true, 130},
+		{AddAlways, 130, false, 130}, // same score: written, unchanged
+		{AddAlways, 10, true, 10},
+	}
+	for i, st := range steps {
+		changed, err := lb.AddUserWithOption(User{ID: "u1", Entity: "US", Score: st.score}, st.opt)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if changed != st.changed {
+			t.Errorf("step %d: expected changed=%v, got %v", i, st.changed, changed)
+		}
+		score, _ := lb.GetUserScore("u1")
+		entityScore, _ := lb.client.ZScore(lb.ctx, "test:entity:US", "u1").Result()
+		if score != st.want || entityScore != st.want {
+			t.Errorf("step %d: expected score %v, got global %v, entity %v", i, st.want, score, entityScore)
+		}
+	}
+
+	// a rejected write leaves the entity mapping alone too
+	if changed, _ := lb.AddUserWithOption(User{ID: "u1", Entity: "UK", Score: 5}, AddOnlyIfHigher); changed {
+		t.Error("expected lower score to be rejected")
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "US" {
+		t.Errorf("expected entity US, got %s", entity)
+	}
+
+	if _, err := lb.AddUserWithOption(User{ID: "u1", Score: 1}, AddOption(9)); err == nil {
+		t.Error("expected error for unknown option")
+	}
+	capped := newTestLeaderboard(t, Config{Namespace: "test", EvictionPolicy: EvictionLowest, MaxUsers: 10})
+	defer capped.Close()
+	if _, err := capped.AddUserWithOption(User{ID: "u1", Score: 1}, AddOnlyIfHigher); err == nil {
+		t.Error("expected error with an eviction policy")
+	}
+}
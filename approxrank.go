@@ -0,0 +1,138 @@
+package redisboard
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rankBuckets is the number of buckets of the approximate rank histogram.
+const rankBuckets = 100
+
+// rankHistogram is an in-process score histogram of the global ranking:
+// the scores found at rankBuckets+1 evenly spaced ranks. A rank is
+// estimated by interpolating a score between its two boundaries, so the
+// error is bounded by one bucket (ZCARD/rankBuckets users) and is much
+// smaller when scores within a bucket are spread evenly.
+type rankHistogram struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	scores  []float64 // boundary scores, descending
+	ranks   []int64   // 0-based rank of each boundary
+	expires time.Time
+}
+
+// newRankHistogram returns an empty histogram rebuilt every ttl.
+func newRankHistogram(ttl time.Duration) *rankHistogram {
+	return &rankHistogram{ttl: ttl}
+}
+
+// estimate returns the approximate 0-based rank of score, rebuilding the
+// histogram through lb if it expired.
+func (h *rankHistogram) estimate(lb *Leaderboard, score float64) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if time.Now().After(h.expires) {
+		if err := h.build(lb); err != nil {
+			return -1, err
+		}
+	}
+	if len(h.scores) == 0 {
+		return 0, nil
+	}
+
+	// a boundary tied with score: ties can span several boundaries, so
+	// return the first, the top of the tie group within one bucket, which
+	// RankAtScore reports exactly
+	k := sort.Search(len(h.scores), func(i int) bool { return h.scores[i] <= score })
+	if k < len(h.scores) && h.scores[k] == score {
+		return int(h.ranks[k]), nil
+	}
+
+	// first boundary ranked below score
+	j := k
+	if j == 0 {
+		return 0, nil
+	}
+	if j == len(h.scores) {
+		return int(h.ranks[j-1]), nil
+	}
+	hi, lo := h.scores[j-1], h.scores[j]
+	frac := (hi - score) / (hi - lo)
+	return int(h.ranks[j-1] + int64(frac*float64(h.ranks[j]-h.ranks[j-1]))), nil
+}
+
+// build fetches the boundary scores in a single pipeline. Each lookup is
+// O(log n), so a rebuild costs rankBuckets+1 of them however large the
+// board is.
+func (h *rankHistogram) build(lb *Leaderboard) error {
+	globalKey := lb.globalKey()
+	n, err := lb.reader.ZCard(lb.ctx, globalKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count users: %w", err)
+	}
+
+	pipe := lb.reader.Pipeline()
+	var cmds []*redis.ZSliceCmd
+	var ranks []int64
+	for i := int64(0); i <= rankBuckets && n > 0; i++ {
+		rank := i * (n - 1) / rankBuckets
+		if len(ranks) > 0 && ranks[len(ranks)-1] == rank {
+			continue // small board: fewer users than buckets
+		}
+		ranks = append(ranks, rank)
+		cmds = append(cmds, pipe.ZRevRangeWithScores(lb.ctx, globalKey, rank, rank))
+	}
+	if len(cmds) > 0 {
+		if _, err := pipe.Exec(lb.ctx); err != nil {
+			return fmt.Errorf("failed to fetch score histogram: %w", err)
+		}
+	}
+
+	h.scores, h.ranks = h.scores[:0], h.ranks[:0]
+	for i, cmd := range cmds {
+		members := cmd.Val()
+		if len(members) == 0 {
+			break // board shrank during the rebuild
+		}
+		h.scores = append(h.scores, members[0].Score)
+		h.ranks = append(h.ranks, ranks[i])
+	}
+	h.expires = time.Now().Add(h.ttl)
+	return nil
+}
+
+// GetApproximateRank returns an estimate of a user's 0-based global rank,
+// for UIs showing "about rank 10,234" on boards with millions of users.
+// Costs a single ZSCORE plus an in-memory lookup in a score histogram that
+// is rebuilt every Config.ApproxRankTTL with rankBuckets+1 pipelined
+// lookups. The estimate is off by at most ZCARD/rankBuckets ranks (1% of
+// the board) from the histogram's view, and more if the board changed
+// since it was built; use GetRankGlobal where exact ranks matter. Tied
+// users are estimated at the top of their tie group, the rank RankAtScore
+// reports for their score, rather than at their ordinal rank.
+// Returns -1 if user not found.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) GetApproximateRank(userID string) (_ int, err error) {
+	defer lb.startOp("GetApproximateRank", userID, "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return -1, err
+	}
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return -1, err
+	}
+
+	score, err := lb.reader.ZScore(lb.ctx, lb.globalKey(), member).Result()
+	if err == redis.Nil {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("failed to get score: %w", err)
+	}
+	return lb.rankHistogram.estimate(lb, score)
+}
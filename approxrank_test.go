@@ -0,0 +1,98 @@
+package redisboard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGetApproximateRank(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", ApproxRankTTL: time.Hour})
+	defer lb.Close()
+
+	const n = 2000
+	updates := make([]ScoreUpdate, n)
+	for i := range updates {
+		// skewed scores so buckets aren't evenly spread
+		updates[i] = ScoreUpdate{UserID: fmt.Sprintf("u%d", i), Delta: float64(i*i + 1)}
+	}
+	if err := lb.IncrementScores(updates); err != nil {
+		t.Fatalf("IncrementScores: %v", err)
+	}
+
+	maxErr := n / rankBuckets
+	for i := 0; i < n; i += 37 {
+		id := fmt.Sprintf("u%d", i)
+		exact, _ := lb.GetRankGlobal(id)
+		approx, err := lb.GetApproximateRank(id)
+		if err != nil {
+			t.Fatalf("GetApproximateRank(%s): %v", id, err)
+		}
+		if d := approx - exact; d > maxErr || d < -maxErr {
+			t.Errorf("%s: approximate rank %d too far from exact %d", id, approx, exact)
+		}
+	}
+	if rank, _ := lb.GetApproximateRank("u1999"); rank != 0 {
+		t.Errorf("expected top user at rank 0, got %d", rank)
+	}
+	if rank, _ := lb.GetApproximateRank("u0"); rank != n-1 {
+		t.Errorf("expected bottom user at rank %d, got %d", n-1, rank)
+	}
+	if rank, err := lb.GetApproximateRank("nobody"); err != nil || rank != -1 {
+		t.Errorf("expected -1 for unknown user, got %d (err: %v)", rank, err)
+	}
+
+	// the histogram is reused within ApproxRankTTL
+	hook := &countingHook{}
+	lb.reader.(redis.UniversalClient).AddHook(hook)
+	lb.GetApproximateRank("u1000")
+	if calls := hook.calls.Load(); calls != 1 {
+		t.Errorf("expected a single ZSCORE with a fresh histogram, got %d commands", calls)
+	}
+}
+
+func TestGetApproximateRankEmpty(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if rank, err := lb.GetApproximateRank("u1"); err != nil || rank != -1 {
+		t.Errorf("expected -1 on empty board, got %d (err: %v)", rank, err)
+	}
+	lb.AddUser(User{ID: "u1", Score: 10})
+	if rank, err := lb.GetApproximateRank("u1"); err != nil || rank != 0 {
+		t.Errorf("expected rank 0 for single user, got %d (err: %v)", rank, err)
+	}
+}
+
+func TestGetApproximateRankTies(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", ApproxRankTTL: time.Hour})
+	defer lb.Close()
+
+	// 100 ranked users above 900 tied at 0
+	const n = 1000
+	users := make([]User, n)
+	for i := range users {
+		users[i] = User{ID: fmt.Sprintf("u%03d", i)}
+		if i < 100 {
+			users[i].Score = float64(1000 - i)
+		}
+	}
+	if err := lb.AddUsers(users, nil); err != nil {
+		t.Fatalf("AddUsers: %v", err)
+	}
+
+	maxErr := n / rankBuckets
+	for _, id := range []string{"u050", "u100", "u500", "u999"} {
+		score, _ := lb.GetUserScore(id)
+		exact, _ := lb.RankAtScore(score)
+		approx, err := lb.GetApproximateRank(id)
+		if err != nil {
+			t.Fatalf("GetApproximateRank(%s): %v", id, err)
+		}
+		if d := approx - int(exact); d > maxErr || d < -maxErr {
+			t.Errorf("%s: approximate rank %d too far from %d", id, approx, exact)
+		}
+	}
+}
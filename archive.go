@@ -0,0 +1,119 @@
+package redisboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrArchiveExists is returned when archiving under a label already taken.
+var ErrArchiveExists = errors.New("archive already exists")
+
+// validateArchiveLabel checks an archive label: labels are key segments,
+// so they follow the entity code rules.
+func (lb *Leaderboard) validateArchiveLabel(label string) error {
+	if label == "" || lb.validateEntity(label) != nil {
+		return fmt.Errorf("invalid archive label %q", label)
+	}
+	return nil
+}
+
+// ArchiveTopK stores the current global top n users, with entity,
+// metadata and name, under label, e.g. "2024-w12" for a week's final
+// standings. ResetScores keeps archives; only
+// ForceClearLeaderBoardWithNamespacePrefix deletes them. The users are
+// ordered like GetTopKGlobal; an empty board archives no users.
+// Returns error if:
+// - label is invalid or empty
+// - n is not positive, or above Config.MaxReadSize (ErrTooManyUsers)
+// - label is already archived (ErrArchiveExists)
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) ArchiveTopK(label string, n int) (err error) {
+	defer lb.startOp("ArchiveTopK", "", "").end(&err)
+	if err := lb.validateArchiveLabel(label); err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("invalid archive size %d", n)
+	}
+	if n > lb.config.MaxReadSize {
+		return fmt.Errorf("%w: archive of %d users exceeds %d", ErrTooManyUsers, n, lb.config.MaxReadSize)
+	}
+	if err := lb.unpartitioned(); err != nil {
+		return err
+	}
+
+	members, err := lb.client.ZRevRangeWithScores(lb.ctx, lb.globalKey(), 0, int64(n-1)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to fetch top users: %w", err)
+	}
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
+		return fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	lb.sortTies(users)
+	if users == nil {
+		users = []User{}
+	}
+	data, err := json.Marshal(users)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive: %w", err)
+	}
+
+	ok, err := lb.client.SetNX(lb.ctx, lb.archiveKey(label), data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrArchiveExists, label)
+	}
+	return nil
+}
+
+// ResetScoresWithArchive archives the top n users under label with
+// ArchiveTopK, then resets the board with ResetScores(keepMembers). The
+// board is left untouched if archiving fails, so final standings are never
+// lost to a reset.
+// Not atomic: writes between the archive and the reset are reset but not
+// archived.
+// Returns error if:
+// - archiving fails, as for ArchiveTopK
+// - the reset fails, as for ResetScores (the archive is kept)
+func (lb *Leaderboard) ResetScoresWithArchive(keepMembers bool, label string, n int) (err error) {
+	defer lb.startOp("ResetScoresWithArchive", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if err := lb.ArchiveTopK(label, n); err != nil {
+		return err
+	}
+	return lb.ResetScores(keepMembers)
+}
+
+// GetArchivedTopK returns the users archived under label by ArchiveTopK,
+// in their archived order.
+// Returns error if:
+// - label is invalid or empty
+// - nothing is archived under label
+// - Redis operation fails
+func (lb *Leaderboard) GetArchivedTopK(label string) (_ []User, err error) {
+	defer lb.startOp("GetArchivedTopK", "", "").end(&err)
+	if err := lb.validateArchiveLabel(label); err != nil {
+		return nil, err
+	}
+	data, err := lb.reader.Get(lb.ctx, lb.archiveKey(label)).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("no archive %q", label)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archive: %w", err)
+	}
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode archive %q: %w", label, err)
+	}
+	return users, nil
+}
@@ -0,0 +1,81 @@
+package redisboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResetScoresWithArchive(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10, Metadata: map[string]string{"avatar": "a.png"}})
+	lb.AddUser(User{ID: "u2", Entity: "IN", Score: 30})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 20})
+	lb.AddUser(User{ID: "u4", Entity: "IN", Score: 5})
+
+	users, err := lb.GetTopKGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := users[:3]
+
+	if err := lb.ResetScoresWithArchive(false, "week1", 3); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := lb.GetAll(); len(all) != 0 {
+		t.Errorf("expected an empty board after the reset, got %v", all)
+	}
+	got, err := lb.GetArchivedTopK("week1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected archive %v, got %v", want, got)
+	}
+
+	// the archive survives later resets and is never overwritten
+	lb.AddUser(User{ID: "u5", Score: 1})
+	if err := lb.ResetScoresWithArchive(true, "week1", 3); !errors.Is(err, ErrArchiveExists) {
+		t.Errorf("expected ErrArchiveExists, got %v", err)
+	}
+	if score, _ := lb.GetUserScore("u5"); score != 1 {
+		t.Errorf("expected a failed archive to skip the reset, got score %v", score)
+	}
+	if err := lb.ResetScores(false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := lb.GetArchivedTopK("week1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected archive to survive ResetScores, got %v", got)
+	}
+
+	// an empty board archives no users
+	if err := lb.ArchiveTopK("week2", 3); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := lb.GetArchivedTopK("week2"); err != nil || len(got) != 0 {
+		t.Errorf("expected an empty archive, got %v, %v", got, err)
+	}
+}
+
+func TestArchiveTopKInvalid(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if err := lb.ArchiveTopK("", 3); err == nil {
+		t.Error("expected empty label to be rejected")
+	}
+	if err := lb.ArchiveTopK("a:b", 3); err == nil {
+		t.Error("expected label with separator to be rejected")
+	}
+	if err := lb.ArchiveTopK("week1", 0); err == nil {
+		t.Error("expected non-positive size to be rejected")
+	}
+	if err := lb.ArchiveTopK("week1", lb.config.MaxReadSize+1); !errors.Is(err, ErrTooManyUsers) {
+		t.Errorf("expected ErrTooManyUsers, got %v", err)
+	}
+	if _, err := lb.GetArchivedTopK("missing"); err == nil {
+		t.Error("expected missing archive to fail")
+	}
+}
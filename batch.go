@@ -0,0 +1,341 @@
+package redisboard
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ScoreUpdate is a single score change applied by IncrementScores.
+type ScoreUpdate struct {
+	UserID string  // user identifier
+	Entity string  // user's entity (same semantics as IncrementScore)
+	Delta  float64 // amount to add (negative to subtract)
+}
+
+// BatchError reports the operations of a batch that failed, keyed by their
+// index in the input. Operations not listed were applied.
+type BatchError struct {
+	Errors map[int]error
+}
+
+// Error summarizes the failures, quoting the first failed operation.
+func (e *BatchError) Error() string {
+	first := firstIndex(e)
+	return fmt.Sprintf("%d batch operations failed (first %d: %v)", len(e.Errors), first, e.Errors[first])
+}
+
+// firstIndex returns the lowest failed index of e.
+func firstIndex(e *BatchError) int {
+	first := -1
+	for i := range e.Errors {
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+	return first
+}
+
+// IncrementScores applies many score increments in pipelines of
+// Config.BatchSize updates, e.g. scoring
+// every player of a match at once. Updates are validated and rounded like
+// IncrementScore (integers when FloatScores=false) and honor StrictEntity
+// and TrackActivity; zero deltas are skipped. Unlike IncrementScore, they
+// bypass RequireExistingUser (absent users are created at the delta),
+// TrackBestRank, PublishRankChanges and CoalesceInterval (applied right
+// away).
+// Invalid updates are skipped; the others are still applied.
+// Returns *BatchError listing failed updates by index if:
+// - user ID is empty
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - delta exceeds 2^53 with FloatScores=false (ErrScoreOverflow)
+// - delta is NaN or infinite (ErrInvalidScore)
+// - entity is invalid (ErrInvalidEntity)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - the user has another entity with StrictEntity (ErrEntityMismatch)
+// - Redis operation fails
+func (lb *Leaderboard) IncrementScores(updates []ScoreUpdate) (err error) {
+	defer lb.startOp("IncrementScores", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	defer lb.topKCache.invalidate()
+
+	failed := make(map[int]error)
+	for start := 0; start < len(updates); start += lb.config.BatchSize {
+		end := min(start+lb.config.BatchSize, len(updates))
+
+		mismatched, err := lb.checkEntities(updates[start:end])
+		if err != nil {
+			for i := start; i < end; i++ {
+				failed[i] = err
+			}
+			continue
+		}
+
+		pipe := lb.client.Pipeline()
+		cmds := make(map[int][]redis.Cmder)
+		for i := start; i < end; i++ {
+			u := updates[i]
+			u.Entity = lb.normalizeEntity(u.Entity)
+			if err := mismatched[i-start]; err != nil {
+				failed[i] = err
+				continue
+			}
+			if u.UserID == "" {
+				failed[i] = fmt.Errorf("invalid user ID or score increment")
+				continue
+			}
+			if err := lb.validateUserID(u.UserID); err != nil {
+				failed[i] = err
+				continue
+			}
+			if u.Delta == 0 {
+				continue
+			}
+			if err := lb.validateEntity(u.Entity); err != nil {
+				failed[i] = err
+				continue
+			}
+
+			delta, err := lb.normalizeScore(u.Delta)
+			if err != nil {
+				failed[i] = err
+				continue
+			}
+			if err := lb.admitEntity(u.Entity); err != nil {
+				failed[i] = err
+				continue
+			}
+			cmds[i] = append(cmds[i],
+				lb.setEntity(pipe, u.UserID, u.Entity),
+				pipe.ZIncrBy(lb.ctx, lb.userGlobalKey(u.UserID), delta, lb.memberFor(u.UserID, u.Entity)),
+			)
+			if u.Entity != "" {
+				cmds[i] = append(cmds[i], lb.entityWrite(pipe, entityIncr, u.Entity, u.UserID, delta))
+			}
+			if cmd := lb.touch(pipe, u.UserID); cmd != nil {
+				cmds[i] = append(cmds[i], cmd)
+			}
+		}
+		if len(cmds) == 0 {
+			continue
+		}
+
+		// Exec reports only the first failure, so attribute each command's error
+		_, _ = pipe.Exec(lb.ctx)
+		for i, updateCmds := range cmds {
+			for _, cmd := range updateCmds {
+				if err := cmd.Err(); err != nil {
+					failed[i] = fmt.Errorf("failed to increment score: %w", conflictErr(err))
+					break
+				}
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Errors: failed}
+	}
+	return nil
+}
+
+// AddUsers creates or updates many users in pipelines of Config.BatchSize
+// users, e.g. seeding a board. Each user behaves like AddUser. Users whose
+// rankings are capped, and every user with a Sharder, PublishRankChanges
+// or TrackBestRank, are written by AddUser itself, one round-trip each.
+// If progress is not nil, it is called after each chunk with the number of
+// users handled so far and len(users).
+// Invalid users are skipped; the others are still applied.
+// Returns *BatchError listing failed users by index if:
+// - user ID is empty
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - score is invalid (see AddUser)
+// - entity is invalid (ErrInvalidEntity)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - metadata can't be encoded
+// - any AddUser error for users written one at a time
+// - Redis operation fails
+func (lb *Leaderboard) AddUsers(users []User, progress func(done, total int)) (err error) {
+	defer lb.startOp("AddUsers", "", "").end(&err)
+	defer lb.topKCache.invalidate()
+
+	failed := make(map[int]error)
+	for start := 0; start < len(users); start += lb.config.BatchSize {
+		end := min(start+lb.config.BatchSize, len(users))
+
+		pipe := lb.client.Pipeline()
+		cmds := make(map[int][]redis.Cmder)
+		for i := start; i < end; i++ {
+			u := users[i]
+			if lb.perUserWrites() || lb.config.TrackBestRank || lb.capped(u.Entity) {
+				if err := lb.AddUser(u); err != nil {
+					failed[i] = err
+				}
+				continue
+			}
+			userCmds, err := lb.queueAdd(pipe, u)
+			if err != nil {
+				failed[i] = err
+				continue
+			}
+			cmds[i] = userCmds
+		}
+
+		if len(cmds) > 0 {
+			// Exec reports only the first failure, so attribute each command's error
+			_, _ = pipe.Exec(lb.ctx)
+			for i, userCmds := range cmds {
+				for _, cmd := range userCmds {
+					if err := cmd.Err(); err != nil {
+						failed[i] = fmt.Errorf("failed to add user: %w", conflictErr(err))
+						break
+					}
+				}
+			}
+		}
+		if progress != nil {
+			progress(end, len(users))
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Errors: failed}
+	}
+	return nil
+}
+
+// queueAdd validates u like AddUser and queues its uncapped writes on pipe,
+// returning the queued commands.
+func (lb *Leaderboard) queueAdd(pipe redis.Pipeliner, u User) ([]redis.Cmder, error) {
+	u.Entity = lb.normalizeEntity(u.Entity)
+	if err := finiteScore(u.Score); err != nil {
+		return nil, err
+	}
+	if u.ID == "" || !lb.validScore(u.Score) {
+		return nil, fmt.Errorf("invalid user ID or score")
+	}
+	if err := lb.validateUserID(u.ID); err != nil {
+		return nil, err
+	}
+	if err := lb.validateEntity(u.Entity); err != nil {
+		return nil, err
+	}
+	score, err := lb.normalizeScore(u.Score)
+	if err != nil {
+		return nil, err
+	}
+	if err := lb.admitEntity(u.Entity); err != nil {
+		return nil, err
+	}
+	var meta []byte
+	if lb.config.EnableMetadata && len(u.Metadata) > 0 {
+		meta, err = json.Marshal(u.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+	}
+
+	cmds := []redis.Cmder{
+		lb.setEntity(pipe, u.ID, u.Entity),
+		pipe.ZAdd(lb.ctx, lb.userGlobalKey(u.ID), redis.Z{Score: score, Member: lb.memberFor(u.ID, u.Entity)}),
+	}
+	if u.Entity != "" {
+		cmds = append(cmds, lb.entityWrite(pipe, entitySet, u.Entity, u.ID, score))
+	}
+	if meta != nil {
+		cmds = append(cmds, pipe.HSet(lb.ctx, lb.metaKey(), u.ID, meta))
+	}
+	if lb.config.EnableNames && u.Name != "" {
+		cmds = append(cmds, pipe.HSet(lb.ctx, lb.namesKey(), u.ID, u.Name))
+	}
+	if cmd := lb.touch(pipe, u.ID); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return cmds, nil
+}
+
+// RemoveUsers deletes many users from all rankings in pipelines of
+// Config.BatchSize users. Each removal behaves like RemoveUser; with a
+// Sharder, users are removed by RemoveUser itself, one round-trip each.
+// If progress is not nil, it is called after each chunk with the number of
+// users handled so far and len(userIDs).
+// Returns *BatchError listing failed users by index if:
+// - user ID is empty
+// - Redis operation fails
+func (lb *Leaderboard) RemoveUsers(userIDs []string, progress func(done, total int)) (err error) {
+	defer lb.startOp("RemoveUsers", "", "").end(&err)
+	defer lb.topKCache.invalidate()
+
+	failed := make(map[int]error)
+	for start := 0; start < len(userIDs); start += lb.config.BatchSize {
+		end := min(start+lb.config.BatchSize, len(userIDs))
+		if err := lb.removeChunk(userIDs, start, end, failed); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(end, len(userIDs))
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Errors: failed}
+	}
+	return nil
+}
+
+// removeChunk removes userIDs[start:end], recording failures by index.
+// Returns error if the users' entities can't be fetched.
+func (lb *Leaderboard) removeChunk(userIDs []string, start, end int, failed map[int]error) error {
+	var ids []string
+	var indexes []int
+	for i := start; i < end; i++ {
+		switch {
+		case userIDs[i] == "":
+			failed[i] = fmt.Errorf("invalid user ID")
+		case lb.config.Sharder != nil:
+			if err := lb.RemoveUser(userIDs[i]); err != nil {
+				failed[i] = err
+			}
+		default:
+			ids = append(ids, userIDs[i])
+			indexes = append(indexes, i)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pipe := lb.client.Pipeline()
+	entitiesCmd := pipe.HMGet(lb.ctx, lb.entitiesKey(), ids...)
+	metricsCmd := pipe.SMembers(lb.ctx, lb.metricsKey())
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return fmt.Errorf("failed to get user entities: %w", err)
+	}
+
+	pipe = lb.client.Pipeline()
+	cmds := make([][]redis.Cmder, len(ids))
+	for j, id := range ids {
+		entity, _ := entitiesCmd.Val()[j].(string)
+		cmds[j] = lb.queueRemove(pipe, pipe, id, entity, metricsCmd.Val())
+	}
+	// Exec reports only the first failure, so attribute each command's error
+	_, _ = pipe.Exec(lb.ctx)
+	for j, userCmds := range cmds {
+		for _, cmd := range userCmds {
+			if err := cmd.Err(); err != nil {
+				failed[indexes[j]] = fmt.Errorf("failed to remove user: %w", err)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// perUserWrites reports whether bulk writes must go through AddUser and
+// RemoveUser one user at a time: entity rankings routed to other backends
+// and published rank changes need per-user round-trips.
+func (lb *Leaderboard) perUserWrites() bool {
+	return lb.config.Sharder != nil || lb.config.PublishRankChanges
+}
@@ -0,0 +1,83 @@
+package redisboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIncrementScores(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	err := lb.IncrementScores([]ScoreUpdate{
+		{UserID: "u1", Entity: "US", Delta: 10.7},
+		{UserID: "u2", Entity: "UK", Delta: 5},
+		{UserID: "", Entity: "US", Delta: 1},
+		{UserID: "u3", Entity: "bad entity", Delta: 1},
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if len(batchErr.Errors) != 2 || batchErr.Errors[2] == nil || !errors.Is(batchErr.Errors[3], ErrInvalidEntity) {
+		t.Errorf("unexpected batch errors: %v", batchErr.Errors)
+	}
+
+	// FloatScores=false rounds deltas like IncrementScore
+	if score, err := lb.GetUserScore("u1"); err != nil || score != 110 {
+		t.Errorf("expected u1 score 110, got %f, err: %v", score, err)
+	}
+	if rank, err := lb.GetRankEntity("u2"); err != nil || rank != 0 {
+		t.Errorf("expected u2 entity rank 0, got %d, err: %v", rank, err)
+	}
+	if _, err := lb.GetUserScore("u3"); err == nil {
+		t.Error("expected invalid update to be skipped")
+	}
+}
+
+func TestAddUsersRemoveUsers(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", BatchSize: 2, EnableNames: true})
+	defer lb.Close()
+
+	var calls [][2]int
+	progress := func(done, total int) { calls = append(calls, [2]int{done, total}) }
+	err := lb.AddUsers([]User{
+		{ID: "u1", Entity: "US", Score: 10, Name: "Ann"},
+		{ID: "u2", Entity: "UK", Score: 20},
+		{ID: "", Score: 5},
+		{ID: "u4", Entity: "bad entity", Score: 1},
+		{ID: "u5", Score: 50},
+	}, progress)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || !errors.Is(batchErr.Errors[3], ErrInvalidEntity) {
+		t.Fatalf("expected failures at 2 and 3, got %v", err)
+	}
+	// 5 users in chunks of 2
+	if want := [][2]int{{2, 5}, {4, 5}, {5, 5}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected progress %v, got %v", want, calls)
+	}
+	if top, _ := lb.GetTopKGlobal(); len(top) != 3 || top[0].ID != "u5" {
+		t.Errorf("unexpected ranking %+v", top)
+	}
+	if data, err := lb.GetUserLeaderboardData("u1"); err != nil || data.EntityRank != 0 || data.Name != "Ann" {
+		t.Errorf("unexpected u1 data: %+v, %v", data, err)
+	}
+
+	calls = nil
+	if err := lb.RemoveUsers([]string{"u1", "u2", "u5"}, progress); err != nil {
+		t.Fatalf("RemoveUsers: %v", err)
+	}
+	if want := [][2]int{{2, 3}, {3, 3}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected progress %v, got %v", want, calls)
+	}
+	if top, _ := lb.GetTopKGlobal(); len(top) != 0 {
+		t.Errorf("expected empty ranking, got %+v", top)
+	}
+	if rank, _ := lb.GetRankEntity("u1"); rank != -1 {
+		t.Errorf("expected u1 removed from its entity, got rank %d", rank)
+	}
+}
@@ -0,0 +1,77 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// bestRankScript stores the user's current global rank as their best if
+// they have none or it improved, returning the current rank (-1 if not
+// ranked).
+// KEYS[1]: global ranking, KEYS[2]: best ranks; ARGV[1]: member, ARGV[2]:
+// user ID.
+const bestRankScript = `
+local rank = redis.call('ZREVRANK', KEYS[1], ARGV[1])
+if not rank then
+	return -1
+end
+local best = tonumber(redis.call('HGET', KEYS[2], ARGV[2]))
+if not best or rank < best then
+	redis.call('HSET', KEYS[2], ARGV[2], rank)
+end
+return rank
+`
+
+// recordBestRank updates the user's best rank after a write. No-op without
+// TrackBestRank.
+func (lb *Leaderboard) recordBestRank(userID string) error {
+	if !lb.config.TrackBestRank {
+		return nil
+	}
+	member, err := lb.resolveMember(lb.client, userID)
+	if err != nil {
+		return err
+	}
+	keys := []string{lb.globalKey(), lb.bestRanksKey()}
+	if _, err := lb.evalScript(bestRankScript, keys, member, userID); err != nil {
+		return fmt.Errorf("failed to record best rank: %w", conflictErr(err))
+	}
+	return nil
+}
+
+// afterWrite records the user's best rank (TrackBestRank) and publishes
+// their rank change (PublishRankChanges) after a single-user write.
+func (lb *Leaderboard) afterWrite(userID string, before int) error {
+	if err := lb.recordBestRank(userID); err != nil {
+		return err
+	}
+	return lb.publishRankChange(userID, before)
+}
+
+// GetBestRank returns the best (lowest) global rank the user ever held,
+// 0-based like GetRankGlobal, e.g. 6 for "your best rank ever: #7".
+// Needs TrackBestRank: the rank is recorded after each AddUser (also
+// through AddUsers and SeedRandom), AddUserWithOption, IncrementScore and
+// DecrementScore of the user, so other bulk writes (IncrementScores,
+// Batch, Import) and climbs caused by other users dropping aren't seen
+// until the user's next write. RemoveUser and ResetScores(false) forget
+// it.
+// Returns -1 if no rank was recorded for the user.
+// Returns error if:
+// - TrackBestRank is unset
+// - Redis operation fails
+func (lb *Leaderboard) GetBestRank(userID string) (_ int, err error) {
+	defer lb.startOp("GetBestRank", userID, "").end(&err)
+	if !lb.config.TrackBestRank {
+		return -1, fmt.Errorf("best rank tracking is disabled")
+	}
+	best, err := lb.reader.HGet(lb.ctx, lb.bestRanksKey(), userID).Int()
+	if err == redis.Nil {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("failed to get best rank: %w", err)
+	}
+	return best, nil
+}
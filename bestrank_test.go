@@ -0,0 +1,57 @@
+package redisboard
+
+import "testing"
+
+func TestGetBestRank(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", TrackBestRank: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 50})
+	lb.AddUser(User{ID: "u3", Entity: "IN", Score: 10})
+	if best, err := lb.GetBestRank("u3"); err != nil || best != 2 {
+		t.Errorf("expected best rank 2, got %d, %v", best, err)
+	}
+
+	// u3 climbs to first, then drops to last: the best rank stays
+	lb.IncrementScore("u3", "IN", 60)
+	lb.IncrementScore("u3", "IN", 100)
+	if rank, _ := lb.GetRankGlobal("u3"); rank != 0 {
+		t.Fatalf("expected u3 first, got %d", rank)
+	}
+	lb.DecrementScore("u3", "IN", 165)
+	if rank, _ := lb.GetRankGlobal("u3"); rank != 2 {
+		t.Fatalf("expected u3 last, got %d", rank)
+	}
+	if best, err := lb.GetBestRank("u3"); err != nil || best != 0 {
+		t.Errorf("expected best rank 0 after the drop, got %d, %v", best, err)
+	}
+	if best, _ := lb.GetBestRank("u1"); best != 0 {
+		t.Errorf("expected u1's best rank 0, got %d", best)
+	}
+
+	lb.RemoveUser("u3")
+	if best, err := lb.GetBestRank("u3"); err != nil || best != -1 {
+		t.Errorf("expected removed user without best rank, got %d, %v", best, err)
+	}
+	lb.ResetScores(false)
+	if best, _ := lb.GetBestRank("u1"); best != -1 {
+		t.Errorf("expected reset to clear best ranks, got %d", best)
+	}
+}
+
+func TestGetBestRankDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 10})
+	if _, err := lb.GetBestRank("u1"); err == nil {
+		t.Error("expected GetBestRank to fail without TrackBestRank")
+	}
+	if n, _ := lb.client.Exists(lb.ctx, lb.bestRanksKey()).Result(); n != 0 {
+		t.Error("expected no best ranks without TrackBestRank")
+	}
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, TrackBestRank: true, CoalesceInterval: 1}); err == nil {
+		t.Error("expected TrackBestRank with CoalesceInterval to be rejected")
+	}
+}
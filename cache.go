@@ -0,0 +1,110 @@
+package redisboard
+
+import (
+	"sync"
+	"time"
+)
+
+// topKCache is an optional in-process cache of top-k results.
+// Entries expire after ttl and are dropped on every write made through the
+// owning Leaderboard. Writes from other processes are only seen once the
+// entry expires, so reads are at most ttl stale.
+// A nil *topKCache is valid and caches nothing.
+type topKCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]topKEntry
+}
+
+// topKEntry is a cached top-k result with its expiry time.
+type topKEntry struct {
+	users   []User
+	expires time.Time
+}
+
+// newTopKCache returns a cache with the given TTL, or nil if ttl <= 0.
+func newTopKCache(ttl time.Duration) *topKCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &topKCache{ttl: ttl, entries: make(map[string]topKEntry)}
+}
+
+// get returns a copy of the cached users for key if present and fresh.
+func (c *topKCache) get(key string) ([]User, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return append([]User(nil), entry.users...), true
+}
+
+// set caches a copy of users under key.
+func (c *topKCache) set(key string, users []User) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = topKEntry{
+		users:   append([]User(nil), users...),
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+// invalidate drops every cached entry.
+func (c *topKCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// pinnedTopK caches the GetTopKGlobalCached result until invalidated or,
+// if ttl > 0, expired. The generation, bumped on every invalidation, keeps
+// a fetch started before an invalidation from caching its stale result.
+type pinnedTopK struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	generation uint64
+	users      []User // nil: nothing cached
+	expires    time.Time
+}
+
+// get returns a copy of the cached users if present and fresh, and the
+// generation to pass to set after fetching them otherwise.
+func (c *pinnedTopK) get() ([]User, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.users == nil || (c.ttl > 0 && time.Now().After(c.expires)) {
+		return nil, c.generation, false
+	}
+	return append([]User(nil), c.users...), c.generation, true
+}
+
+// set caches a copy of users fetched during generation, unless the cache
+// was invalidated since.
+func (c *pinnedTopK) set(generation uint64, users []User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.users = append([]User{}, users...)
+	c.expires = time.Now().Add(c.ttl)
+}
+
+// invalidate drops the cached users.
+func (c *pinnedTopK) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.users = nil
+}
@@ -0,0 +1,193 @@
+package redisboard
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// countingHook counts commands sent to Redis.
+type countingHook struct {
+	calls atomic.Int64
+}
+
+func (h *countingHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *countingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.calls.Add(1)
+		return next(ctx, cmd)
+	}
+}
+
+func (h *countingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.calls.Add(int64(len(cmds)))
+		return next(ctx, cmds)
+	}
+}
+
+func TestTopKCacheTTL(t *testing.T) {
+	ttl := 100 * time.Millisecond
+	lb := newTestLeaderboard(t, Config{Namespace: "test", TopKCacheTTL: ttl})
+	defer lb.Close()
+	writer := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer writer.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if _, err := lb.GetTopKGlobal(); err != nil {
+		t.Fatalf("GetTopKGlobal: %v", err)
+	}
+	cachedAt := time.Now()
+
+	// a write from another instance is not seen until the entry expires
+	writer.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	for {
+		topK, err := lb.GetTopKGlobal()
+		if err != nil {
+			t.Fatalf("GetTopKGlobal: %v", err)
+		}
+		if topK[0].ID == "u2" {
+			break
+		}
+		if time.Since(cachedAt) > ttl+50*time.Millisecond {
+			t.Fatalf("stale top-k served beyond TTL: %+v", topK)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if time.Since(cachedAt) < ttl/2 {
+		t.Error("expected cached result to be served within TTL")
+	}
+}
+
+func TestTopKCacheUserLeaderboardData(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", TopKCacheTTL: time.Minute})
+	defer lb.Close()
+	writer := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer writer.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if _, err := lb.GetUserLeaderboardData("u1"); err != nil {
+		t.Fatalf("GetUserLeaderboardData: %v", err)
+	}
+
+	// within the TTL the top-k lists are shared, the user's rows are fresh
+	writer.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	writer.IncrementScore("u1", "US", 5)
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil {
+		t.Fatalf("GetUserLeaderboardData: %v", err)
+	}
+	if data.Score != 105 || data.GlobalRank != 1 || data.EntityRank != 1 {
+		t.Errorf("expected fresh user rows, got %+v", data)
+	}
+	if len(data.TopKGlobal) != 1 || len(data.TopKEntity) != 1 || data.TopKGlobal[0].Score != 100 {
+		t.Errorf("expected cached top-k, got %+v / %+v", data.TopKGlobal, data.TopKEntity)
+	}
+	if topK, _ := lb.GetTopKGlobal(); len(topK) != 1 {
+		t.Errorf("expected GetTopKGlobal to share the cache, got %+v", topK)
+	}
+
+	// a write through lb drops the shared entries
+	lb.IncrementScore("u1", "US", 1)
+	if data, _ := lb.GetUserLeaderboardData("u1"); len(data.TopKGlobal) != 2 || data.TopKGlobal[0].ID != "u2" {
+		t.Errorf("expected fresh top-k after write, got %+v", data.TopKGlobal)
+	}
+}
+
+func TestTopKCacheInvalidatedOnWrite(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", TopKCacheTTL: time.Minute})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.GetTopKEntity("US")
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+
+	topK, err := lb.GetTopKEntity("US")
+	if err != nil || len(topK) != 2 || topK[0].ID != "u2" {
+		t.Errorf("expected fresh top-k after write, got %+v, err: %v", topK, err)
+	}
+}
+
+func BenchmarkGetTopKGlobalCache(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{"uncached", 0},
+		{"cached", time.Second},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			lb, err := New(Config{Namespace: "bench", RedisAddr: testRedisAddr, TopKCacheTTL: bc.ttl})
+			if err != nil {
+				b.Skipf("redis unavailable: %v", err)
+			}
+			defer lb.Close()
+			lb.ForceClearLeaderBoardWithNamespacePrefix()
+			for i := 0; i < 100; i++ {
+				lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)})
+			}
+
+			hook := &countingHook{}
+			rawClient(lb).AddHook(hook)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := lb.GetTopKGlobal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(hook.calls.Load())/float64(b.N), "redis-cmds/op")
+		})
+	}
+}
+
+func TestGetTopKGlobalCached(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	topK, err := lb.GetTopKGlobalCached()
+	if err != nil || len(topK) != 1 {
+		t.Fatalf("GetTopKGlobalCached: %+v, %v", topK, err)
+	}
+	topK[0].ID = "modified" // callers get a copy
+
+	// writes, even through lb, don't drop the cached result
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	topK, _ = lb.GetTopKGlobalCached()
+	if len(topK) != 1 || topK[0].ID != "u1" {
+		t.Fatalf("expected cached top-k, got %+v", topK)
+	}
+
+	lb.InvalidateCache()
+	topK, _ = lb.GetTopKGlobalCached()
+	if len(topK) != 2 || topK[0].ID != "u2" {
+		t.Fatalf("expected fresh top-k after InvalidateCache, got %+v", topK)
+	}
+
+	// a fetch started before an invalidation isn't cached
+	_, generation, _ := lb.pinnedTopK.get()
+	lb.InvalidateCache()
+	lb.pinnedTopK.set(generation, topK[:1])
+	if _, _, ok := lb.pinnedTopK.get(); ok {
+		t.Error("expected a result fetched before InvalidateCache to be dropped")
+	}
+}
+
+func TestGetTopKGlobalCachedTTL(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	lb := newTestLeaderboard(t, Config{Namespace: "test", CachedTopKTTL: ttl})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.GetTopKGlobalCached()
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	time.Sleep(ttl + 20*time.Millisecond)
+	if topK, _ := lb.GetTopKGlobalCached(); len(topK) != 2 {
+		t.Errorf("expected refetch after CachedTopKTTL, got %+v", topK)
+	}
+}
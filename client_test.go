@@ -0,0 +1,43 @@
+package redisboard
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	_ RedisClient = (*redis.Client)(nil)
+	_ RedisClient = (*redis.ClusterClient)(nil)
+	_ RedisClient = (*redis.Ring)(nil)
+	_ RedisClient = redis.UniversalClient(nil)
+)
+
+func TestMiniredisClient(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	lb, err := New(Config{Namespace: "test", Client: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 30})
+	if err := lb.IncrementScore("u1", "US", 25); err != nil {
+		t.Fatal(err)
+	}
+	if rank, err := lb.GetRankEntity("u1"); err != nil || rank != 0 {
+		t.Errorf("expected u1 first in US, got %d, %v", rank, err)
+	}
+	top, err := lb.GetTopKGlobal()
+	if err != nil || len(top) != 2 || top[0].ID != "u1" || top[0].Score != 35 {
+		t.Errorf("expected u1 on top with 35, got %+v, %v", top, err)
+	}
+	if !mr.Exists("test:global") {
+		t.Error("expected the global ranking in miniredis")
+	}
+}
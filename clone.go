@@ -0,0 +1,197 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNamespaceExists is returned by Clone when the destination namespace
+// already holds keys and overwrite was not requested.
+var ErrNamespaceExists = errors.New("namespace already exists")
+
+// Clone copies every key of the leaderboard (rankings, entity mapping,
+// metadata, metric boards, ...) to destNamespace, e.g. to mirror a board
+// for A/B testing or before a new season. A season's keys are copied to
+// the same season of destNamespace; the default season copies the named
+// seasons too, like ForceClearLeaderBoardWithNamespacePrefix clears them.
+// Keys are copied server-side with COPY (Redis 6.2+). Where COPY fails
+// (older Redis, or keys in different Redis Cluster slots) a key is copied
+// with chunked SCAN reads and writes instead.
+// If destNamespace already holds keys, overwrite must be true; its keys
+// are then deleted before copying.
+// Not atomic: writes during the clone may be missing from the copy.
+// Returns error if:
+// - destNamespace is empty, the leaderboard's own namespace or nested in
+// it (e.g., "game1" and "game1:copy")
+// - destNamespace holds keys and overwrite is false (ErrNamespaceExists)
+// - Redis operation fails
+func (lb *Leaderboard) Clone(destNamespace string, overwrite bool) (err error) {
+	defer lb.startOp("Clone", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if destNamespace == "" {
+		return fmt.Errorf("invalid destination namespace")
+	}
+
+	cfg := lb.config
+	cfg.Namespace = destNamespace
+	dest := &Leaderboard{config: cfg}
+	srcPrefix, destPrefix := lb.keyPrefix(), dest.keyPrefix()
+	// a namespace nested in the other would scan (or clear) the other's keys
+	sep := lb.config.KeySeparator
+	if strings.HasPrefix(destPrefix+sep, srcPrefix+sep) || strings.HasPrefix(srcPrefix+sep, destPrefix+sep) {
+		return fmt.Errorf("invalid destination namespace %q: overlaps %s", destNamespace, lb.config.Namespace)
+	}
+
+	existing, err := lb.scanKeys(dest.keyPattern())
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		if !overwrite {
+			return fmt.Errorf("%w: %s", ErrNamespaceExists, destNamespace)
+		}
+		for start := 0; start < len(existing); start += lb.config.BatchSize {
+			end := min(start+lb.config.BatchSize, len(existing))
+			if err := lb.client.Del(lb.ctx, existing[start:end]...).Err(); err != nil {
+				return fmt.Errorf("failed to clear namespace %s: %w", destNamespace, err)
+			}
+		}
+	}
+
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.Scan(lb.ctx, cursor, lb.keyPattern(), int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		pipe := lb.client.Pipeline()
+		cmds := make([]*redis.Cmd, len(keys))
+		for i, key := range keys {
+			cmds[i] = pipe.Do(lb.ctx, "copy", key, destPrefix+strings.TrimPrefix(key, srcPrefix), "replace")
+		}
+		if len(keys) > 0 {
+			_, _ = pipe.Exec(lb.ctx) // failures fall back per key below
+		}
+		for i, cmd := range cmds {
+			if cmd.Err() == nil {
+				continue
+			}
+			if err := lb.copyKey(keys[i], destPrefix+strings.TrimPrefix(keys[i], srcPrefix)); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// scanKeys returns every key matching pattern.
+func (lb *Leaderboard) scanKeys(pattern string) ([]string, error) {
+	var keys []string
+	iter := lb.client.Scan(lb.ctx, 0, pattern, int64(lb.config.BatchSize)).Iterator()
+	for iter.Next(lb.ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan keys: %w", err)
+	}
+	return keys, nil
+}
+
+// copyKey copies src to dst client-side, in chunks for sorted sets, hashes
+// and sets, keeping src's expiry. A src deleted meanwhile is skipped.
+func (lb *Leaderboard) copyKey(src, dst string) error {
+	typ, err := lb.client.Type(lb.ctx, src).Result()
+	if err != nil {
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if typ == "none" {
+		return nil
+	}
+	if err := lb.client.Del(lb.ctx, dst).Err(); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	var cursor uint64
+	for {
+		var keys []string
+		var next uint64
+		switch typ {
+		case "zset":
+			keys, next, err = lb.client.ZScan(lb.ctx, src, cursor, "", int64(lb.config.BatchSize)).Result()
+		case "hash":
+			keys, next, err = lb.client.HScan(lb.ctx, src, cursor, "", int64(lb.config.BatchSize)).Result()
+		case "set":
+			keys, next, err = lb.client.SScan(lb.ctx, src, cursor, "", int64(lb.config.BatchSize)).Result()
+		case "string":
+			var val string
+			val, err = lb.client.Get(lb.ctx, src).Result()
+			if err == nil {
+				err = lb.client.Set(lb.ctx, dst, val, 0).Err()
+			}
+		default:
+			return fmt.Errorf("failed to copy %s: unsupported type %s", src, typ)
+		}
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, err)
+		}
+
+		if len(keys) > 0 {
+			if err := lb.writeChunk(typ, dst, keys); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", src, err)
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	ttl, err := lb.client.PTTL(lb.ctx, src).Result()
+	if err != nil {
+		return fmt.Errorf("failed to copy %s expiry: %w", src, err)
+	}
+	if ttl > 0 {
+		if err := lb.client.PExpire(lb.ctx, dst, ttl).Err(); err != nil {
+			return fmt.Errorf("failed to copy %s expiry: %w", src, err)
+		}
+	}
+	return nil
+}
+
+// writeChunk writes a SCAN batch of a key of type typ to dst.
+func (lb *Leaderboard) writeChunk(typ, dst string, keys []string) error {
+	switch typ {
+	case "zset":
+		members := make([]redis.Z, 0, len(keys)/2)
+		for i := 0; i+1 < len(keys); i += 2 {
+			score, err := strconv.ParseFloat(keys[i+1], 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse score of %s: %w", keys[i], err)
+			}
+			members = append(members, redis.Z{Score: score, Member: keys[i]})
+		}
+		return lb.client.ZAdd(lb.ctx, dst, members...).Err()
+	case "hash":
+		return lb.client.HSet(lb.ctx, dst, keys).Err()
+	default:
+		members := make([]any, len(keys))
+		for i, k := range keys {
+			members[i] = k
+		}
+		return lb.client.SAdd(lb.ctx, dst, members...).Err()
+	}
+}
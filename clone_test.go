@@ -0,0 +1,56 @@
+package redisboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true})
+	defer lb.Close()
+	dst := newTestLeaderboard(t, Config{Namespace: "test2", EnableMetadata: true})
+	defer dst.Close()
+	defer dst.ForceClearLeaderBoardWithNamespacePrefix()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"name": "Ann"}})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 150})
+	lb.AddUserMetric("u1", "US", "kills", 7)
+
+	hook := &failKeyHook{disabled: true}
+	rawClient(lb).AddHook(hook)
+	// second pass fails every COPY to exercise the chunked fallback
+	for _, copyFails := range []bool{false, true} {
+		hook.disabled = !copyFails
+		if err := lb.Clone("test2", copyFails); err != nil {
+			t.Fatalf("Clone (copyFails=%v): %v", copyFails, err)
+		}
+		hook.disabled = true
+
+		want, _ := lb.GetTopKGlobal()
+		if got, err := dst.GetTopKGlobal(); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("copyFails=%v: expected top-k %+v, got %+v, %v", copyFails, want, got, err)
+		}
+		data, err := dst.GetUserLeaderboardData("u1")
+		if err != nil || data.EntityRank != 1 || data.Metadata["name"] != "Ann" || len(data.TopKEntity) != 2 {
+			t.Errorf("copyFails=%v: unexpected u1 data %+v, %v", copyFails, data, err)
+		}
+		if top, _ := dst.GetTopKMetric("kills"); len(top) != 1 || top[0].Score != 7 {
+			t.Errorf("copyFails=%v: expected metric board copied, got %+v", copyFails, top)
+		}
+	}
+
+	if err := lb.Clone("test2", false); !errors.Is(err, ErrNamespaceExists) {
+		t.Errorf("expected ErrNamespaceExists, got %v", err)
+	}
+	for _, ns := range []string{"", "test", "test:copy"} {
+		if err := lb.Clone(ns, true); err == nil {
+			t.Errorf("Clone(%q): expected error", ns)
+		}
+	}
+	// the source is untouched
+	if top, _ := lb.GetTopKGlobal(); len(top) != 3 {
+		t.Errorf("expected source intact, got %+v", top)
+	}
+}
@@ -0,0 +1,129 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrClosed is returned by operations on a closed leaderboard, or once its
+// Redis connection was closed, instead of go-redis' "client is closed".
+var ErrClosed = errors.New("leaderboard closed")
+
+// closeState tracks a leaderboard's Redis commands in flight, so Close can
+// wait for them before closing the connections and refuse new ones.
+type closeState struct {
+	mu       sync.Mutex
+	idle     *sync.Cond
+	closed   bool
+	inFlight int
+}
+
+// newCloseState returns the state of an open leaderboard.
+func newCloseState() *closeState {
+	s := &closeState{}
+	s.idle = sync.NewCond(&s.mu)
+	return s
+}
+
+// enter registers a command in flight, reporting false once closed.
+func (s *closeState) enter() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.inFlight++
+	return true
+}
+
+// exit unregisters a command entered before.
+func (s *closeState) exit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight--
+	if s.inFlight == 0 {
+		s.idle.Broadcast()
+	}
+}
+
+// close refuses new commands and waits for those in flight.
+func (s *closeState) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for s.inFlight > 0 {
+		s.idle.Wait()
+	}
+}
+
+// closeStateKey is the context key of the closeState of the leaderboard
+// issuing a command.
+type closeStateKey struct{}
+
+// withCloseState returns ctx carrying lb's closeState, for operations
+// running on a caller's context rather than lb.ctx.
+func (lb *Leaderboard) withCloseState(ctx context.Context) context.Context {
+	return context.WithValue(ctx, closeStateKey{}, lb.closed)
+}
+
+// closeHook fails commands of closed leaderboards with ErrClosed and counts
+// the others in flight. It is added to the clients New dials; a caller's
+// Config.Client is wrapped in a closeGuard instead. Seasons share their
+// parent's connection, so the leaderboard is found through the command's
+// context; commands without one pass through untouched.
+type closeHook struct{}
+
+func (closeHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (closeHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		state, ok := ctx.Value(closeStateKey{}).(*closeState)
+		if !ok {
+			return next(ctx, cmd)
+		}
+		if !state.enter() {
+			cmd.SetErr(ErrClosed)
+			return ErrClosed
+		}
+		defer state.exit()
+		return closedErr(cmd, next(ctx, cmd))
+	}
+}
+
+func (closeHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		state, ok := ctx.Value(closeStateKey{}).(*closeState)
+		if !ok {
+			return next(ctx, cmds)
+		}
+		if !state.enter() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrClosed)
+			}
+			return ErrClosed
+		}
+		defer state.exit()
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			closedErr(cmd, cmd.Err())
+		}
+		return closedErr(nil, err)
+	}
+}
+
+// closedErr replaces go-redis' redis.ErrClosed, for a connection closed by
+// its owner or a parent leaderboard, with ErrClosed, also on cmd if set.
+func closedErr(cmd redis.Cmder, err error) error {
+	if !errors.Is(err, redis.ErrClosed) {
+		return err
+	}
+	err = fmt.Errorf("%w: %v", ErrClosed, err)
+	if cmd != nil {
+		cmd.SetErr(err)
+	}
+	return err
+}
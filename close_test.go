@@ -0,0 +1,109 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCloseDuringOperations(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 1000)
+	started := make(chan struct{})
+	var once sync.Once
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprintf("u%d", g*100+i)
+				err := lb.IncrementScore(id, "US", 1)
+				if err == nil {
+					_, err = lb.GetUserLeaderboardData(id)
+				}
+				once.Do(func() { close(started) })
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(g)
+	}
+	<-started
+	if err := lb.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+	}
+
+	if err := lb.AddUser(User{ID: "late", Score: 1}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+	if _, err := lb.GetTopKGlobal(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestCloseSharedClient(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+	season, err := lb.Season("2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// closing a season leaves its parent usable
+	season.Close()
+	if err := season.AddUser(User{ID: "u1", Score: 1}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed on closed season, got %v", err)
+	}
+	if err := lb.AddUser(User{ID: "u1", Score: 1}); err != nil {
+		t.Errorf("expected parent to stay open, got %v", err)
+	}
+
+	// a season whose parent closed the connection reports ErrClosed too
+	season, _ = lb.Season("2025")
+	defer season.Close()
+	lb.Close()
+	if _, err := season.GetUserScore("u1"); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed once the connection is closed, got %v", err)
+	}
+}
+
+func TestCloseCallerClient(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: testRedisAddr})
+	lb := newTestLeaderboard(t, Config{Namespace: "test", Client: client})
+	other := newTestLeaderboard(t, Config{Namespace: "other", Client: client})
+	defer other.Close()
+
+	lb.Close()
+	if err := lb.IncrementScore("u1", "US", 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+	if _, err := lb.GetUserScore("u1"); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+	if err := other.AddUser(User{ID: "u1", Entity: "US", Score: 1}); err != nil {
+		t.Errorf("expected a board sharing the client to stay open, got %v", err)
+	}
+	// the client is wrapped, not hooked: its own commands pass, even on the
+	// closed board's context
+	if err := client.Ping(lb.ctx).Err(); err != nil {
+		t.Errorf("expected the caller's client unaffected, got %v", err)
+	}
+
+	client.Close()
+	if _, err := other.GetTopKGlobal(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed once the caller closed the client, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package redisboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// closeGuard runs a caller's Config.Client under the leaderboard's close
+// state, like closeHook on the clients New dials. go-redis hooks can't be
+// removed, so the caller's client is wrapped instead of hooked: boards and
+// seasons built on one client don't pile hooks on it, and its other users'
+// commands pass untouched. The leaderboard is found through the command's
+// context, as in closeHook. Commands queued on a pipeline are checked at
+// Exec, and WATCH transactions as a whole.
+type closeGuard struct {
+	RedisClient
+}
+
+// guardCmd runs a command with ctx's closeState: it fails with ErrClosed
+// once the leaderboard is closed and counts in flight otherwise.
+func guardCmd[T any, C interface {
+	*T
+	redis.Cmder
+}](ctx context.Context, run func() C) C {
+	state, ok := ctx.Value(closeStateKey{}).(*closeState)
+	if !ok {
+		return run()
+	}
+	if !state.enter() {
+		cmd := C(new(T))
+		cmd.SetErr(ErrClosed)
+		return cmd
+	}
+	defer state.exit()
+	cmd := run()
+	closedErr(cmd, cmd.Err())
+	return cmd
+}
+
+func (c closeGuard) Pipeline() redis.Pipeliner {
+	return closeGuardPipeline{c.RedisClient.Pipeline()}
+}
+
+func (c closeGuard) Watch(ctx context.Context, fn func(*redis.Tx) error, keys ...string) error {
+	state, ok := ctx.Value(closeStateKey{}).(*closeState)
+	if !ok {
+		return c.RedisClient.Watch(ctx, fn, keys...)
+	}
+	if !state.enter() {
+		return ErrClosed
+	}
+	defer state.exit()
+	return closedErr(nil, c.RedisClient.Watch(ctx, fn, keys...))
+}
+
+func (c closeGuard) Ping(ctx context.Context) *redis.StatusCmd {
+	return guardCmd(ctx, func() *redis.StatusCmd { return c.RedisClient.Ping(ctx) })
+}
+
+func (c closeGuard) Type(ctx context.Context, key string) *redis.StatusCmd {
+	return guardCmd(ctx, func() *redis.StatusCmd { return c.RedisClient.Type(ctx, key) })
+}
+
+func (c closeGuard) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.Exists(ctx, keys...) })
+}
+
+func (c closeGuard) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.Del(ctx, keys...) })
+}
+
+func (c closeGuard) Rename(ctx context.Context, key, newkey string) *redis.StatusCmd {
+	return guardCmd(ctx, func() *redis.StatusCmd { return c.RedisClient.Rename(ctx, key, newkey) })
+}
+
+func (c closeGuard) Keys(ctx context.Context, pattern string) *redis.StringSliceCmd {
+	return guardCmd(ctx, func() *redis.StringSliceCmd { return c.RedisClient.Keys(ctx, pattern) })
+}
+
+func (c closeGuard) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return guardCmd(ctx, func() *redis.ScanCmd { return c.RedisClient.Scan(ctx, cursor, match, count) })
+}
+
+func (c closeGuard) PExpire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	return guardCmd(ctx, func() *redis.BoolCmd { return c.RedisClient.PExpire(ctx, key, expiration) })
+}
+
+func (c closeGuard) PTTL(ctx context.Context, key string) *redis.DurationCmd {
+	return guardCmd(ctx, func() *redis.DurationCmd { return c.RedisClient.PTTL(ctx, key) })
+}
+
+func (c closeGuard) Get(ctx context.Context, key string) *redis.StringCmd {
+	return guardCmd(ctx, func() *redis.StringCmd { return c.RedisClient.Get(ctx, key) })
+}
+
+func (c closeGuard) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	return guardCmd(ctx, func() *redis.StatusCmd { return c.RedisClient.Set(ctx, key, value, expiration) })
+}
+
+func (c closeGuard) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return guardCmd(ctx, func() *redis.BoolCmd { return c.RedisClient.SetNX(ctx, key, value, expiration) })
+}
+
+func (c closeGuard) HGet(ctx context.Context, key, field string) *redis.StringCmd {
+	return guardCmd(ctx, func() *redis.StringCmd { return c.RedisClient.HGet(ctx, key, field) })
+}
+
+func (c closeGuard) HMGet(ctx context.Context, key string, fields ...string) *redis.SliceCmd {
+	return guardCmd(ctx, func() *redis.SliceCmd { return c.RedisClient.HMGet(ctx, key, fields...) })
+}
+
+func (c closeGuard) HSet(ctx context.Context, key string, values ...interface{}) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.HSet(ctx, key, values...) })
+}
+
+func (c closeGuard) HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.HDel(ctx, key, fields...) })
+}
+
+func (c closeGuard) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return guardCmd(ctx, func() *redis.ScanCmd { return c.RedisClient.HScan(ctx, key, cursor, match, count) })
+}
+
+func (c closeGuard) SAdd(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.SAdd(ctx, key, members...) })
+}
+
+func (c closeGuard) SCard(ctx context.Context, key string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.SCard(ctx, key) })
+}
+
+func (c closeGuard) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	return guardCmd(ctx, func() *redis.StringSliceCmd { return c.RedisClient.SMembers(ctx, key) })
+}
+
+func (c closeGuard) SScan(ctx context.Context, key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return guardCmd(ctx, func() *redis.ScanCmd { return c.RedisClient.SScan(ctx, key, cursor, match, count) })
+}
+
+func (c closeGuard) ZAdd(ctx context.Context, key string, members ...redis.Z) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZAdd(ctx, key, members...) })
+}
+
+func (c closeGuard) ZAddXX(ctx context.Context, key string, members ...redis.Z) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZAddXX(ctx, key, members...) })
+}
+
+func (c closeGuard) ZRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZRem(ctx, key, members...) })
+}
+
+func (c closeGuard) ZCard(ctx context.Context, key string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZCard(ctx, key) })
+}
+
+func (c closeGuard) ZCount(ctx context.Context, key, min, max string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZCount(ctx, key, min, max) })
+}
+
+func (c closeGuard) ZScore(ctx context.Context, key, member string) *redis.FloatCmd {
+	return guardCmd(ctx, func() *redis.FloatCmd { return c.RedisClient.ZScore(ctx, key, member) })
+}
+
+func (c closeGuard) ZRevRank(ctx context.Context, key, member string) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.ZRevRank(ctx, key, member) })
+}
+
+func (c closeGuard) ZRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd {
+	return guardCmd(ctx, func() *redis.StringSliceCmd { return c.RedisClient.ZRange(ctx, key, start, stop) })
+}
+
+func (c closeGuard) ZRangeWithScores(ctx context.Context, key string, start, stop int64) *redis.ZSliceCmd {
+	return guardCmd(ctx, func() *redis.ZSliceCmd { return c.RedisClient.ZRangeWithScores(ctx, key, start, stop) })
+}
+
+func (c closeGuard) ZRevRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd {
+	return guardCmd(ctx, func() *redis.StringSliceCmd { return c.RedisClient.ZRevRange(ctx, key, start, stop) })
+}
+
+func (c closeGuard) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) *redis.ZSliceCmd {
+	return guardCmd(ctx, func() *redis.ZSliceCmd { return c.RedisClient.ZRevRangeWithScores(ctx, key, start, stop) })
+}
+
+func (c closeGuard) ZRangeByScore(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd {
+	return guardCmd(ctx, func() *redis.StringSliceCmd { return c.RedisClient.ZRangeByScore(ctx, key, opt) })
+}
+
+func (c closeGuard) ZRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd {
+	return guardCmd(ctx, func() *redis.ZSliceCmd { return c.RedisClient.ZRangeByScoreWithScores(ctx, key, opt) })
+}
+
+func (c closeGuard) ZRevRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd {
+	return guardCmd(ctx, func() *redis.ZSliceCmd { return c.RedisClient.ZRevRangeByScoreWithScores(ctx, key, opt) })
+}
+
+func (c closeGuard) ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	return guardCmd(ctx, func() *redis.ScanCmd { return c.RedisClient.ZScan(ctx, key, cursor, match, count) })
+}
+
+func (c closeGuard) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	return guardCmd(ctx, func() *redis.Cmd { return c.RedisClient.EvalSha(ctx, sha1, keys, args...) })
+}
+
+func (c closeGuard) ScriptLoad(ctx context.Context, script string) *redis.StringCmd {
+	return guardCmd(ctx, func() *redis.StringCmd { return c.RedisClient.ScriptLoad(ctx, script) })
+}
+
+func (c closeGuard) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	return guardCmd(ctx, func() *redis.IntCmd { return c.RedisClient.Publish(ctx, channel, message) })
+}
+
+// closeGuardPipeline checks the close state of a closeGuard pipeline when it
+// is sent. The commands queued on a closed leaderboard's pipeline are
+// discarded unsent; Exec reports ErrClosed.
+type closeGuardPipeline struct {
+	redis.Pipeliner
+}
+
+func (p closeGuardPipeline) Exec(ctx context.Context) ([]redis.Cmder, error) {
+	state, ok := ctx.Value(closeStateKey{}).(*closeState)
+	if !ok {
+		return p.Pipeliner.Exec(ctx)
+	}
+	if !state.enter() {
+		p.Discard()
+		return nil, ErrClosed
+	}
+	defer state.exit()
+	cmds, err := p.Pipeliner.Exec(ctx)
+	for _, cmd := range cmds {
+		closedErr(cmd, cmd.Err())
+	}
+	return cmds, closedErr(nil, err)
+}
@@ -0,0 +1,151 @@
+package redisboard
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// coalescer buffers IncrementScore/DecrementScore deltas client-side,
+// summing them per user and entity, so a user scored dozens of times per
+// second costs one ZINCRBY per flush instead of one round trip per call.
+type coalescer struct {
+	mu      sync.Mutex
+	index   map[pendingKey]int // position of each user/entity in updates
+	updates []ScoreUpdate      // summed deltas, in first-seen order
+
+	running   bool // flush loop started
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// pendingKey identifies a buffered delta.
+type pendingKey struct {
+	userID, entity string
+}
+
+// newCoalescer returns an empty buffer, or nil if interval <= 0.
+func newCoalescer(interval time.Duration) *coalescer {
+	if interval <= 0 {
+		return nil
+	}
+	return &coalescer{
+		index: make(map[pendingKey]int),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// start launches the flush loop in the background.
+func (c *coalescer) start(lb *Leaderboard, interval time.Duration) {
+	c.running = true
+	go c.run(lb, interval)
+}
+
+// add buffers delta for userID in entity.
+func (c *coalescer) add(userID, entity string, delta float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := pendingKey{userID, entity}
+	if i, ok := c.index[k]; ok {
+		c.updates[i].Delta += delta
+		return
+	}
+	c.index[k] = len(c.updates)
+	c.updates = append(c.updates, ScoreUpdate{UserID: userID, Entity: entity, Delta: delta})
+}
+
+// take empties the buffer and returns its deltas.
+func (c *coalescer) take() []ScoreUpdate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	updates := c.updates
+	c.updates = nil
+	clear(c.index)
+	return updates
+}
+
+// run flushes the buffer every interval until close. Transient failures
+// stay buffered for the next flush (see Flush); every failure is logged, as
+// nobody else sees the error.
+func (c *coalescer) run(lb *Leaderboard, interval time.Duration) {
+	defer close(c.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if err := lb.Flush(); err != nil {
+				lb.config.Logger.Warn("coalesced flush failed", "namespace", lb.config.Namespace, "error", err)
+			}
+		}
+	}
+}
+
+// close stops the flush loop, if running, and waits for it to exit.
+func (c *coalescer) close() {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+		if c.running {
+			<-c.done
+		}
+	})
+}
+
+// Flush writes the increments buffered with Config.CoalesceInterval to
+// Redis as one IncrementScores batch. Runs every CoalesceInterval and on
+// Close; call it directly where pending deltas must be visible (e.g., at
+// the end of a match). No-op without CoalesceInterval.
+// Deltas failing on network errors, timeouts or Redis replies worth a retry
+// (e.g., LOADING) are kept buffered for the next flush; those that would
+// fail again (invalid deltas, ErrScoreOverflow, ErrTooManyEntities,
+// ErrNamespaceConflict, ...) are dropped.
+// Returns error (*BatchError below the OpError) if any delta failed, kept
+// or dropped.
+func (lb *Leaderboard) Flush() (err error) {
+	defer lb.startOp("Flush", "", "").end(&err)
+	if lb.coalescer == nil {
+		return nil
+	}
+	updates := lb.coalescer.take()
+	if len(updates) == 0 {
+		return nil
+	}
+
+	err = lb.IncrementScores(updates)
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		for i, updateErr := range batchErr.Errors {
+			if transientErr(updateErr) {
+				u := updates[i]
+				lb.coalescer.add(u.UserID, u.Entity, u.Delta)
+			}
+		}
+	}
+	return err
+}
+
+// transientErr reports whether a failed write may succeed when retried:
+// network failures and timeouts, and the Redis replies go-redis retries
+// itself (LOADING, READONLY, CLUSTERDOWN, TRYAGAIN). Validation errors and
+// other replies (e.g., WRONGTYPE) never will.
+func transientErr(err error) bool {
+	var replyErr redis.Error
+	if errors.As(err, &replyErr) {
+		for _, prefix := range []string{"LOADING ", "READONLY ", "CLUSTERDOWN ", "TRYAGAIN "} {
+			if strings.HasPrefix(replyErr.Error(), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	var timeout interface{ Timeout() bool } // net.Error, context.DeadlineExceeded, pool timeouts
+	return errors.As(err, &timeout) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
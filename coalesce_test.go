@@ -0,0 +1,99 @@
+package redisboard
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCoalesceIncrements(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", CoalesceInterval: time.Hour})
+	defer lb.Close()
+
+	hook := &countingHook{}
+	rawClient(lb).AddHook(hook)
+	for i := 0; i < 50; i++ {
+		lb.IncrementScore("u1", "US", 3)
+		lb.DecrementScore("u1", "US", 1)
+		lb.IncrementScore("u2", "", 1)
+	}
+	if calls := hook.calls.Load(); calls != 0 {
+		t.Errorf("expected increments to be buffered, got %d commands", calls)
+	}
+	if _, err := lb.GetUserScore("u1"); err == nil {
+		t.Error("expected buffered increments to be invisible before Flush")
+	}
+
+	if err := lb.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 100 {
+		t.Errorf("expected u1 summed to 100, got %v", score)
+	}
+	if score, _ := lb.GetUserScore("u2"); score != 50 {
+		t.Errorf("expected u2 summed to 50, got %v", score)
+	}
+	if rank, _ := lb.GetRankEntity("u1"); rank != 0 {
+		t.Errorf("expected u1 ranked in US, got %d", rank)
+	}
+	if err := lb.Flush(); err != nil {
+		t.Errorf("expected empty Flush to succeed, got %v", err)
+	}
+}
+
+func TestCoalesceFlushLoopAndClose(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", CoalesceInterval: 20 * time.Millisecond})
+	defer lb.Close()
+	slow := newTestLeaderboard(t, Config{Namespace: "test", CoalesceInterval: time.Hour})
+
+	lb.IncrementScore("u1", "", 5)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if score, err := lb.GetUserScore("u1"); err == nil && score == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected background flush within a second")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Close flushes what is still pending
+	slow.IncrementScore("u1", "", 7)
+	if err := slow.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 12 {
+		t.Errorf("expected Close to flush pending delta, got %v", score)
+	}
+}
+
+func TestCoalesceFlushFailures(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", CoalesceInterval: time.Hour})
+	defer lb.Close()
+	rawClient(lb).Set(lb.ctx, lb.entityKey("EU"), "not a ranking", 0)
+
+	lb.IncrementScore("u1", "EU", 1)
+	lb.IncrementScore("u2", "US", 2)
+
+	// a dropped connection keeps every delta for the next flush
+	hook := &failKeyHook{err: io.ErrUnexpectedEOF}
+	rawClient(lb).AddHook(hook)
+	if err := lb.Flush(); err == nil {
+		t.Fatal("expected Flush to fail while Redis is unreachable")
+	}
+	hook.disabled = true
+
+	// WRONGTYPE on the EU ranking would fail forever, so that delta is dropped
+	var batchErr *BatchError
+	if err := lb.Flush(); !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[0], ErrNamespaceConflict) {
+		t.Fatalf("expected only u1's delta to fail with ErrNamespaceConflict, got %v", err)
+	}
+	if score, err := lb.GetUserScore("u2"); err != nil || score != 2 {
+		t.Errorf("expected u2's retried delta applied, got %v, %v", score, err)
+	}
+	if err := lb.Flush(); err != nil {
+		t.Errorf("expected the conflicting delta dropped, got %v", err)
+	}
+}
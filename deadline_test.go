@@ -0,0 +1,127 @@
+package redisboard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stallingRedis listens on a random port and answers the connection
+// handshake and PING like Redis, but never replies to other commands, like
+// a server stuck on a slow script.
+func stallingRedis(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveStalling(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveStalling(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		switch strings.ToUpper(args[0]) {
+		case "HELLO":
+			conn.Write([]byte("-ERR unknown command 'HELLO'\r\n"))
+		case "PING":
+			conn.Write([]byte("+PONG\r\n"))
+		case "CLIENT":
+			conn.Write([]byte("+OK\r\n"))
+		case "TYPE":
+			conn.Write([]byte("+none\r\n"))
+		}
+		// anything else stalls
+	}
+}
+
+// readCommand reads one RESP array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		size, err := readLength(r)
+		if err != nil {
+			return nil, err
+		}
+		arg := make([]byte, size+2) // trailing \r\n
+		if _, err := io.ReadFull(r, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:size])
+	}
+	return args, nil
+}
+
+// readLength reads a RESP "*n" or "$n" header line.
+func readLength(r *bufio.Reader) (int, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimRight(line[1:], "\r\n"))
+}
+
+func TestBatchExecHonorsDeadline(t *testing.T) {
+	lb, err := New(Config{Namespace: "test", RedisAddr: stallingRedis(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lb.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = lb.NewBatch().AddUser(User{ID: "u1", Score: 1}).Exec(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Exec to return at the deadline, took %v", elapsed)
+	}
+}
+
+func TestStreamTopKGlobalHonorsDeadline(t *testing.T) {
+	lb, err := New(Config{Namespace: "test", RedisAddr: stallingRedis(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lb.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	users, errs := lb.StreamTopKGlobal(ctx)
+	for range users {
+	}
+	if err := <-errs; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the stream to stop at the deadline, took %v", elapsed)
+	}
+}
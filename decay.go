@@ -0,0 +1,164 @@
+package redisboard
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// validateDecayFactor accepts factors in (0, 1]: 1 keeps every score.
+func validateDecayFactor(factor float64) error {
+	if math.IsNaN(factor) || factor <= 0 || factor > 1 {
+		return fmt.Errorf("invalid decay factor %v", factor)
+	}
+	return nil
+}
+
+// ApplyDecay multiplies every score of the global and entity rankings by
+// factor, e.g. 0.99 daily so active players stay on top of an engagement
+// board. Decayed scores are rounded like written ones (truncated with
+// FloatScores=false). Entity totals are rebuilt afterwards; metric boards
+// are left untouched.
+// Costs one ZSCAN batch and one pipelined ZADD XX per Config.BatchSize
+// members of each ranking, so the whole board is read and rewritten:
+// O(N log N) on Redis and one in-memory set of the members of the ranking
+// being decayed.
+// Schedule it off-peak on large boards, or use Config.DecayInterval.
+// Not atomic across members: readers see a partly decayed board meanwhile,
+// and increments landing between a member's read and rewrite are lost.
+// Users removed concurrently are not re-added.
+// Returns error if:
+// - factor is not in (0, 1]
+// - entities are sharded (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) ApplyDecay(factor float64) (err error) {
+	defer lb.startOp("ApplyDecay", "", "").end(&err)
+	if err := validateDecayFactor(factor); err != nil {
+		return err
+	}
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if factor == 1 {
+		return nil
+	}
+	defer lb.topKCache.invalidate()
+
+	entityKeys, err := lb.scanKeys(lb.entityKey("") + "*")
+	if err != nil {
+		return err
+	}
+	for _, key := range append(lb.globalKeys(), entityKeys...) {
+		if err := lb.decayScores(key, factor); err != nil {
+			return err
+		}
+	}
+	if lb.config.EntityTotals {
+		return lb.RebuildEntityTotals()
+	}
+	return nil
+}
+
+// decayScores multiplies every score of the ranking at key by factor, one
+// ZSCAN batch per pipeline. ZSCAN may return a member twice, so decayed
+// members are remembered; ZADD XX never re-adds members removed since the
+// scan.
+func (lb *Leaderboard) decayScores(key string, factor float64) error {
+	decayed := make(map[string]struct{})
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.ZScan(lb.ctx, key, cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", key, err)
+		}
+		members := make([]redis.Z, 0, len(keys)/2)
+		for i := 0; i+1 < len(keys); i += 2 {
+			if _, ok := decayed[keys[i]]; ok {
+				continue
+			}
+			decayed[keys[i]] = struct{}{}
+			score, err := strconv.ParseFloat(keys[i+1], 64)
+			if err != nil {
+				return fmt.Errorf("invalid score %q in %s: %w", keys[i+1], key, err)
+			}
+			score, err = lb.normalizeScore(score * factor)
+			if err != nil {
+				return err
+			}
+			members = append(members, redis.Z{Score: score, Member: keys[i]})
+		}
+		if len(members) > 0 {
+			if err := lb.client.ZAddXX(lb.ctx, key, members...).Err(); err != nil {
+				return fmt.Errorf("failed to decay %s: %w", key, conflictErr(err))
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// decayLoop applies Config.DecayFactor every Config.DecayInterval.
+type decayLoop struct {
+	interval time.Duration
+	factor   float64
+
+	running   bool // loop started
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newDecayLoop returns a decay loop, or nil if interval <= 0.
+func newDecayLoop(interval time.Duration, factor float64) *decayLoop {
+	if interval <= 0 {
+		return nil
+	}
+	return &decayLoop{
+		interval: interval,
+		factor:   factor,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// start launches the loop in the background.
+func (d *decayLoop) start(lb *Leaderboard) {
+	d.running = true
+	go d.run(lb)
+}
+
+// run decays the board every interval until close. Failures are logged and
+// not retried: a failed decay stops midway, leaving some rankings decayed,
+// and retrying would decay those twice.
+func (d *decayLoop) run(lb *Leaderboard) {
+	defer close(d.done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			if err := lb.ApplyDecay(d.factor); err != nil {
+				lb.config.Logger.Warn("score decay failed", "namespace", lb.config.Namespace, "error", err)
+			}
+		}
+	}
+}
+
+// close stops the loop, if running, and waits for a decay in progress.
+func (d *decayLoop) close() {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+		if d.running {
+			<-d.done
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package redisboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyDecay(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityTotals: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 50})
+	lb.AddUser(User{ID: "u3", Score: 7})
+
+	if err := lb.ApplyDecay(0.5); err != nil {
+		t.Fatal(err)
+	}
+	// integer scores are truncated like written ones
+	for id, want := range map[string]float64{"u1": 50, "u2": 25, "u3": 3} {
+		if score, err := lb.GetUserScore(id); err != nil || score != want {
+			t.Errorf("expected %s decayed to %v, got %v, %v", id, want, score, err)
+		}
+	}
+	top, err := lb.GetTopKEntity("US")
+	if err != nil || len(top) != 2 || top[0].Score != 50 || top[1].Score != 25 {
+		t.Errorf("expected US ranking decayed in sync, got %+v, %v", top, err)
+	}
+	if total, _ := lb.client.ZScore(lb.ctx, lb.entityTotalsKey(), "US").Result(); total != 75 {
+		t.Errorf("expected US total 75, got %v", total)
+	}
+	if report, _ := lb.Verify(); !report.OK() {
+		t.Errorf("expected consistent rankings, got %+v", report)
+	}
+
+	for _, factor := range []float64{0, -0.5, 1.5} {
+		if err := lb.ApplyDecay(factor); err == nil {
+			t.Errorf("expected factor %v to be rejected", factor)
+		}
+	}
+}
+
+func TestDecayInterval(t *testing.T) {
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, DecayInterval: time.Second}); err == nil {
+		t.Error("expected missing DecayFactor to be rejected")
+	}
+
+	lb := newTestLeaderboard(t, Config{Namespace: "test", FloatScores: true, DecayInterval: 20 * time.Millisecond, DecayFactor: 0.5})
+	lb.AddUser(User{ID: "u1", Score: 100})
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		score, err := lb.GetUserScore("u1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if score < 100 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the background loop to decay u1")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := lb.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
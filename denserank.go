@@ -0,0 +1,61 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetDenseRankGlobal returns a user's dense global rank: the number of
+// distinct scores above the user's (0-based, like GetRankGlobal), so tied
+// users share a rank and the next score takes the next rank. Scores
+// 100, 100, 90 rank 0, 0, 1, where GetRankGlobal gives the ordinal ranks
+// 0, 1, 2 in Redis' tie order. For competition ranks (0, 0, 2), use
+// RankAtScore with the user's score.
+// Redis can't count distinct scores, so the scores above the user are
+// read upwards in batches, each starting past the highest score of the
+// last: the cost grows with the number of users above, like a page of the
+// board per batch. Not a snapshot under concurrent writes.
+// Returns -1 if user not found.
+// Returns error if:
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) GetDenseRankGlobal(userID string) (_ int, err error) {
+	defer lb.startOp("GetDenseRankGlobal", userID, "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return -1, err
+	}
+	globalKey := lb.globalKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return -1, err
+	}
+	score, err := lb.reader.ZScore(lb.ctx, globalKey, member).Result()
+	if err == redis.Nil {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("failed to get user score: %w", err)
+	}
+
+	rank := 0
+	for {
+		page, err := lb.reader.ZRangeByScoreWithScores(lb.ctx, globalKey, &redis.ZRangeBy{
+			Min:   "(" + formatScore(score),
+			Max:   "+inf",
+			Count: int64(lb.config.BatchSize),
+		}).Result()
+		if err != nil {
+			return -1, fmt.Errorf("failed to fetch higher scores: %w", err)
+		}
+		for _, z := range page {
+			if z.Score != score {
+				rank++
+				score = z.Score
+			}
+		}
+		if len(page) < lb.config.BatchSize {
+			return rank, nil
+		}
+	}
+}
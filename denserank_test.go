@@ -0,0 +1,44 @@
+package redisboard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGetDenseRankGlobal(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	for id, score := range map[string]float64{"a": 100, "b": 100, "c": 90, "d": 80, "e": 80, "f": 70} {
+		lb.AddUser(User{ID: id, Score: score})
+	}
+	for id, want := range map[string]int{"a": 0, "b": 0, "c": 1, "d": 2, "e": 2, "f": 3, "missing": -1} {
+		if rank, err := lb.GetDenseRankGlobal(id); err != nil || rank != want {
+			t.Errorf("%s: expected dense rank %d, got %d, err: %v", id, want, rank, err)
+		}
+	}
+	// ordinal ranks stay distinct for tied users
+	ra, _ := lb.GetRankGlobal("a")
+	rb, _ := lb.GetRankGlobal("b")
+	if ra == rb {
+		t.Errorf("expected distinct ordinal ranks, got %d and %d", ra, rb)
+	}
+}
+
+func TestGetDenseRankGlobalBatches(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	// ties spanning batch boundaries are counted once
+	users := make([]User, 0, 2*batchSize+500)
+	for i := 0; i < cap(users); i++ {
+		users = append(users, User{ID: fmt.Sprintf("u%d", i), Score: float64(i % 7)})
+	}
+	if err := lb.AddUsers(users, nil); err != nil {
+		t.Fatal(err)
+	}
+	lb.AddUser(User{ID: "zero", Score: 0})
+	if rank, err := lb.GetDenseRankGlobal("zero"); err != nil || rank != 6 {
+		t.Errorf("expected dense rank 6, got %d, err: %v", rank, err)
+	}
+}
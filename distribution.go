@@ -0,0 +1,85 @@
+package redisboard
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetScoreDistribution counts users per score range across the global
+// ranking, e.g. to see how scores are spread. buckets are ascending
+// boundaries: each range runs from one boundary (inclusive) to the next
+// (exclusive), and the last boundary opens a final range to +inf. Counts are
+// keyed by labels like "100-200" and "500+"; users below the first boundary
+// are not counted.
+// Costs one ZCOUNT per range, all in a single pipeline.
+// Returns error if:
+// - buckets is empty or not strictly ascending
+// - Redis operation fails
+func (lb *Leaderboard) GetScoreDistribution(buckets []float64) (_ map[string]int64, err error) {
+	defer lb.startOp("GetScoreDistribution", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return nil, err
+	}
+	return lb.scoreDistribution(lb.reader, lb.globalKey(), buckets)
+}
+
+// GetScoreDistributionEntity is GetScoreDistribution within an entity.
+// Returns error if:
+// - entity is empty or invalid (ErrInvalidEntity)
+// - buckets is empty or not strictly ascending
+// - Redis operation fails
+func (lb *Leaderboard) GetScoreDistributionEntity(entity string, buckets []float64) (_ map[string]int64, err error) {
+	defer lb.startOp("GetScoreDistributionEntity", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if entity == "" {
+		return nil, fmt.Errorf("invalid entity")
+	}
+	if err := lb.validateEntity(entity); err != nil {
+		return nil, err
+	}
+	return lb.scoreDistribution(lb.entityReader(entity), lb.entityKey(entity), buckets)
+}
+
+// scoreDistribution counts members of key per range of buckets.
+func (lb *Leaderboard) scoreDistribution(c RedisCommands, key string, buckets []float64) (map[string]int64, error) {
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("no buckets")
+	}
+	for i, b := range buckets {
+		if math.IsNaN(b) || (i > 0 && b <= buckets[i-1]) {
+			return nil, fmt.Errorf("buckets must be strictly ascending")
+		}
+	}
+
+	pipe := c.Pipeline()
+	labels := make([]string, len(buckets))
+	cmds := make([]*redis.IntCmd, len(buckets))
+	for i, lo := range buckets {
+		min := formatScore(lo)
+		if i == len(buckets)-1 {
+			labels[i] = min + "+"
+			cmds[i] = pipe.ZCount(lb.ctx, key, min, "+inf")
+			continue
+		}
+		max := formatScore(buckets[i+1])
+		labels[i] = min + "-" + max
+		cmds[i] = pipe.ZCount(lb.ctx, key, min, "("+max)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return nil, fmt.Errorf("failed to count score ranges: %w", err)
+	}
+
+	counts := make(map[string]int64, len(buckets))
+	for i, cmd := range cmds {
+		counts[labels[i]] = cmd.Val()
+	}
+	return counts, nil
+}
+
+// formatScore formats a score as a ZCOUNT bound or bucket label.
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
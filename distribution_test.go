@@ -0,0 +1,44 @@
+package redisboard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetScoreDistribution(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", FloatScores: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 50})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 199.5})
+	lb.AddUser(User{ID: "u4", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u5", Score: 1000})
+
+	dist, err := lb.GetScoreDistribution([]float64{100, 200, 500})
+	if err != nil {
+		t.Fatalf("GetScoreDistribution: %v", err)
+	}
+	want := map[string]int64{"100-200": 2, "200-500": 1, "500+": 1}
+	if !reflect.DeepEqual(dist, want) {
+		t.Errorf("expected %v, got %v", want, dist)
+	}
+
+	dist, err = lb.GetScoreDistributionEntity("US", []float64{0, 100.5})
+	if err != nil {
+		t.Fatalf("GetScoreDistributionEntity: %v", err)
+	}
+	want = map[string]int64{"0-100.5": 2, "100.5+": 1}
+	if !reflect.DeepEqual(dist, want) {
+		t.Errorf("expected %v, got %v", want, dist)
+	}
+
+	for _, buckets := range [][]float64{nil, {200, 100}, {100, 100}} {
+		if _, err := lb.GetScoreDistribution(buckets); err == nil {
+			t.Errorf("expected error for buckets %v", buckets)
+		}
+	}
+	if _, err := lb.GetScoreDistributionEntity("", []float64{0}); err == nil {
+		t.Error("expected error for empty entity")
+	}
+}
@@ -0,0 +1,147 @@
+package redisboard
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// dumpRecord is one line of an Export dump.
+type dumpRecord struct {
+	ID       string            `json:"id"`
+	Entity   string            `json:"entity,omitempty"`
+	Score    float64           `json:"score"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Name     string            `json:"name,omitempty"`
+}
+
+// gzipMagic opens every gzip stream, letting Import detect compression.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Export writes every user of the leaderboard to w as newline-delimited
+// JSON, one {"id","entity","score","metadata","name"} object per line, for
+// backups and migrations between namespaces or clusters.
+// Streams the board like IterateUsers, so memory stays bounded; the dump is
+// not a consistent snapshot if writes run concurrently.
+// Returns error if:
+// - writing to w fails
+// - Redis operation fails
+func (lb *Leaderboard) Export(w io.Writer) (err error) {
+	defer lb.startOp("Export", "", "").end(&err)
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	err = lb.IterateUsers(func(u User) error {
+		rec := dumpRecord{ID: u.ID, Entity: u.Entity, Score: u.Score, Metadata: u.Metadata, Name: u.Name}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("failed to write user %s: %w", u.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write dump: %w", err)
+	}
+	return nil
+}
+
+// ExportCompressed is Export wrapped in gzip, which shrinks dumps of large
+// boards several times over. Import recognizes compressed dumps by their
+// gzip header, so both kinds are read the same way.
+// Returns error if:
+// - writing to w fails
+// - Redis operation fails
+func (lb *Leaderboard) ExportCompressed(w io.Writer) (err error) {
+	defer lb.startOp("ExportCompressed", "", "").end(&err)
+	zw := gzip.NewWriter(w)
+	if err := lb.Export(zw); err != nil {
+		zw.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write dump: %w", err)
+	}
+	return nil
+}
+
+// Import adds every user of a dump written by Export or ExportCompressed,
+// detecting gzip compression from the stream header. Users are written with
+// AddUsers in chunks of Config.BatchSize, so scores are normalized, caps
+// are enforced and existing users are overwritten.
+// Stops after the first chunk with a failing user; the chunk's other users
+// and the chunks before it stay imported.
+// Returns the number of imported users.
+// Returns error if:
+// - the dump is malformed
+// - any AddUser error (with the first failing user's line)
+func (lb *Leaderboard) Import(r io.Reader) (int, error) {
+	return lb.ImportWithProgress(r, nil)
+}
+
+// ImportWithProgress is Import calling progress, if not nil, after each
+// chunk with the number of users handled so far. A dump's length isn't
+// known in advance, so total is always -1.
+func (lb *Leaderboard) ImportWithProgress(r io.Reader, progress func(done, total int)) (_ int, err error) {
+	defer lb.startOp("Import", "", "").end(&err)
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read compressed dump: %w", err)
+		}
+		defer zr.Close()
+		src = zr
+	}
+
+	dec := json.NewDecoder(src)
+	n, line := 0, 0
+	chunk := make([]User, 0, lb.config.BatchSize)
+	flush := func() error {
+		err := lb.AddUsers(chunk, nil)
+		first := line - len(chunk) // line before the chunk's first user
+		n += len(chunk)
+		chunk = chunk[:0]
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			n -= len(batchErr.Errors)
+			i := firstIndex(batchErr)
+			return fmt.Errorf("failed to import line %d: %w", first+i+1, batchErr.Errors[i])
+		} else if err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(n, -1)
+		}
+		return nil
+	}
+	for {
+		var rec dumpRecord
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			if ferr := flush(); ferr != nil {
+				return n, ferr
+			}
+			return n, fmt.Errorf("invalid dump at line %d: %w", line+1, err)
+		}
+		line++
+		chunk = append(chunk, User{ID: rec.ID, Entity: rec.Entity, Score: rec.Score, Metadata: rec.Metadata, Name: rec.Name})
+		if len(chunk) == lb.config.BatchSize {
+			if err := flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		if err := flush(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
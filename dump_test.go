@@ -0,0 +1,89 @@
+package redisboard
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportImport(t *testing.T) {
+	src := newTestLeaderboard(t, Config{Namespace: "test", FloatScores: true, EnableMetadata: true})
+	defer src.Close()
+	dst := newTestLeaderboard(t, Config{Namespace: "test2", FloatScores: true, EnableMetadata: true})
+	defer dst.Close()
+	defer dst.ForceClearLeaderBoardWithNamespacePrefix()
+
+	src.AddUser(User{ID: "u1", Entity: "US", Score: 100.5, Metadata: map[string]string{"name": "Ann"}})
+	src.AddUser(User{ID: "u2", Score: 200})
+	src.AddUser(User{ID: "u3", Entity: "UK", Score: 300})
+
+	for _, compress := range []bool{false, true} {
+		dst.ForceClearLeaderBoardWithNamespacePrefix()
+
+		var buf bytes.Buffer
+		export := src.Export
+		if compress {
+			export = src.ExportCompressed
+		}
+		if err := export(&buf); err != nil {
+			t.Fatalf("export (compress=%v): %v", compress, err)
+		}
+		if got := bytes.HasPrefix(buf.Bytes(), gzipMagic); got != compress {
+			t.Errorf("compress=%v: gzip header present=%v", compress, got)
+		}
+
+		n, err := dst.Import(&buf)
+		if err != nil || n != 3 {
+			t.Fatalf("Import (compress=%v): %d, %v", compress, n, err)
+		}
+		data, err := dst.GetUserLeaderboardData("u1")
+		if err != nil || data.Score != 100.5 || data.Entity != "US" || data.Metadata["name"] != "Ann" {
+			t.Errorf("compress=%v: u1 not restored: %+v, %v", compress, data, err)
+		}
+		if top, _ := dst.GetTopKGlobal(); len(top) != 3 || top[0].ID != "u3" || top[0].Entity != "UK" {
+			t.Errorf("compress=%v: unexpected ranking %+v", compress, top)
+		}
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	dump := "{\"id\":\"u1\",\"score\":10}\n{\"id\":\"u2\",\"entity\":\"bad entity\",\"score\":5}\n"
+	n, err := lb.Import(strings.NewReader(dump))
+	if err == nil || n != 1 || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected failure at line 2 after one import, got %d, %v", n, err)
+	}
+	if _, err := lb.Import(strings.NewReader("not json")); err == nil {
+		t.Error("expected error for malformed dump")
+	}
+}
+
+func TestImportWithProgress(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", BatchSize: 2})
+	defer lb.Close()
+
+	var dump strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&dump, "{\"id\":\"u%d\",\"score\":%d}\n", i, i)
+	}
+	var done []int
+	n, err := lb.ImportWithProgress(strings.NewReader(dump.String()), func(d, total int) {
+		if total != -1 {
+			t.Errorf("expected unknown total, got %d", total)
+		}
+		done = append(done, d)
+	})
+	if err != nil || n != 5 {
+		t.Fatalf("ImportWithProgress: %d, %v", n, err)
+	}
+	if want := []int{2, 4, 5}; !reflect.DeepEqual(done, want) {
+		t.Errorf("expected progress %v, got %v", want, done)
+	}
+	if top, _ := lb.GetTopKGlobal(); len(top) != 5 || top[0].ID != "u4" {
+		t.Errorf("unexpected ranking %+v", top)
+	}
+}
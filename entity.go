@@ -0,0 +1,214 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrEntityExists is returned by RenameEntity when the target entity
+// already has members and merging was not requested.
+var ErrEntityExists = errors.New("entity already exists")
+
+// RenameEntity changes an entity code (e.g., "UK" -> "GB") for all its members.
+// Copies the entity ranking (and its metric boards) to the new code,
+// updates every member's entity mapping in chunked pipelines and deletes
+// the old keys. Scores are preserved.
+// If newCode already has members, merge must be true; colliding members are
+// combined per Config.EntityMergeAggregate (see MergeEntities).
+// With EntityCaseInsensitive, oldCode resolves as for MergeEntities.
+// Not atomic: writes to oldCode during the rename may be lost.
+// Returns error if:
+// - either code is empty or invalid (ErrInvalidEntity)
+// - newCode exists and merge is false (ErrEntityExists)
+// - Redis operation fails
+func (lb *Leaderboard) RenameEntity(oldCode, newCode string, merge bool) (err error) {
+	defer lb.startOp("RenameEntity", "", oldCode).end(&err)
+	newCode = lb.normalizeEntity(newCode)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if oldCode == "" || newCode == "" {
+		return fmt.Errorf("invalid entity")
+	}
+	if oldCode, err = lb.sourceEntity(oldCode); err != nil {
+		return err
+	}
+	if err := lb.validateEntity(newCode); err != nil {
+		return err
+	}
+	if oldCode == newCode {
+		return nil
+	}
+
+	if !merge {
+		newKey := lb.entityKey(newCode)
+		n, err := lb.client.Exists(lb.ctx, newKey).Result()
+		if err != nil {
+			return fmt.Errorf("failed to check entity %s: %w", newCode, err)
+		}
+		if n > 0 {
+			return fmt.Errorf("%w: %s", ErrEntityExists, newCode)
+		}
+	}
+	return lb.mergeEntities([]string{oldCode}, newCode)
+}
+
+// MergeEntities combines several entities (e.g., two guilds) into dest.
+// Unions the source rankings (and their metric boards) into dest, reassigns
+// every source member's entity mapping to dest and deletes the sources.
+// Members present in several sets are combined per
+// Config.EntityMergeAggregate: "MAX" (default) keeps the highest score, which
+// matches the member's global score; "SUM" adds the entity scores together,
+// so the entity score can then differ from the global score.
+// With EntityCaseInsensitive, dest is lowercased and sources resolve to
+// their lowercase ranking, unless one was stored in its given case before
+// the option was enabled.
+// Not atomic: writes to the sources during the merge may be lost.
+// Returns error if:
+// - no sources or dest is empty
+// - any entity is invalid (ErrInvalidEntity)
+// - Redis operation fails
+func (lb *Leaderboard) MergeEntities(sources []string, dest string) (err error) {
+	defer lb.startOp("MergeEntities", "", dest).end(&err)
+	dest = lb.normalizeEntity(dest)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if len(sources) == 0 || dest == "" {
+		return fmt.Errorf("invalid entity")
+	}
+	if err := lb.validateEntity(dest); err != nil {
+		return err
+	}
+	var filtered []string
+	for _, src := range sources {
+		if src == "" {
+			return fmt.Errorf("invalid entity")
+		}
+		src, err := lb.sourceEntity(src)
+		if err != nil {
+			return err
+		}
+		if src != dest && !slices.Contains(filtered, src) { // "US" and "us" may collapse
+			filtered = append(filtered, src)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return lb.mergeEntities(filtered, dest)
+}
+
+// sourceEntity validates and normalizes an entity to rename or merge from.
+// With EntityCaseInsensitive, a code named in another case resolves to the
+// lowercase ranking, unless a ranking stored under exactly that code exists:
+// entities written before the option was enabled keep their case, and can
+// still be folded in (e.g. RenameEntity("US", "us", true)). Costs one
+// EXISTS for such codes.
+func (lb *Leaderboard) sourceEntity(code string) (string, error) {
+	if err := lb.validateEntity(code); err != nil {
+		return "", err
+	}
+	normalized := lb.normalizeEntity(code)
+	if normalized == code {
+		return code, nil
+	}
+	n, err := lb.client.Exists(lb.ctx, lb.entityKey(code)).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to check entity %s: %w", code, err)
+	}
+	if n > 0 {
+		return code, nil
+	}
+	return normalized, nil
+}
+
+// mergeEntities unions source entity sets into dest, remaps members in
+// chunked pipelines and deletes the source keys.
+func (lb *Leaderboard) mergeEntities(sources []string, dest string) error {
+	defer lb.topKCache.invalidate()
+
+	metrics, err := lb.client.SMembers(lb.ctx, lb.metricsKey()).Result()
+	if err != nil {
+		return fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+	metrics = append([]string{""}, metrics...) // default board first
+
+	// Union sources into dest for the default board and every metric board
+	pipe := lb.client.Pipeline()
+	for _, metric := range metrics {
+		destKey := lb.metricEntityKey(metric, dest)
+		keys := []string{destKey}
+		for _, src := range sources {
+			keys = append(keys, lb.metricEntityKey(metric, src))
+		}
+		pipe.ZUnionStore(lb.ctx, destKey, &redis.ZStore{
+			Keys:      keys,
+			Aggregate: lb.config.EntityMergeAggregate,
+		})
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return fmt.Errorf("failed to merge entities into %s: %w", dest, err)
+	}
+
+	// Reassign entity mapping of every source member
+	for _, src := range sources {
+		srcKey := lb.entityKey(src)
+		batch := int64(lb.config.BatchSize)
+		for start := int64(0); ; start += batch {
+			members, err := lb.client.ZRange(lb.ctx, srcKey, start, start+batch-1).Result()
+			if err != nil {
+				return fmt.Errorf("failed to fetch entity %s members: %w", src, err)
+			}
+			if len(members) == 0 {
+				break
+			}
+			pipe := lb.client.Pipeline()
+			for _, userID := range members {
+				lb.setEntity(pipe, userID, dest)
+			}
+			if _, err := pipe.Exec(lb.ctx); err != nil {
+				return fmt.Errorf("failed to update entity %s members: %w", src, err)
+			}
+		}
+	}
+
+	// Delete the now-merged sources
+	var srcKeys []string
+	for _, metric := range metrics {
+		for _, src := range sources {
+			srcKeys = append(srcKeys, lb.metricEntityKey(metric, src))
+		}
+	}
+	if err := lb.client.Del(lb.ctx, srcKeys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete merged entities: %w", err)
+	}
+	if err := lb.mergeEntityTotals(sources, dest); err != nil {
+		return err
+	}
+	return lb.replaceEntityCodes(sources, dest)
+}
+
+// GetEntityMembers returns the IDs of all users in an entity, without
+// scores or ranking (e.g., for roster features). Ordered by ascending score.
+// Returns error if:
+// - the entity has more than Config.MaxReadSize members (ErrTooManyUsers)
+// - Redis operation fails
+func (lb *Leaderboard) GetEntityMembers(entity string) (_ []string, err error) {
+	defer lb.startOp("GetEntityMembers", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	entityKey := lb.entityKey(entity)
+
+	// Fetch one extra member to detect oversized entities in one round-trip
+	members, err := lb.entityClient(entity).ZRange(lb.ctx, entityKey, 0, int64(lb.config.MaxReadSize)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entity %s members: %w", entity, err)
+	}
+	if len(members) > lb.config.MaxReadSize {
+		return nil, fmt.Errorf("%w: entity %s has more than %d members", ErrTooManyUsers, entity, lb.config.MaxReadSize)
+	}
+	return members, nil
+}
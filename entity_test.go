@@ -0,0 +1,198 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRenameEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "UK", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 80})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 90})
+
+	if err := lb.RenameEntity("UK", "GB", false); err != nil {
+		t.Fatalf("RenameEntity: %v", err)
+	}
+	topK, err := lb.GetTopKEntity("GB")
+	if err != nil {
+		t.Fatalf("GetTopKEntity: %v", err)
+	}
+	if len(topK) != 2 || topK[0].ID != "u1" || topK[0].Score != 100 {
+		t.Errorf("unexpected renamed topK: %+v", topK)
+	}
+	if _, err := lb.GetTopKEntity("UK"); err == nil {
+		t.Error("expected old entity to be gone")
+	}
+	if entity, _ := lb.GetUserEntity("u2"); entity != "GB" {
+		t.Errorf("expected entity GB, got %s", entity)
+	}
+
+	if err := lb.RenameEntity("GB", "US", false); !errors.Is(err, ErrEntityExists) {
+		t.Errorf("expected ErrEntityExists, got %v", err)
+	}
+	if err := lb.RenameEntity("GB", "US", true); err != nil {
+		t.Fatalf("RenameEntity merge: %v", err)
+	}
+	topK, err = lb.GetTopKEntity("US")
+	if err != nil || len(topK) != 3 {
+		t.Errorf("expected 3 merged users, got %+v, err: %v", topK, err)
+	}
+}
+
+func TestMergeEntities(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "A", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "B", Score: 80})
+	lb.AddUser(User{ID: "u3", Entity: "C", Score: 50})
+	// stale overlapping membership: u1 also in B with a lower score
+	lb.client.ZAdd(lb.ctx, "test:entity:B", redis.Z{Score: 10, Member: "u1"})
+
+	if err := lb.MergeEntities([]string{"A", "B"}, "C"); err != nil {
+		t.Fatalf("MergeEntities: %v", err)
+	}
+	topK, err := lb.GetTopKEntity("C")
+	if err != nil {
+		t.Fatalf("GetTopKEntity: %v", err)
+	}
+	if len(topK) != 3 || topK[0].ID != "u1" || topK[0].Score != 100 {
+		t.Errorf("unexpected merged topK: %+v", topK)
+	}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if entity, _ := lb.GetUserEntity(id); entity != "C" {
+			t.Errorf("expected %s entity C, got %s", id, entity)
+		}
+	}
+	for _, src := range []string{"A", "B"} {
+		if _, err := lb.GetTopKEntity(src); err == nil {
+			t.Errorf("expected source %s to be deleted", src)
+		}
+	}
+}
+
+func TestMergeEntitiesSum(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityMergeAggregate: "SUM"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "A", Score: 100})
+	lb.client.ZAdd(lb.ctx, "test:entity:B", redis.Z{Score: 10, Member: "u1"})
+
+	if err := lb.MergeEntities([]string{"A", "B"}, "C"); err != nil {
+		t.Fatalf("MergeEntities: %v", err)
+	}
+	topK, err := lb.GetTopKEntity("C")
+	if err != nil || len(topK) != 1 || topK[0].Score != 110 {
+		t.Errorf("expected summed score 110, got %+v, err: %v", topK, err)
+	}
+}
+
+func TestGetEntityMembers(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxReadSize: 2})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 80})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 90})
+
+	members, err := lb.GetEntityMembers("US")
+	if err != nil || len(members) != 2 {
+		t.Errorf("expected 2 members, got %v, err: %v", members, err)
+	}
+	if members, err := lb.GetEntityMembers("FR"); err != nil || len(members) != 0 {
+		t.Errorf("expected no members, got %v, err: %v", members, err)
+	}
+
+	lb.AddUser(User{ID: "u4", Entity: "US", Score: 70})
+	if _, err := lb.GetEntityMembers("US"); !errors.Is(err, ErrTooManyUsers) {
+		t.Errorf("expected ErrTooManyUsers, got %v", err)
+	}
+}
+
+func TestEntityCaseInsensitive(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityCaseInsensitive: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "us", Score: 200})
+	lb.IncrementScore("u3", "Us", 50)
+	lb.IncrementScores([]ScoreUpdate{{UserID: "u4", Entity: "uS", Delta: 10}})
+	lb.NewBatch().AddUser(User{ID: "u5", Entity: "US", Score: 5}).Exec(context.Background())
+
+	for _, entity := range []string{"US", "us", "Us"} {
+		top, err := lb.GetTopKEntity(entity)
+		if err != nil || len(top) != 5 || top[0].ID != "u2" || top[0].Entity != "us" {
+			t.Errorf("GetTopKEntity(%q): expected 5 users in us, got %+v, %v", entity, top, err)
+		}
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "us" {
+		t.Errorf("expected u1 mapped to us, got %q", entity)
+	}
+	if rank, _ := lb.GetRankEntity("u1"); rank != 1 {
+		t.Errorf("expected u1 second in us, got %d", rank)
+	}
+	if n, _ := lb.client.Exists(lb.ctx, lb.key("entity", "US")).Result(); n != 0 {
+		t.Error("expected no upper-case entity key")
+	}
+
+	lb.UpdateEntityByUserID("u1", "GB")
+	if members, _ := lb.GetEntityMembers("gb"); len(members) != 1 || members[0] != "u1" {
+		t.Errorf("expected u1 moved to gb, got %v", members)
+	}
+	lb.RemoveEntity("Gb", true)
+	if score, err := lb.GetUserScore("u1"); err == nil {
+		t.Errorf("expected u1 removed with its entity, got score %v", score)
+	}
+}
+
+func TestEntityCaseInsensitiveRenameMerge(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityCaseInsensitive: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 50})
+
+	if err := lb.RenameEntity("US", "CA", false); err != nil {
+		t.Fatalf("RenameEntity: %v", err)
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "ca" {
+		t.Errorf("expected u1 renamed to ca, got %q", entity)
+	}
+	if n, _ := lb.client.Exists(lb.ctx, lb.entityKey("us")).Result(); n != 0 {
+		t.Error("expected the us ranking to be gone")
+	}
+
+	if err := lb.MergeEntities([]string{"UK"}, "Ca"); err != nil {
+		t.Fatalf("MergeEntities: %v", err)
+	}
+	if members, _ := lb.GetEntityMembers("CA"); len(members) != 2 {
+		t.Errorf("expected uk merged into ca, got %v", members)
+	}
+	if entity, _ := lb.GetUserEntity("u2"); entity != "ca" {
+		t.Errorf("expected u2 moved to ca, got %q", entity)
+	}
+
+	// a ranking stored in upper case before the option is still folded in
+	folded := newTestLeaderboard(t, Config{Namespace: "test2", EntityCaseInsensitive: true})
+	defer folded.Close()
+	defer folded.ForceClearLeaderBoardWithNamespacePrefix()
+	folded.client.ZAdd(folded.ctx, folded.globalKey(), redis.Z{Score: 10, Member: "u3"})
+	folded.client.ZAdd(folded.ctx, folded.entityKey("US"), redis.Z{Score: 10, Member: "u3"})
+	folded.client.HSet(folded.ctx, folded.entitiesKey(), "u3", "US")
+	folded.AddUser(User{ID: "u4", Entity: "US", Score: 20})
+	if err := folded.RenameEntity("US", "us", true); err != nil {
+		t.Fatalf("RenameEntity: %v", err)
+	}
+	if members, _ := folded.GetEntityMembers("us"); len(members) != 2 {
+		t.Errorf("expected US folded into us, got %v", members)
+	}
+	if entity, _ := folded.GetUserEntity("u3"); entity != "us" {
+		t.Errorf("expected u3 remapped to us, got %q", entity)
+	}
+}
@@ -0,0 +1,33 @@
+package redisboard
+
+import "fmt"
+
+// GetTopKEntityOrGlobal is GetTopKEntity for UIs that would rather show
+// the global board than a near-empty regional one: when the entity has
+// fewer than Config.MinEntitySize users, it returns GetTopKGlobal's users
+// instead and reports fallback. GetTopKEntity itself never falls back, and
+// with MinEntitySize unset this behaves like it.
+// Costs one ZCARD of the entity ranking on top of the top-k read.
+// Returns error if:
+// - no users in entity and MinEntitySize is unset
+// - no users in the global leaderboard on fallback
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKEntityOrGlobal(entity string) (_ []User, fallback bool, err error) {
+	defer lb.startOp("GetTopKEntityOrGlobal", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if lb.config.MinEntitySize > 0 {
+		size, err := lb.entityReader(entity).ZCard(lb.ctx, lb.entityKey(entity)).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to count entity %s users: %w", entity, err)
+		}
+		if size < int64(lb.config.MinEntitySize) {
+			users, err := lb.topKGlobal()
+			if err != nil {
+				return nil, false, err
+			}
+			return users, true, nil
+		}
+	}
+	users, err := lb.topKEntity(entity)
+	return users, false, err
+}
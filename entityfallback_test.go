@@ -0,0 +1,45 @@
+package redisboard
+
+import "testing"
+
+func TestGetTopKEntityOrGlobal(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MinEntitySize: 3})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 20})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 30})
+	lb.AddUser(User{ID: "u4", Entity: "NZ", Score: 40})
+
+	users, fallback, err := lb.GetTopKEntityOrGlobal("US")
+	if err != nil || fallback || len(users) != 3 || users[0].ID != "u3" {
+		t.Errorf("expected US top-k without fallback, got %v, %v, %v", users, fallback, err)
+	}
+
+	// NZ has one user and an unknown entity none: both fall back to global
+	for _, entity := range []string{"NZ", "FR"} {
+		users, fallback, err = lb.GetTopKEntityOrGlobal(entity)
+		if err != nil || !fallback || len(users) != 4 || users[0].ID != "u4" {
+			t.Errorf("expected global fallback for %s, got %v, %v, %v", entity, users, fallback, err)
+		}
+	}
+
+	// GetTopKEntity stays strict
+	if users, err := lb.GetTopKEntity("NZ"); err != nil || len(users) != 1 {
+		t.Errorf("expected NZ's own top-k, got %v, %v", users, err)
+	}
+}
+
+func TestGetTopKEntityOrGlobalDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "NZ", Score: 40})
+	users, fallback, err := lb.GetTopKEntityOrGlobal("NZ")
+	if err != nil || fallback || len(users) != 1 {
+		t.Errorf("expected NZ's own top-k, got %v, %v, %v", users, fallback, err)
+	}
+	if _, _, err := lb.GetTopKEntityOrGlobal("FR"); err == nil {
+		t.Error("expected empty entity to fail without MinEntitySize")
+	}
+}
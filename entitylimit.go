@@ -0,0 +1,161 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Entity limit policies for Config.EntityLimitPolicy.
+const (
+	// EntityLimitNone leaves MaxEntities unenforced (default).
+	EntityLimitNone = ""
+	// EntityLimitReject makes writes introducing a new entity fail with
+	// ErrTooManyEntities once MaxEntities entities are known.
+	EntityLimitReject = "reject"
+	// EntityLimitLog still creates new entities past MaxEntities, but logs
+	// each one through Config.Logger.
+	EntityLimitLog = "log"
+)
+
+// ErrTooManyEntities is returned under EntityLimitReject by writes that
+// would create an entity beyond Config.MaxEntities.
+var ErrTooManyEntities = errors.New("too many entities")
+
+// admitEntityScript registers an entity code in the set of known entities
+// unless the set already holds MaxEntities codes.
+// KEYS[1]: entity codes set; ARGV[1]: entity, ARGV[2]: MaxEntities,
+// ARGV[3]: "1" to register the entity even past the limit.
+// Returns 1 if the entity is known or registered within the limit, 2 if
+// registered past the limit and 0 if rejected.
+const admitEntityScript = `
+if redis.call('SISMEMBER', KEYS[1], ARGV[1]) == 1 then
+	return 1
+end
+local over = redis.call('SCARD', KEYS[1]) >= tonumber(ARGV[2])
+if over and ARGV[3] ~= '1' then
+	return 0
+end
+redis.call('SADD', KEYS[1], ARGV[1])
+if over then
+	return 2
+end
+return 1
+`
+
+// admitEntity checks a write's entity against MaxEntities per
+// EntityLimitPolicy, registering it as known. Entities admitted before are
+// remembered by the Leaderboard, so steady-state writes cost no round trip.
+func (lb *Leaderboard) admitEntity(entity string) error {
+	if entity == "" || lb.config.EntityLimitPolicy == EntityLimitNone {
+		return nil
+	}
+	if _, ok := lb.knownEntities.Load(entity); ok {
+		return nil
+	}
+
+	force := "0"
+	if lb.config.EntityLimitPolicy == EntityLimitLog {
+		force = "1"
+	}
+	res, err := lb.evalScript(admitEntityScript, []string{lb.entityCodesKey()}, entity, lb.config.MaxEntities, force)
+	if err != nil {
+		return fmt.Errorf("failed to check entity count: %w", conflictErr(err))
+	}
+	switch res, _ := res.(int64); res {
+	case 0:
+		return fmt.Errorf("%w: %s exceeds %d entities", ErrTooManyEntities, entity, lb.config.MaxEntities)
+	case 2:
+		lb.config.Logger.Warn("entity limit exceeded",
+			"namespace", lb.config.Namespace,
+			"entity", entity,
+			"max_entities", lb.config.MaxEntities,
+		)
+	}
+	lb.knownEntities.Store(entity, struct{}{})
+	return nil
+}
+
+// replaceEntityCodes unregisters deleted entity codes, so they no longer
+// count towards MaxEntities, and registers added (if not empty) without
+// checking the limit: RenameEntity and MergeEntities never add entities.
+func (lb *Leaderboard) replaceEntityCodes(removed []string, added string) error {
+	if lb.config.EntityLimitPolicy == EntityLimitNone {
+		return nil
+	}
+	key := lb.entityCodesKey()
+	pipe := lb.client.Pipeline()
+	for _, code := range removed {
+		pipe.SRem(lb.ctx, key, code)
+		lb.knownEntities.Delete(code)
+	}
+	if added != "" {
+		pipe.SAdd(lb.ctx, key, added)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return fmt.Errorf("failed to update entity codes: %w", err)
+	}
+	if added != "" {
+		lb.knownEntities.Store(added, struct{}{})
+	}
+	return nil
+}
+
+// seedEntityCodes registers the entities of existing users when the set of
+// known entities doesn't exist yet, e.g. on a board created before
+// EntityLimitPolicy was set.
+func (lb *Leaderboard) seedEntityCodes() error {
+	key := lb.entityCodesKey()
+	n, err := lb.client.Exists(lb.ctx, key).Result()
+	if err != nil || n > 0 {
+		return err
+	}
+
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.HScan(lb.ctx, lb.entitiesKey(), cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan entity mapping: %w", err)
+		}
+		var codes []any
+		for i := 1; i < len(keys); i += 2 {
+			if keys[i] != "" {
+				codes = append(codes, keys[i])
+			}
+		}
+		if len(codes) > 0 {
+			if err := lb.client.SAdd(lb.ctx, key, codes...).Err(); err != nil {
+				return fmt.Errorf("failed to register entities: %w", err)
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// EntityCount returns the number of entities on the leaderboard, e.g. to
+// export as a metric and alert before MaxEntities is reached.
+// With EntityLimitPolicy this is the size of the set of known entities,
+// which counts an entity until RemoveEntity, RenameEntity or MergeEntities
+// deletes it, even after its last member is removed. Without, the entity
+// rankings are counted with SCAN.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) EntityCount() (_ int64, err error) {
+	defer lb.startOp("EntityCount", "", "").end(&err)
+	if lb.config.EntityLimitPolicy != EntityLimitNone {
+		n, err := lb.client.SCard(lb.ctx, lb.entityCodesKey()).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to count entities: %w", err)
+		}
+		return n, nil
+	}
+	if err := lb.unsharded(); err != nil {
+		return 0, err
+	}
+	keys, err := lb.scanKeys(lb.entityKey("") + "*")
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(keys)), nil
+}
@@ -0,0 +1,109 @@
+package redisboard
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestEntityLimitReject(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxEntities: 2, EntityLimitPolicy: EntityLimitReject})
+	defer lb.Close()
+
+	if err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lb.IncrementScore("u2", "EU", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := lb.AddUser(User{ID: "u3", Entity: "ASIA", Score: 10}); !errors.Is(err, ErrTooManyEntities) {
+		t.Errorf("expected ErrTooManyEntities, got %v", err)
+	}
+	if n, _ := lb.client.Exists(lb.ctx, lb.entityKey("ASIA")).Result(); n != 0 {
+		t.Error("expected rejected entity not to be created")
+	}
+	err := lb.IncrementScores([]ScoreUpdate{{UserID: "u4", Entity: "ASIA", Delta: 1}, {UserID: "u5", Entity: "US", Delta: 1}})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(batchErr.Errors[0], ErrTooManyEntities) || batchErr.Errors[1] != nil {
+		t.Errorf("expected only the new entity rejected, got %v", err)
+	}
+
+	// known entities and users without entity are still accepted
+	if err := lb.AddUser(User{ID: "u6", Entity: "US", Score: 1}); err != nil {
+		t.Errorf("expected known entity to be accepted, got %v", err)
+	}
+	if err := lb.AddUser(User{ID: "u7", Score: 1}); err != nil {
+		t.Errorf("expected user without entity to be accepted, got %v", err)
+	}
+	if n, err := lb.EntityCount(); err != nil || n != 2 {
+		t.Errorf("expected 2 entities, got %d, %v", n, err)
+	}
+
+	// removing an entity frees its place, renaming doesn't add one
+	if err := lb.RemoveEntity("EU", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := lb.AddUser(User{ID: "u3", Entity: "ASIA", Score: 10}); err != nil {
+		t.Errorf("expected entity to be accepted after RemoveEntity, got %v", err)
+	}
+	if err := lb.RenameEntity("ASIA", "APAC", false); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := lb.EntityCount(); n != 2 {
+		t.Errorf("expected 2 entities after rename, got %d", n)
+	}
+	if err := lb.AddUser(User{ID: "u8", Entity: "APAC", Score: 1}); err != nil {
+		t.Errorf("expected renamed entity to be known, got %v", err)
+	}
+}
+
+func TestEntityLimitLog(t *testing.T) {
+	var out lockedBuffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxEntities: 1, EntityLimitPolicy: EntityLimitLog, Logger: logger})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	if err := lb.AddUser(User{ID: "u2", Entity: "EU", Score: 10}); err != nil {
+		t.Fatalf("expected entity past the limit to be created, got %v", err)
+	}
+	lb.AddUser(User{ID: "u3", Entity: "EU", Score: 10})
+	if n := strings.Count(out.String(), "entity limit exceeded"); n != 1 {
+		t.Errorf("expected one log line, got %d: %s", n, out.String())
+	}
+	if !strings.Contains(out.String(), `"entity":"EU"`) {
+		t.Errorf("expected entity in log, got %s", out.String())
+	}
+	if n, _ := lb.EntityCount(); n != 2 {
+		t.Errorf("expected 2 entities, got %d", n)
+	}
+}
+
+func TestEntityLimitSeed(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "EU", Score: 10})
+	lb.AddUser(User{ID: "u3", Score: 10})
+	if n, err := lb.EntityCount(); err != nil || n != 2 {
+		t.Errorf("expected 2 entity rankings, got %d, %v", n, err)
+	}
+
+	// enabling the policy on an existing board counts its entities
+	limited, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, MaxEntities: 2, EntityLimitPolicy: EntityLimitReject})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer limited.Close()
+	if n, _ := limited.EntityCount(); n != 2 {
+		t.Errorf("expected 2 seeded entities, got %d", n)
+	}
+	if err := limited.AddUser(User{ID: "u4", Entity: "ASIA", Score: 10}); !errors.Is(err, ErrTooManyEntities) {
+		t.Errorf("expected ErrTooManyEntities, got %v", err)
+	}
+
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, EntityLimitPolicy: "drop"}); err == nil {
+		t.Error("expected unknown entity limit policy to be rejected")
+	}
+}
@@ -0,0 +1,175 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Entity ranking writes for entityWrite.
+const (
+	entitySet  = "set"  // ZADD the score
+	entityIncr = "incr" // ZINCRBY the score
+	entityRem  = "rem"  // ZREM the user
+)
+
+// entityTotalsScript applies a write to an entity ranking and adds the
+// change of the user's entity score to the entity's total. An entity whose
+// ranking becomes empty leaves the totals.
+// KEYS[1]: entity ranking, KEYS[2]: entity totals; ARGV[1]: write (see
+// entitySet...), ARGV[2]: user ID, ARGV[3]: score, ARGV[4]: entity.
+const entityTotalsScript = `
+local old = tonumber(redis.call('ZSCORE', KEYS[1], ARGV[2]))
+local delta
+if ARGV[1] == 'set' then
+	redis.call('ZADD', KEYS[1], ARGV[3], ARGV[2])
+	delta = tonumber(ARGV[3]) - (old or 0)
+elseif ARGV[1] == 'incr' then
+	redis.call('ZINCRBY', KEYS[1], ARGV[3], ARGV[2])
+	delta = tonumber(ARGV[3])
+else
+	if not old then
+		return 0
+	end
+	redis.call('ZREM', KEYS[1], ARGV[2])
+	delta = -old
+end
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	redis.call('ZREM', KEYS[2], ARGV[4])
+else
+	redis.call('ZINCRBY', KEYS[2], delta, ARGV[4])
+end
+return 1
+`
+
+// entityWrite queues a write of userID's score in entity's ranking on
+// pipe. With EntityTotals it runs entityTotalsScript instead, sent in full
+// like setEntity so it works inside MULTI too.
+func (lb *Leaderboard) entityWrite(pipe redis.Pipeliner, op, entity, userID string, score float64) redis.Cmder {
+	entityKey := lb.entityKey(entity)
+	if lb.config.EntityTotals {
+		keys := []string{entityKey, lb.entityTotalsKey()}
+		return pipe.Eval(lb.ctx, entityTotalsScript, keys, op, userID, score, entity)
+	}
+	switch op {
+	case entitySet:
+		return pipe.ZAdd(lb.ctx, entityKey, redis.Z{Score: score, Member: userID})
+	case entityIncr:
+		return pipe.ZIncrBy(lb.ctx, entityKey, score, userID)
+	default:
+		return pipe.ZRem(lb.ctx, entityKey, userID)
+	}
+}
+
+// GetEntityRank returns an entity's rank among all entities by total score
+// (0-based, like GetRankGlobal), e.g. "we're the 3rd-ranked guild" as 2.
+// Reads the entity totals maintained with EntityTotals.
+// Returns -1 if the entity has no ranked users.
+// Returns error if:
+// - EntityTotals is unset
+// - Redis operation fails
+func (lb *Leaderboard) GetEntityRank(entity string) (_ int, err error) {
+	defer lb.startOp("GetEntityRank", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if !lb.config.EntityTotals {
+		return -1, fmt.Errorf("entity totals are disabled")
+	}
+	rank, err := lb.reader.ZRevRank(lb.ctx, lb.entityTotalsKey(), entity).Result()
+	if err == redis.Nil {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("failed to get entity rank: %w", err)
+	}
+	return int(rank), nil
+}
+
+// RebuildEntityTotals recomputes every entity's total from the entity
+// rankings, e.g. after enabling EntityTotals on an existing board. The
+// totals are built in a temporary key, one entity at a time, and swapped in
+// with RENAME, so readers never see partial totals. Repair runs it when it
+// fixed anything.
+// Not atomic: writes during the rebuild may be missing from the totals
+// until the next rebuild.
+// Returns error if:
+// - EntityTotals is unset
+// - Redis operation fails
+func (lb *Leaderboard) RebuildEntityTotals() (err error) {
+	defer lb.startOp("RebuildEntityTotals", "", "").end(&err)
+	if !lb.config.EntityTotals {
+		return fmt.Errorf("entity totals are disabled")
+	}
+	tmpKey := lb.key("totals", "building")
+	if err := lb.client.Del(lb.ctx, tmpKey).Err(); err != nil {
+		return fmt.Errorf("failed to rebuild entity totals: %w", err)
+	}
+
+	entityKeys, err := lb.scanKeys(lb.entityKey("") + "*")
+	if err != nil {
+		return err
+	}
+	prefix := lb.entityKey("")
+	for _, entityKey := range entityKeys {
+		total, err := lb.sumScores(entityKey)
+		if err != nil {
+			return err
+		}
+		entity := entityKey[len(prefix):]
+		if err := lb.client.ZAdd(lb.ctx, tmpKey, redis.Z{Score: total, Member: entity}).Err(); err != nil {
+			return fmt.Errorf("failed to rebuild entity totals: %w", err)
+		}
+	}
+
+	if len(entityKeys) == 0 {
+		err = lb.client.Del(lb.ctx, lb.entityTotalsKey()).Err()
+	} else {
+		err = lb.client.Rename(lb.ctx, tmpKey, lb.entityTotalsKey()).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to swap entity totals: %w", err)
+	}
+	return nil
+}
+
+// mergeEntityTotals drops merged sources from the totals and recomputes
+// dest's total, which EntityMergeAggregate "MAX" keeps below the sum of
+// the merged totals.
+func (lb *Leaderboard) mergeEntityTotals(sources []string, dest string) error {
+	if !lb.config.EntityTotals {
+		return nil
+	}
+	total, err := lb.sumScores(lb.entityKey(dest))
+	if err != nil {
+		return err
+	}
+	members := make([]any, len(sources))
+	for i, src := range sources {
+		members[i] = src
+	}
+	pipe := lb.client.Pipeline()
+	pipe.ZRem(lb.ctx, lb.entityTotalsKey(), members...)
+	pipe.ZAdd(lb.ctx, lb.entityTotalsKey(), redis.Z{Score: total, Member: dest})
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return fmt.Errorf("failed to update entity totals: %w", err)
+	}
+	return nil
+}
+
+// sumScores adds up the scores of a ranking, Config.BatchSize members per read.
+// Reads by rank rather than ZSCAN, which may return a member twice.
+func (lb *Leaderboard) sumScores(key string) (float64, error) {
+	var total float64
+	batch := int64(lb.config.BatchSize)
+	for start := int64(0); ; start += batch {
+		members, err := lb.client.ZRangeWithScores(lb.ctx, key, start, start+batch-1).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		for _, z := range members {
+			total += z.Score
+		}
+		if len(members) < lb.config.BatchSize {
+			return total, nil
+		}
+	}
+}
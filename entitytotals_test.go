@@ -0,0 +1,112 @@
+package redisboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// entityTotals returns the maintained entity totals.
+func entityTotals(t *testing.T, lb *Leaderboard) map[string]float64 {
+	t.Helper()
+	members, err := lb.client.ZRangeWithScores(lb.ctx, lb.entityTotalsKey(), 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	totals := make(map[string]float64, len(members))
+	for _, z := range members {
+		totals[z.Member.(string)] = z.Score
+	}
+	return totals
+}
+
+func expectTotals(t *testing.T, lb *Leaderboard, want map[string]float64) {
+	t.Helper()
+	got := entityTotals(t, lb)
+	if len(got) != len(want) {
+		t.Errorf("expected totals %v, got %v", want, got)
+		return
+	}
+	for entity, total := range want {
+		if got[entity] != total {
+			t.Errorf("expected totals %v, got %v", want, got)
+			return
+		}
+	}
+}
+
+func TestEntityTotals(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityTotals: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "red", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "red", Score: 20})
+	lb.AddUser(User{ID: "u3", Entity: "blue", Score: 25})
+	lb.AddUser(User{ID: "u4", Score: 100}) // no entity, no total
+	expectTotals(t, lb, map[string]float64{"red": 30, "blue": 25})
+
+	lb.AddUser(User{ID: "u1", Entity: "red", Score: 15}) // overwrite adds the difference
+	lb.IncrementScore("u3", "blue", 10)
+	lb.DecrementScore("u2", "red", 5)
+	expectTotals(t, lb, map[string]float64{"red": 30, "blue": 35})
+
+	if rank, err := lb.GetEntityRank("blue"); err != nil || rank != 0 {
+		t.Errorf("expected blue first, got %d, %v", rank, err)
+	}
+	if rank, _ := lb.GetEntityRank("red"); rank != 1 {
+		t.Errorf("expected red second, got %d", rank)
+	}
+	if rank, err := lb.GetEntityRank("green"); err != nil || rank != -1 {
+		t.Errorf("expected unknown entity unranked, got %d, %v", rank, err)
+	}
+
+	lb.UpdateEntityByUserID("u1", "blue")
+	expectTotals(t, lb, map[string]float64{"red": 15, "blue": 50})
+	lb.RemoveUser("u2")
+	expectTotals(t, lb, map[string]float64{"blue": 50})
+
+	lb.IncrementScores([]ScoreUpdate{{UserID: "u5", Entity: "red", Delta: 7}, {UserID: "u3", Entity: "blue", Delta: 1}})
+	err := lb.NewBatch().
+		AddUser(User{ID: "u6", Entity: "green", Score: 3}).
+		Increment("u5", "red", 1).
+		Remove("u1").
+		Exec(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTotals(t, lb, map[string]float64{"red": 8, "blue": 36, "green": 3})
+
+	if err := lb.MergeEntities([]string{"green"}, "red"); err != nil {
+		t.Fatal(err)
+	}
+	expectTotals(t, lb, map[string]float64{"red": 11, "blue": 36})
+	if err := lb.RemoveEntity("blue", false); err != nil {
+		t.Fatal(err)
+	}
+	expectTotals(t, lb, map[string]float64{"red": 11})
+
+	// a rebuild recomputes totals drifted by writes bypassing them
+	lb.client.ZAdd(lb.ctx, lb.entityKey("red"), redis.Z{Score: 100, Member: "u9"})
+	lb.client.ZAdd(lb.ctx, lb.entityTotalsKey(), redis.Z{Score: 1, Member: "stale"})
+	if err := lb.RebuildEntityTotals(); err != nil {
+		t.Fatal(err)
+	}
+	expectTotals(t, lb, map[string]float64{"red": 111})
+}
+
+func TestEntityTotalsDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "red", Score: 10})
+	if n, _ := lb.client.Exists(lb.ctx, lb.entityTotalsKey()).Result(); n != 0 {
+		t.Error("expected no totals without EntityTotals")
+	}
+	if _, err := lb.GetEntityRank("red"); err == nil {
+		t.Error("expected GetEntityRank to fail without EntityTotals")
+	}
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, EntityTotals: true, MaxUsersPerEntity: 5}); err == nil {
+		t.Error("expected EntityTotals with user caps to be rejected")
+	}
+}
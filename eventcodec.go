@@ -0,0 +1,67 @@
+package redisboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative -I proto proto/leaderboard_event.proto
+
+// Event codecs for Config.EventCodec.
+const (
+	EventCodecJSON     = "json"     // JSON-encoded RankChange (default)
+	EventCodecProtobuf = "protobuf" // LeaderboardEvent from proto/leaderboard_event.proto
+)
+
+// errBadEvent reports a payload which isn't an encoded LeaderboardEvent.
+var errBadEvent = errors.New("malformed LeaderboardEvent")
+
+// MarshalProto encodes c as a LeaderboardEvent in the protobuf wire format,
+// as published with EventCodecProtobuf.
+// Returns error if c.UserID isn't valid UTF-8, as proto3 strings must be.
+func (c RankChange) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&LeaderboardEvent{
+		UserId:  c.UserID,
+		OldRank: int64(c.OldRank),
+		NewRank: int64(c.NewRank),
+		Score:   c.Score,
+	})
+}
+
+// UnmarshalProto decodes a LeaderboardEvent encoded by MarshalProto or any
+// protobuf encoder into c, skipping unknown fields.
+// Returns error if b isn't a valid LeaderboardEvent.
+func (c *RankChange) UnmarshalProto(b []byte) error {
+	*c = RankChange{}
+	var event LeaderboardEvent
+	if err := proto.Unmarshal(b, &event); err != nil {
+		return fmt.Errorf("%w: %v", errBadEvent, err)
+	}
+	*c = RankChange{
+		UserID:  event.UserId,
+		OldRank: int(event.OldRank),
+		NewRank: int(event.NewRank),
+		Score:   event.Score,
+	}
+	return nil
+}
+
+// encodeRankChange encodes a published rank change with the EventCodec.
+func (lb *Leaderboard) encodeRankChange(change RankChange) ([]byte, error) {
+	if lb.config.EventCodec == EventCodecProtobuf {
+		return change.MarshalProto()
+	}
+	return json.Marshal(change)
+}
+
+// decodeRankChange decodes a rank change payload with the EventCodec.
+func (lb *Leaderboard) decodeRankChange(payload []byte) (RankChange, error) {
+	var change RankChange
+	if lb.config.EventCodec == EventCodecProtobuf {
+		return change, change.UnmarshalProto(payload)
+	}
+	return change, json.Unmarshal(payload, &change)
+}
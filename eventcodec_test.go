@@ -0,0 +1,78 @@
+package redisboard
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestRankChangeProto(t *testing.T) {
+	change := RankChange{UserID: "u3", OldRank: -1, NewRank: 1, Score: 250}
+	// the LeaderboardEvent encoding of proto/leaderboard_event.proto
+	want := []byte{
+		0x0a, 0x02, 'u', '3', // user_id
+		0x10, 0x01, // old_rank: zigzag(-1)
+		0x18, 0x02, // new_rank: zigzag(1)
+		0x21, 0, 0, 0, 0, 0, 0x40, 0x6f, 0x40, // score: 250.0
+	}
+	got, err := change.MarshalProto()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("expected % x, got % x, %v", want, got, err)
+	}
+	if _, err := (RankChange{UserID: "\xff"}).MarshalProto(); err == nil {
+		t.Error("expected error for a non-UTF-8 user ID")
+	}
+
+	var decoded RankChange
+	unknown := append(append([]byte{}, got...), 0x28, 0x05, 0x35, 1, 2, 3, 4) // fields 5 and 6
+	if err := decoded.UnmarshalProto(unknown); err != nil || decoded != change {
+		t.Errorf("expected %+v, got %+v, %v", change, decoded, err)
+	}
+	if err := decoded.UnmarshalProto([]byte(`{"userID":"u3"}`)); err == nil {
+		t.Error("expected error for a JSON payload")
+	}
+	if err := decoded.UnmarshalProto(want[:12]); err == nil {
+		t.Error("expected error for a truncated payload")
+	}
+	if err := decoded.UnmarshalProto(nil); err != nil || decoded != (RankChange{}) {
+		t.Errorf("expected zero change for empty payload, got %+v, %v", decoded, err)
+	}
+}
+
+func TestEventCodecProtobuf(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", PublishRankChanges: true, EventCodec: EventCodecProtobuf})
+	defer lb.Close()
+
+	sub, err := lb.SubscribeRankCrossings(1)
+	if err != nil {
+		t.Fatalf("SubscribeRankCrossings: %v", err)
+	}
+	defer sub.Close()
+	raw := lb.client.Subscribe(lb.ctx, lb.rankEventsChannel())
+	defer raw.Close()
+	if _, err := raw.Receive(lb.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	want := RankChange{UserID: "u1", OldRank: -1, NewRank: 0, Score: 100}
+	select {
+	case got := <-sub.C:
+		if got.RankChange != want || !got.Entered {
+			t.Errorf("expected %+v entering, got %+v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+	msg, err := raw.ReceiveMessage(lb.ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload, _ := want.MarshalProto(); msg.Payload != string(payload) {
+		t.Errorf("expected a LeaderboardEvent payload, got %q", msg.Payload)
+	}
+
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, EventCodec: "xml"}); err == nil {
+		t.Error("expected unknown EventCodec to be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+package redisboard
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RankChange is published on the rank events channel after each
+// AddUser/IncrementScore/DecrementScore when PublishRankChanges is set,
+// encoded per EventCodec.
+type RankChange struct {
+	UserID  string  `json:"userID"`
+	OldRank int     `json:"oldRank"` // global rank before the write, -1 if not ranked
+	NewRank int     `json:"newRank"` // global rank after the write, -1 if not ranked
+	Score   float64 `json:"score"`   // score after the write
+}
+
+// RankCrossing is a RankChange that moved a user across a subscription's
+// threshold.
+type RankCrossing struct {
+	RankChange
+	Threshold int  // the subscription's threshold
+	Entered   bool // true: moved into the top Threshold, false: dropped out
+}
+
+// rankBefore returns the user's global rank ahead of a write, or -1 if
+// they aren't ranked or PublishRankChanges is unset.
+func (lb *Leaderboard) rankBefore(userID string) (int, error) {
+	if !lb.config.PublishRankChanges {
+		return -1, nil
+	}
+	return lb.globalRank(userID)
+}
+
+// publishRankChange looks up the user's rank after a write and publishes
+// the change from before. No-op without PublishRankChanges.
+func (lb *Leaderboard) publishRankChange(userID string, before int) error {
+	if !lb.config.PublishRankChanges {
+		return nil
+	}
+	member, err := lb.resolveMember(lb.client, userID)
+	if err != nil {
+		return err
+	}
+	pipe := lb.client.Pipeline()
+	rankCmd := pipe.ZRevRank(lb.ctx, lb.globalKey(), member)
+	scoreCmd := pipe.ZScore(lb.ctx, lb.globalKey(), member)
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to get rank after write: %w", err)
+	}
+	change := RankChange{UserID: userID, OldRank: before, NewRank: -1, Score: scoreCmd.Val()}
+	if rankCmd.Err() == nil {
+		change.NewRank = int(rankCmd.Val())
+	}
+
+	payload, err := lb.encodeRankChange(change)
+	if err != nil {
+		return fmt.Errorf("failed to encode rank change: %w", err)
+	}
+	if err := lb.client.Publish(lb.ctx, lb.rankEventsChannel(), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish rank change: %w", err)
+	}
+	return nil
+}
+
+// globalRank returns the user's global rank, or -1 if not ranked.
+func (lb *Leaderboard) globalRank(userID string) (int, error) {
+	member, err := lb.resolveMember(lb.client, userID)
+	if err != nil {
+		return -1, err
+	}
+	rank, err := lb.client.ZRevRank(lb.ctx, lb.globalKey(), member).Result()
+	if err == redis.Nil {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("failed to get global rank: %w", err)
+	}
+	return int(rank), nil
+}
+
+// RankSubscription delivers RankCrossings until closed.
+type RankSubscription struct {
+	C <-chan RankCrossing // crossings, in publish order
+
+	pubsub    *redis.PubSub
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Close ends the subscription and closes C.
+func (s *RankSubscription) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		err = s.pubsub.Close()
+		<-s.done
+	})
+	return err
+}
+
+// SubscribeRankCrossings subscribes to the rank changes published by every
+// Leaderboard on this namespace with PublishRankChanges, delivering only
+// those where a user moved into or out of the global top threshold (e.g.,
+// entered the top 10). Only the written user is checked: users pushed
+// across the threshold by someone else's write are not reported.
+// If C isn't drained, events are dropped once the subscription's buffer
+// fills, as with any Redis Pub/Sub consumer.
+// Returns error if:
+// - threshold is not positive
+// - Redis operation fails
+func (lb *Leaderboard) SubscribeRankCrossings(threshold int) (_ *RankSubscription, err error) {
+	defer lb.startOp("SubscribeRankCrossings", "", "").end(&err)
+	if threshold <= 0 {
+		return nil, fmt.Errorf("invalid threshold: %d", threshold)
+	}
+
+	pubsub := lb.client.Subscribe(lb.ctx, lb.rankEventsChannel())
+	if _, err := pubsub.Receive(lb.ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to rank events: %w", err)
+	}
+
+	c := make(chan RankCrossing)
+	sub := &RankSubscription{C: c, pubsub: pubsub, stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		defer close(c)
+		within := func(rank int) bool { return rank >= 0 && rank < threshold }
+		for msg := range pubsub.Channel() {
+			change, err := lb.decodeRankChange([]byte(msg.Payload))
+			if err != nil {
+				continue // not a rank change, or another codec
+			}
+			if within(change.OldRank) == within(change.NewRank) {
+				continue
+			}
+			crossing := RankCrossing{RankChange: change, Threshold: threshold, Entered: within(change.NewRank)}
+			select {
+			case c <- crossing:
+			case <-sub.stop:
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
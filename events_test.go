@@ -0,0 +1,55 @@
+package redisboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeRankCrossings(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", PublishRankChanges: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 300})
+	lb.AddUser(User{ID: "u2", Score: 200})
+
+	sub, err := lb.SubscribeRankCrossings(2)
+	if err != nil {
+		t.Fatalf("SubscribeRankCrossings: %v", err)
+	}
+	defer sub.Close()
+
+	lb.AddUser(User{ID: "u3", Score: 100}) // enters at rank 2: no crossing
+	lb.IncrementScore("u3", "", 150)       // 250 -> rank 1: entered top 2
+	lb.IncrementScore("u1", "", 10)        // stays at rank 0: no crossing
+	lb.DecrementScore("u3", "", 200)       // 50 -> rank 2: dropped out
+
+	want := []RankCrossing{
+		{RankChange: RankChange{UserID: "u3", OldRank: 2, NewRank: 1, Score: 250}, Threshold: 2, Entered: true},
+		{RankChange: RankChange{UserID: "u3", OldRank: 1, NewRank: 2, Score: 50}, Threshold: 2, Entered: false},
+	}
+	for i, w := range want {
+		select {
+		case got := <-sub.C:
+			if got != w {
+				t.Errorf("crossing %d: expected %+v, got %+v", i, w, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("crossing %d: timed out", i)
+		}
+	}
+	select {
+	case got := <-sub.C:
+		t.Errorf("unexpected crossing %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if _, ok := <-sub.C; ok {
+		t.Error("expected C to be closed")
+	}
+	if _, err := lb.SubscribeRankCrossings(0); err == nil {
+		t.Error("expected error for non-positive threshold")
+	}
+}
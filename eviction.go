@@ -0,0 +1,162 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Eviction policies for Config.EvictionPolicy.
+const (
+	// EvictionNone leaves MaxUsers unenforced (default).
+	EvictionNone = ""
+	// EvictionReject makes AddUser fail with ErrLeaderboardFull for new
+	// users once the global ranking holds MaxUsers users.
+	EvictionReject = "reject"
+	// EvictionLowest keeps only the top MaxUsers users: once full, adding a
+	// user evicts the lowest-scoring one (possibly the new user itself).
+	EvictionLowest = "evictLowest"
+)
+
+var (
+	// ErrLeaderboardFull is returned by AddUser under EvictionReject when
+	// the global ranking already holds MaxUsers users.
+	ErrLeaderboardFull = errors.New("leaderboard full")
+
+	// ErrEntityFull is returned when an entity ranking already holds
+	// MaxUsersPerEntity users and the user can't take a place in it.
+	ErrEntityFull = errors.New("entity full")
+)
+
+// admitScript writes a user's score to capped rankings atomically, so
+// concurrent inserts can't overshoot a cap.
+// KEYS: rankings to write; ARGV[1]: score, followed by each key's member,
+// cap (0: uncapped) and policy.
+// Returns the 1-based index of a full ranking under EvictionReject, else
+// the evicted members as flattened (key index, member) pairs.
+const admitScript = `
+local score = ARGV[1]
+for i, key in ipairs(KEYS) do
+	local member, max = ARGV[3 * i - 1], tonumber(ARGV[3 * i])
+	if max > 0 and ARGV[3 * i + 1] == 'reject' and not redis.call('ZSCORE', key, member)
+		and redis.call('ZCARD', key) >= max then
+		return i
+	end
+end
+local evicted = {}
+for i, key in ipairs(KEYS) do
+	local member, max = ARGV[3 * i - 1], tonumber(ARGV[3 * i])
+	redis.call('ZADD', key, score, member)
+	local excess = redis.call('ZCARD', key) - max
+	if max > 0 and excess > 0 then
+		local popped = redis.call('ZPOPMIN', key, excess)
+		for j = 1, #popped, 2 do
+			evicted[#evicted + 1] = i
+			evicted[#evicted + 1] = popped[j]
+		end
+	end
+end
+return evicted
+`
+
+// capped reports whether AddUser must go through admitUser.
+func (lb *Leaderboard) capped(entity string) bool {
+	return lb.config.EvictionPolicy != EvictionNone || (entity != "" && lb.config.MaxUsersPerEntity > 0)
+}
+
+// entityPolicy is the policy applied to full entities: entities evict only
+// under EvictionLowest and reject otherwise.
+func (lb *Leaderboard) entityPolicy() string {
+	if lb.config.EvictionPolicy == EvictionLowest {
+		return EvictionLowest
+	}
+	return EvictionReject
+}
+
+// admitUser writes the user's global and entity scores under the MaxUsers
+// and MaxUsersPerEntity caps and removes evicted users completely.
+// Reports whether the user itself is still on the leaderboard.
+func (lb *Leaderboard) admitUser(userID, entity string, score float64) (bool, error) {
+	// the existing global member must carry the new entity before the cap
+	// check, or it would be counted as a different user
+	if err := lb.relocate(userID, entity); err != nil {
+		return false, err
+	}
+
+	globalMax := 0
+	if lb.config.EvictionPolicy != EvictionNone {
+		globalMax = lb.config.MaxUsers
+	}
+	keys := []string{lb.globalKey()}
+	args := []any{score, lb.memberFor(userID, entity), globalMax, lb.config.EvictionPolicy}
+	if entity != "" {
+		keys = append(keys, lb.entityKey(entity))
+		args = append(args, userID, lb.config.MaxUsersPerEntity, lb.entityPolicy())
+	}
+
+	res, err := lb.evalScript(admitScript, keys, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to add user: %w", conflictErr(err))
+	}
+	switch res {
+	case int64(1):
+		return false, fmt.Errorf("%w: %d users", ErrLeaderboardFull, lb.config.MaxUsers)
+	case int64(2):
+		return false, fmt.Errorf("%w: %s has %d users", ErrEntityFull, entity, lb.config.MaxUsersPerEntity)
+	}
+
+	admitted := true
+	evicted, _ := res.([]any)
+	for i := 0; i+1 < len(evicted); i += 2 {
+		id, _ := evicted[i+1].(string)
+		if evicted[i] == int64(1) {
+			id, _ = lb.parseMember(id)
+		}
+		if id == userID {
+			admitted = false
+		}
+		if err := lb.RemoveUser(id); err != nil {
+			return false, fmt.Errorf("failed to clean up evicted user %s: %w", id, err)
+		}
+	}
+	return admitted, nil
+}
+
+// entityRoom checks a move of userID into the entity ranking at key against
+// MaxUsersPerEntity, reading within the caller's WATCH transaction.
+// Returns the members to evict under EvictionLowest, or ErrEntityFull if
+// the entity rejects users or the user would be its lowest.
+func (lb *Leaderboard) entityRoom(tx *redis.Tx, key, userID string, score float64) ([]string, error) {
+	limit := lb.config.MaxUsersPerEntity
+	if limit <= 0 {
+		return nil, nil
+	}
+	if _, err := tx.ZScore(lb.ctx, key, userID).Result(); err != redis.Nil {
+		return nil, err // already a member (err == nil) or a Redis failure
+	}
+	card, err := tx.ZCard(lb.ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count entity users: %w", err)
+	}
+	excess := card - int64(limit) + 1
+	if excess <= 0 {
+		return nil, nil
+	}
+	full := fmt.Errorf("%w: %d users", ErrEntityFull, limit)
+	if lb.entityPolicy() != EvictionLowest {
+		return nil, full
+	}
+	lowest, err := tx.ZRangeWithScores(lb.ctx, key, 0, excess-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lowest entity users: %w", err)
+	}
+	if len(lowest) > 0 && score <= lowest[len(lowest)-1].Score {
+		return nil, full
+	}
+	evicted := make([]string, len(lowest))
+	for i, z := range lowest {
+		evicted[i], _ = z.Member.(string)
+	}
+	return evicted, nil
+}
@@ -0,0 +1,117 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEvictionLowest(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUsers: 5, EvictionPolicy: EvictionLowest})
+	defer lb.Close()
+
+	for i := 0; i < 50; i++ {
+		if err := lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)}); err != nil {
+			t.Fatalf("AddUser u%d failed: %v", i, err)
+		}
+		if n := lb.client.ZCard(lb.ctx, lb.globalKey()).Val(); n > 5 {
+			t.Fatalf("expected at most 5 users, got %d", n)
+		}
+	}
+
+	// evicted users are gone from the entity ranking and mapping too
+	if n := lb.client.ZCard(lb.ctx, lb.entityKey("US")).Val(); n != 5 {
+		t.Errorf("expected 5 users in entity, got %d", n)
+	}
+	if n := rawClient(lb).HLen(lb.ctx, lb.entitiesKey()).Val(); n != 5 {
+		t.Errorf("expected 5 entity mappings, got %d", n)
+	}
+	if _, err := lb.GetUserScore("u44"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected u44 to be evicted, got %v", err)
+	}
+
+	// a user below the lowest of a full board is not stored
+	if err := lb.AddUser(User{ID: "low", Entity: "UK", Score: 1}); err != nil {
+		t.Fatalf("AddUser low failed: %v", err)
+	}
+	if entity, _ := lb.GetUserEntity("low"); entity != "" {
+		t.Errorf("expected low user not to be stored, got entity %q", entity)
+	}
+}
+
+func TestEvictionReject(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUsers: 2, EvictionPolicy: EvictionReject})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 10})
+	lb.AddUser(User{ID: "u2", Score: 20})
+	if err := lb.AddUser(User{ID: "u3", Score: 30}); !errors.Is(err, ErrLeaderboardFull) {
+		t.Errorf("expected ErrLeaderboardFull, got %v", err)
+	}
+	// existing users can still be updated
+	if err := lb.AddUser(User{ID: "u1", Score: 50}); err != nil {
+		t.Errorf("expected update of existing user to succeed, got %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 50 {
+		t.Errorf("expected score 50, got %v", score)
+	}
+}
+
+func TestInvalidEvictionPolicy(t *testing.T) {
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, EvictionPolicy: "random"}); err == nil {
+		t.Errorf("expected unknown eviction policy to be rejected")
+	}
+}
+
+func TestMaxUsersPerEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUsersPerEntity: 2})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "guild", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "guild", Score: 20})
+	if err := lb.AddUser(User{ID: "u3", Entity: "guild", Score: 30}); !errors.Is(err, ErrEntityFull) {
+		t.Errorf("expected ErrEntityFull, got %v", err)
+	}
+	if _, err := lb.GetUserScore("u3"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected rejected user not to be stored, got %v", err)
+	}
+	lb.AddUser(User{ID: "u4", Entity: "other", Score: 40})
+	if err := lb.UpdateEntityByUserID("u4", "guild"); !errors.Is(err, ErrEntityFull) {
+		t.Errorf("expected ErrEntityFull on move, got %v", err)
+	}
+	if entity, _ := lb.GetUserEntity("u4"); entity != "other" {
+		t.Errorf("expected u4 to stay in other, got %s", entity)
+	}
+}
+
+func TestMaxUsersPerEntityEvict(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUsersPerEntity: 2, EvictionPolicy: EvictionLowest})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "guild", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "guild", Score: 20})
+	if err := lb.AddUser(User{ID: "u3", Entity: "guild", Score: 30}); err != nil {
+		t.Fatalf("AddUser u3 failed: %v", err)
+	}
+	if _, err := lb.GetUserScore("u1"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected u1 to be evicted, got %v", err)
+	}
+
+	lb.AddUser(User{ID: "u4", Entity: "other", Score: 40})
+	if err := lb.UpdateEntityByUserID("u4", "guild"); err != nil {
+		t.Fatalf("UpdateEntityByUserID failed: %v", err)
+	}
+	members, err := lb.GetEntityMembers("guild")
+	if err != nil || len(members) != 2 || members[0] != "u3" || members[1] != "u4" {
+		t.Errorf("expected guild members [u3 u4], got %v (err: %v)", members, err)
+	}
+	if _, err := lb.GetUserScore("u2"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected u2 to be evicted by the move, got %v", err)
+	}
+
+	// a user below the entity's lowest can't move in
+	lb.AddUser(User{ID: "u5", Entity: "other", Score: 1})
+	if err := lb.UpdateEntityByUserID("u5", "guild"); !errors.Is(err, ErrEntityFull) {
+		t.Errorf("expected ErrEntityFull for low user, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package redisboard
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CSV export columns.
+const (
+	ColumnRank   = "rank"
+	ColumnUserID = "userID"
+	ColumnEntity = "entity"
+	ColumnScore  = "score"
+)
+
+// defaultCSVColumns are exported when ExportCSV gets no columns.
+var defaultCSVColumns = []string{ColumnRank, ColumnUserID, ColumnEntity, ColumnScore}
+
+// ExportCSV writes the global leaderboard as CSV to w, e.g. for community
+// managers working in spreadsheets: a header row, then one row per user.
+// columns picks the columns and their order among ColumnRank, ColumnUserID,
+// ColumnEntity and ColumnScore; nil exports all four. Ranks are global,
+// 0-based unless OneBasedRanks is set. Fields containing commas, quotes or
+// newlines are quoted.
+// Streams the board with ZSCAN in batches, pipelining the rank lookups of
+// each batch, so memory stays bounded. Rows are in no particular order
+// (sort by rank in the spreadsheet) and, as with IterateUsers, they are not
+// a consistent snapshot.
+// Returns error if:
+// - a column is unknown
+// - writing to w fails
+// - Redis operation fails
+func (lb *Leaderboard) ExportCSV(w io.Writer, columns []string) (err error) {
+	defer lb.startOp("ExportCSV", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		columns = defaultCSVColumns
+	}
+	wantRank := false
+	for _, col := range columns {
+		switch col {
+		case ColumnRank:
+			wantRank = true
+		case ColumnUserID, ColumnEntity, ColumnScore:
+		default:
+			return fmt.Errorf("unknown CSV column %q", col)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	globalKey := lb.globalKey()
+	row := make([]string, len(columns))
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.ZScan(lb.ctx, globalKey, cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan users: %w", err)
+		}
+		users, err := lb.scannedUsers(keys)
+		if err != nil {
+			return err
+		}
+
+		var rankCmds []*redis.IntCmd
+		if wantRank && len(users) > 0 {
+			pipe := lb.client.Pipeline()
+			rankCmds = make([]*redis.IntCmd, len(users))
+			for i := range users {
+				rankCmds[i] = pipe.ZRevRank(lb.ctx, globalKey, keys[2*i])
+			}
+			if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+				return fmt.Errorf("failed to get global ranks: %w", err)
+			}
+		}
+
+		for i, u := range users {
+			if rankCmds != nil && rankCmds[i].Err() == redis.Nil {
+				continue // removed since the scan
+			}
+			for j, col := range columns {
+				switch col {
+				case ColumnRank:
+					rank := int(rankCmds[i].Val())
+					if lb.config.OneBasedRanks {
+						rank++
+					}
+					row[j] = strconv.Itoa(rank)
+				case ColumnUserID:
+					row[j] = u.ID
+				case ColumnEntity:
+					row[j] = u.Entity
+				case ColumnScore:
+					row[j] = formatScore(u.Score)
+				}
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
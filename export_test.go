@@ -0,0 +1,57 @@
+package redisboard
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", FloatScores: true, OneBasedRanks: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100.5})
+	lb.AddUser(User{ID: "smith, j", Entity: "UK", Score: 300})
+	lb.AddUser(User{ID: "u3", Score: 200})
+
+	var buf bytes.Buffer
+	if err := lb.ExportCSV(&buf, nil); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"smith, j"`) {
+		t.Errorf("expected comma field to be quoted, got:\n%s", buf.String())
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if strings.Join(rows[0], ",") != "rank,userID,entity,score" {
+		t.Errorf("unexpected header: %v", rows[0])
+	}
+	body := rows[1:]
+	sort.Slice(body, func(i, j int) bool { return body[i][0] < body[j][0] })
+	want := [][]string{
+		{"1", "smith, j", "UK", "300"},
+		{"2", "u3", "", "200"},
+		{"3", "u1", "US", "100.5"},
+	}
+	for i := range want {
+		if strings.Join(body[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d: expected %v, got %v", i, want[i], body[i])
+		}
+	}
+
+	buf.Reset()
+	if err := lb.ExportCSV(&buf, []string{"score", "userID"}); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 4 || lines[0] != "score,userID" {
+		t.Errorf("unexpected custom columns export:\n%s", buf.String())
+	}
+
+	if err := lb.ExportCSV(&buf, []string{"rank", "password"}); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
@@ -0,0 +1,1129 @@
+// gRPC interface of grpcserver, exposing the operations of the example HTTP
+// server. Ranks are 0-based, -1 if not ranked.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: leaderboard.proto
+
+package leaderboardpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // with Config.EnableMetadata
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                                                                                   // with Config.EnableNames
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_leaderboard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *User) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *User) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AddUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUserRequest) Reset() {
+	*x = AddUserRequest{}
+	mi := &file_leaderboard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUserRequest) ProtoMessage() {}
+
+func (x *AddUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUserRequest.ProtoReflect.Descriptor instead.
+func (*AddUserRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type AddUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUserResponse) Reset() {
+	*x = AddUserResponse{}
+	mi := &file_leaderboard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUserResponse) ProtoMessage() {}
+
+func (x *AddUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUserResponse.ProtoReflect.Descriptor instead.
+func (*AddUserResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{2}
+}
+
+type RemoveUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserRequest) Reset() {
+	*x = RemoveUserRequest{}
+	mi := &file_leaderboard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserRequest) ProtoMessage() {}
+
+func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserRequest.ProtoReflect.Descriptor instead.
+func (*RemoveUserRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserResponse) Reset() {
+	*x = RemoveUserResponse{}
+	mi := &file_leaderboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserResponse) ProtoMessage() {}
+
+func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserResponse.ProtoReflect.Descriptor instead.
+func (*RemoveUserResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{4}
+}
+
+type IncrementScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Delta         float64                `protobuf:"fixed64,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementScoreRequest) Reset() {
+	*x = IncrementScoreRequest{}
+	mi := &file_leaderboard_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementScoreRequest) ProtoMessage() {}
+
+func (x *IncrementScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementScoreRequest.ProtoReflect.Descriptor instead.
+func (*IncrementScoreRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *IncrementScoreRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IncrementScoreRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *IncrementScoreRequest) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type IncrementScoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementScoreResponse) Reset() {
+	*x = IncrementScoreResponse{}
+	mi := &file_leaderboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementScoreResponse) ProtoMessage() {}
+
+func (x *IncrementScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementScoreResponse.ProtoReflect.Descriptor instead.
+func (*IncrementScoreResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{6}
+}
+
+type DecrementScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Delta         float64                `protobuf:"fixed64,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecrementScoreRequest) Reset() {
+	*x = DecrementScoreRequest{}
+	mi := &file_leaderboard_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecrementScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecrementScoreRequest) ProtoMessage() {}
+
+func (x *DecrementScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecrementScoreRequest.ProtoReflect.Descriptor instead.
+func (*DecrementScoreRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DecrementScoreRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DecrementScoreRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *DecrementScoreRequest) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type DecrementScoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecrementScoreResponse) Reset() {
+	*x = DecrementScoreResponse{}
+	mi := &file_leaderboard_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecrementScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecrementScoreResponse) ProtoMessage() {}
+
+func (x *DecrementScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecrementScoreResponse.ProtoReflect.Descriptor instead.
+func (*DecrementScoreResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{8}
+}
+
+type UpdateEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEntityRequest) Reset() {
+	*x = UpdateEntityRequest{}
+	mi := &file_leaderboard_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntityRequest) ProtoMessage() {}
+
+func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateEntityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateEntityRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+type UpdateEntityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEntityResponse) Reset() {
+	*x = UpdateEntityResponse{}
+	mi := &file_leaderboard_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntityResponse) ProtoMessage() {}
+
+func (x *UpdateEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEntityResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{10}
+}
+
+type GetTopKGlobalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopKGlobalRequest) Reset() {
+	*x = GetTopKGlobalRequest{}
+	mi := &file_leaderboard_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopKGlobalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopKGlobalRequest) ProtoMessage() {}
+
+func (x *GetTopKGlobalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopKGlobalRequest.ProtoReflect.Descriptor instead.
+func (*GetTopKGlobalRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{11}
+}
+
+type GetTopKGlobalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopKGlobalResponse) Reset() {
+	*x = GetTopKGlobalResponse{}
+	mi := &file_leaderboard_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopKGlobalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopKGlobalResponse) ProtoMessage() {}
+
+func (x *GetTopKGlobalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopKGlobalResponse.ProtoReflect.Descriptor instead.
+func (*GetTopKGlobalResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTopKGlobalResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetTopKEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopKEntityRequest) Reset() {
+	*x = GetTopKEntityRequest{}
+	mi := &file_leaderboard_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopKEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopKEntityRequest) ProtoMessage() {}
+
+func (x *GetTopKEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopKEntityRequest.ProtoReflect.Descriptor instead.
+func (*GetTopKEntityRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTopKEntityRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+type GetTopKEntityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopKEntityResponse) Reset() {
+	*x = GetTopKEntityResponse{}
+	mi := &file_leaderboard_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopKEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopKEntityResponse) ProtoMessage() {}
+
+func (x *GetTopKEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopKEntityResponse.ProtoReflect.Descriptor instead.
+func (*GetTopKEntityResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTopKEntityResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankRequest) Reset() {
+	*x = GetRankRequest{}
+	mi := &file_leaderboard_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankRequest) ProtoMessage() {}
+
+func (x *GetRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankRequest.ProtoReflect.Descriptor instead.
+func (*GetRankRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRankRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetRankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GlobalRank    int64                  `protobuf:"varint,1,opt,name=global_rank,json=globalRank,proto3" json:"global_rank,omitempty"`
+	EntityRank    int64                  `protobuf:"varint,2,opt,name=entity_rank,json=entityRank,proto3" json:"entity_rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankResponse) Reset() {
+	*x = GetRankResponse{}
+	mi := &file_leaderboard_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankResponse) ProtoMessage() {}
+
+func (x *GetRankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankResponse.ProtoReflect.Descriptor instead.
+func (*GetRankResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRankResponse) GetGlobalRank() int64 {
+	if x != nil {
+		return x.GlobalRank
+	}
+	return 0
+}
+
+func (x *GetRankResponse) GetEntityRank() int64 {
+	if x != nil {
+		return x.EntityRank
+	}
+	return 0
+}
+
+type GetLeaderboardDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardDataRequest) Reset() {
+	*x = GetLeaderboardDataRequest{}
+	mi := &file_leaderboard_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardDataRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardDataRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardDataRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetLeaderboardDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetLeaderboardDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Entity        string                 `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
+	GlobalRank    int64                  `protobuf:"varint,4,opt,name=global_rank,json=globalRank,proto3" json:"global_rank,omitempty"`
+	EntityRank    int64                  `protobuf:"varint,5,opt,name=entity_rank,json=entityRank,proto3" json:"entity_rank,omitempty"`
+	TopKGlobal    []*User                `protobuf:"bytes,6,rep,name=top_k_global,json=topKGlobal,proto3" json:"top_k_global,omitempty"`
+	TopKEntity    []*User                `protobuf:"bytes,7,rep,name=top_k_entity,json=topKEntity,proto3" json:"top_k_entity,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Name          string                 `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardDataResponse) Reset() {
+	*x = GetLeaderboardDataResponse{}
+	mi := &file_leaderboard_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardDataResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardDataResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardDataResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetLeaderboardDataResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardDataResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GetLeaderboardDataResponse) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *GetLeaderboardDataResponse) GetGlobalRank() int64 {
+	if x != nil {
+		return x.GlobalRank
+	}
+	return 0
+}
+
+func (x *GetLeaderboardDataResponse) GetEntityRank() int64 {
+	if x != nil {
+		return x.EntityRank
+	}
+	return 0
+}
+
+func (x *GetLeaderboardDataResponse) GetTopKGlobal() []*User {
+	if x != nil {
+		return x.TopKGlobal
+	}
+	return nil
+}
+
+func (x *GetLeaderboardDataResponse) GetTopKEntity() []*User {
+	if x != nil {
+		return x.TopKEntity
+	}
+	return nil
+}
+
+func (x *GetLeaderboardDataResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetLeaderboardDataResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_leaderboard_proto protoreflect.FileDescriptor
+
+const file_leaderboard_proto_rawDesc = "" +
+	"\n" +
+	"\x11leaderboard.proto\x12\rredisboard.v1\"\xd4\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12=\n" +
+	"\bmetadata\x18\x04 \x03(\v2!.redisboard.v1.User.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\x0eAddUserRequest\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.redisboard.v1.UserR\x04user\"\x11\n" +
+	"\x0fAddUserResponse\",\n" +
+	"\x11RemoveUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x14\n" +
+	"\x12RemoveUserResponse\"^\n" +
+	"\x15IncrementScoreRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x01R\x05delta\"\x18\n" +
+	"\x16IncrementScoreResponse\"^\n" +
+	"\x15DecrementScoreRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x01R\x05delta\"\x18\n" +
+	"\x16DecrementScoreResponse\"F\n" +
+	"\x13UpdateEntityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\"\x16\n" +
+	"\x14UpdateEntityResponse\"\x16\n" +
+	"\x14GetTopKGlobalRequest\"B\n" +
+	"\x15GetTopKGlobalResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.redisboard.v1.UserR\x05users\".\n" +
+	"\x14GetTopKEntityRequest\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\"B\n" +
+	"\x15GetTopKEntityResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.redisboard.v1.UserR\x05users\")\n" +
+	"\x0eGetRankRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"S\n" +
+	"\x0fGetRankResponse\x12\x1f\n" +
+	"\vglobal_rank\x18\x01 \x01(\x03R\n" +
+	"globalRank\x12\x1f\n" +
+	"\ventity_rank\x18\x02 \x01(\x03R\n" +
+	"entityRank\"4\n" +
+	"\x19GetLeaderboardDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb9\x03\n" +
+	"\x1aGetLeaderboardDataResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06entity\x18\x03 \x01(\tR\x06entity\x12\x1f\n" +
+	"\vglobal_rank\x18\x04 \x01(\x03R\n" +
+	"globalRank\x12\x1f\n" +
+	"\ventity_rank\x18\x05 \x01(\x03R\n" +
+	"entityRank\x125\n" +
+	"\ftop_k_global\x18\x06 \x03(\v2\x13.redisboard.v1.UserR\n" +
+	"topKGlobal\x125\n" +
+	"\ftop_k_entity\x18\a \x03(\v2\x13.redisboard.v1.UserR\n" +
+	"topKEntity\x12S\n" +
+	"\bmetadata\x18\b \x03(\v27.redisboard.v1.GetLeaderboardDataResponse.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xae\x06\n" +
+	"\vLeaderboard\x12H\n" +
+	"\aAddUser\x12\x1d.redisboard.v1.AddUserRequest\x1a\x1e.redisboard.v1.AddUserResponse\x12Q\n" +
+	"\n" +
+	"RemoveUser\x12 .redisboard.v1.RemoveUserRequest\x1a!.redisboard.v1.RemoveUserResponse\x12]\n" +
+	"\x0eIncrementScore\x12$.redisboard.v1.IncrementScoreRequest\x1a%.redisboard.v1.IncrementScoreResponse\x12]\n" +
+	"\x0eDecrementScore\x12$.redisboard.v1.DecrementScoreRequest\x1a%.redisboard.v1.DecrementScoreResponse\x12W\n" +
+	"\fUpdateEntity\x12\".redisboard.v1.UpdateEntityRequest\x1a#.redisboard.v1.UpdateEntityResponse\x12Z\n" +
+	"\rGetTopKGlobal\x12#.redisboard.v1.GetTopKGlobalRequest\x1a$.redisboard.v1.GetTopKGlobalResponse\x12Z\n" +
+	"\rGetTopKEntity\x12#.redisboard.v1.GetTopKEntityRequest\x1a$.redisboard.v1.GetTopKEntityResponse\x12H\n" +
+	"\aGetRank\x12\x1d.redisboard.v1.GetRankRequest\x1a\x1e.redisboard.v1.GetRankResponse\x12i\n" +
+	"\x12GetLeaderboardData\x12(.redisboard.v1.GetLeaderboardDataRequest\x1a).redisboard.v1.GetLeaderboardDataResponseB7Z5github.com/lijuuu/RedisBoard/grpcserver/leaderboardpbb\x06proto3"
+
+var (
+	file_leaderboard_proto_rawDescOnce sync.Once
+	file_leaderboard_proto_rawDescData []byte
+)
+
+func file_leaderboard_proto_rawDescGZIP() []byte {
+	file_leaderboard_proto_rawDescOnce.Do(func() {
+		file_leaderboard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaderboard_proto_rawDesc), len(file_leaderboard_proto_rawDesc)))
+	})
+	return file_leaderboard_proto_rawDescData
+}
+
+var file_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_leaderboard_proto_goTypes = []any{
+	(*User)(nil),                       // 0: redisboard.v1.User
+	(*AddUserRequest)(nil),             // 1: redisboard.v1.AddUserRequest
+	(*AddUserResponse)(nil),            // 2: redisboard.v1.AddUserResponse
+	(*RemoveUserRequest)(nil),          // 3: redisboard.v1.RemoveUserRequest
+	(*RemoveUserResponse)(nil),         // 4: redisboard.v1.RemoveUserResponse
+	(*IncrementScoreRequest)(nil),      // 5: redisboard.v1.IncrementScoreRequest
+	(*IncrementScoreResponse)(nil),     // 6: redisboard.v1.IncrementScoreResponse
+	(*DecrementScoreRequest)(nil),      // 7: redisboard.v1.DecrementScoreRequest
+	(*DecrementScoreResponse)(nil),     // 8: redisboard.v1.DecrementScoreResponse
+	(*UpdateEntityRequest)(nil),        // 9: redisboard.v1.UpdateEntityRequest
+	(*UpdateEntityResponse)(nil),       // 10: redisboard.v1.UpdateEntityResponse
+	(*GetTopKGlobalRequest)(nil),       // 11: redisboard.v1.GetTopKGlobalRequest
+	(*GetTopKGlobalResponse)(nil),      // 12: redisboard.v1.GetTopKGlobalResponse
+	(*GetTopKEntityRequest)(nil),       // 13: redisboard.v1.GetTopKEntityRequest
+	(*GetTopKEntityResponse)(nil),      // 14: redisboard.v1.GetTopKEntityResponse
+	(*GetRankRequest)(nil),             // 15: redisboard.v1.GetRankRequest
+	(*GetRankResponse)(nil),            // 16: redisboard.v1.GetRankResponse
+	(*GetLeaderboardDataRequest)(nil),  // 17: redisboard.v1.GetLeaderboardDataRequest
+	(*GetLeaderboardDataResponse)(nil), // 18: redisboard.v1.GetLeaderboardDataResponse
+	nil,                                // 19: redisboard.v1.User.MetadataEntry
+	nil,                                // 20: redisboard.v1.GetLeaderboardDataResponse.MetadataEntry
+}
+var file_leaderboard_proto_depIdxs = []int32{
+	19, // 0: redisboard.v1.User.metadata:type_name -> redisboard.v1.User.MetadataEntry
+	0,  // 1: redisboard.v1.AddUserRequest.user:type_name -> redisboard.v1.User
+	0,  // 2: redisboard.v1.GetTopKGlobalResponse.users:type_name -> redisboard.v1.User
+	0,  // 3: redisboard.v1.GetTopKEntityResponse.users:type_name -> redisboard.v1.User
+	0,  // 4: redisboard.v1.GetLeaderboardDataResponse.top_k_global:type_name -> redisboard.v1.User
+	0,  // 5: redisboard.v1.GetLeaderboardDataResponse.top_k_entity:type_name -> redisboard.v1.User
+	20, // 6: redisboard.v1.GetLeaderboardDataResponse.metadata:type_name -> redisboard.v1.GetLeaderboardDataResponse.MetadataEntry
+	1,  // 7: redisboard.v1.Leaderboard.AddUser:input_type -> redisboard.v1.AddUserRequest
+	3,  // 8: redisboard.v1.Leaderboard.RemoveUser:input_type -> redisboard.v1.RemoveUserRequest
+	5,  // 9: redisboard.v1.Leaderboard.IncrementScore:input_type -> redisboard.v1.IncrementScoreRequest
+	7,  // 10: redisboard.v1.Leaderboard.DecrementScore:input_type -> redisboard.v1.DecrementScoreRequest
+	9,  // 11: redisboard.v1.Leaderboard.UpdateEntity:input_type -> redisboard.v1.UpdateEntityRequest
+	11, // 12: redisboard.v1.Leaderboard.GetTopKGlobal:input_type -> redisboard.v1.GetTopKGlobalRequest
+	13, // 13: redisboard.v1.Leaderboard.GetTopKEntity:input_type -> redisboard.v1.GetTopKEntityRequest
+	15, // 14: redisboard.v1.Leaderboard.GetRank:input_type -> redisboard.v1.GetRankRequest
+	17, // 15: redisboard.v1.Leaderboard.GetLeaderboardData:input_type -> redisboard.v1.GetLeaderboardDataRequest
+	2,  // 16: redisboard.v1.Leaderboard.AddUser:output_type -> redisboard.v1.AddUserResponse
+	4,  // 17: redisboard.v1.Leaderboard.RemoveUser:output_type -> redisboard.v1.RemoveUserResponse
+	6,  // 18: redisboard.v1.Leaderboard.IncrementScore:output_type -> redisboard.v1.IncrementScoreResponse
+	8,  // 19: redisboard.v1.Leaderboard.DecrementScore:output_type -> redisboard.v1.DecrementScoreResponse
+	10, // 20: redisboard.v1.Leaderboard.UpdateEntity:output_type -> redisboard.v1.UpdateEntityResponse
+	12, // 21: redisboard.v1.Leaderboard.GetTopKGlobal:output_type -> redisboard.v1.GetTopKGlobalResponse
+	14, // 22: redisboard.v1.Leaderboard.GetTopKEntity:output_type -> redisboard.v1.GetTopKEntityResponse
+	16, // 23: redisboard.v1.Leaderboard.GetRank:output_type -> redisboard.v1.GetRankResponse
+	18, // 24: redisboard.v1.Leaderboard.GetLeaderboardData:output_type -> redisboard.v1.GetLeaderboardDataResponse
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_leaderboard_proto_init() }
+func file_leaderboard_proto_init() {
+	if File_leaderboard_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboard_proto_rawDesc), len(file_leaderboard_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaderboard_proto_goTypes,
+		DependencyIndexes: file_leaderboard_proto_depIdxs,
+		MessageInfos:      file_leaderboard_proto_msgTypes,
+	}.Build()
+	File_leaderboard_proto = out.File
+	file_leaderboard_proto_goTypes = nil
+	file_leaderboard_proto_depIdxs = nil
+}
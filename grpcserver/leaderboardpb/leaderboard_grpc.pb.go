@@ -0,0 +1,446 @@
+// gRPC interface of grpcserver, exposing the operations of the example HTTP
+// server. Ranks are 0-based, -1 if not ranked.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: leaderboard.proto
+
+package leaderboardpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Leaderboard_AddUser_FullMethodName            = "/redisboard.v1.Leaderboard/AddUser"
+	Leaderboard_RemoveUser_FullMethodName         = "/redisboard.v1.Leaderboard/RemoveUser"
+	Leaderboard_IncrementScore_FullMethodName     = "/redisboard.v1.Leaderboard/IncrementScore"
+	Leaderboard_DecrementScore_FullMethodName     = "/redisboard.v1.Leaderboard/DecrementScore"
+	Leaderboard_UpdateEntity_FullMethodName       = "/redisboard.v1.Leaderboard/UpdateEntity"
+	Leaderboard_GetTopKGlobal_FullMethodName      = "/redisboard.v1.Leaderboard/GetTopKGlobal"
+	Leaderboard_GetTopKEntity_FullMethodName      = "/redisboard.v1.Leaderboard/GetTopKEntity"
+	Leaderboard_GetRank_FullMethodName            = "/redisboard.v1.Leaderboard/GetRank"
+	Leaderboard_GetLeaderboardData_FullMethodName = "/redisboard.v1.Leaderboard/GetLeaderboardData"
+)
+
+// LeaderboardClient is the client API for Leaderboard service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaderboardClient interface {
+	// AddUser adds a user or overwrites their score and entity.
+	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error)
+	// RemoveUser removes a user from all rankings.
+	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*RemoveUserResponse, error)
+	// IncrementScore adds delta to a user's score, creating the user if needed.
+	IncrementScore(ctx context.Context, in *IncrementScoreRequest, opts ...grpc.CallOption) (*IncrementScoreResponse, error)
+	// DecrementScore subtracts delta from a user's score.
+	DecrementScore(ctx context.Context, in *DecrementScoreRequest, opts ...grpc.CallOption) (*DecrementScoreResponse, error)
+	// UpdateEntity moves a user to another entity.
+	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error)
+	// GetTopKGlobal returns the top k users across all entities.
+	GetTopKGlobal(ctx context.Context, in *GetTopKGlobalRequest, opts ...grpc.CallOption) (*GetTopKGlobalResponse, error)
+	// GetTopKEntity returns the top k users of an entity.
+	GetTopKEntity(ctx context.Context, in *GetTopKEntityRequest, opts ...grpc.CallOption) (*GetTopKEntityResponse, error)
+	// GetRank returns a user's global and entity ranks.
+	GetRank(ctx context.Context, in *GetRankRequest, opts ...grpc.CallOption) (*GetRankResponse, error)
+	// GetLeaderboardData returns a user's score, ranks and the top k lists.
+	GetLeaderboardData(ctx context.Context, in *GetLeaderboardDataRequest, opts ...grpc.CallOption) (*GetLeaderboardDataResponse, error)
+}
+
+type leaderboardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardClient(cc grpc.ClientConnInterface) LeaderboardClient {
+	return &leaderboardClient{cc}
+}
+
+func (c *leaderboardClient) AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddUserResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_AddUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*RemoveUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveUserResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_RemoveUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) IncrementScore(ctx context.Context, in *IncrementScoreRequest, opts ...grpc.CallOption) (*IncrementScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementScoreResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_IncrementScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) DecrementScore(ctx context.Context, in *DecrementScoreRequest, opts ...grpc.CallOption) (*DecrementScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecrementScoreResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_DecrementScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateEntityResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_UpdateEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetTopKGlobal(ctx context.Context, in *GetTopKGlobalRequest, opts ...grpc.CallOption) (*GetTopKGlobalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopKGlobalResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetTopKGlobal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetTopKEntity(ctx context.Context, in *GetTopKEntityRequest, opts ...grpc.CallOption) (*GetTopKEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopKEntityResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetTopKEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetRank(ctx context.Context, in *GetRankRequest, opts ...grpc.CallOption) (*GetRankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetLeaderboardData(ctx context.Context, in *GetLeaderboardDataRequest, opts ...grpc.CallOption) (*GetLeaderboardDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardDataResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetLeaderboardData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaderboardServer is the server API for Leaderboard service.
+// All implementations must embed UnimplementedLeaderboardServer
+// for forward compatibility.
+type LeaderboardServer interface {
+	// AddUser adds a user or overwrites their score and entity.
+	AddUser(context.Context, *AddUserRequest) (*AddUserResponse, error)
+	// RemoveUser removes a user from all rankings.
+	RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error)
+	// IncrementScore adds delta to a user's score, creating the user if needed.
+	IncrementScore(context.Context, *IncrementScoreRequest) (*IncrementScoreResponse, error)
+	// DecrementScore subtracts delta from a user's score.
+	DecrementScore(context.Context, *DecrementScoreRequest) (*DecrementScoreResponse, error)
+	// UpdateEntity moves a user to another entity.
+	UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error)
+	// GetTopKGlobal returns the top k users across all entities.
+	GetTopKGlobal(context.Context, *GetTopKGlobalRequest) (*GetTopKGlobalResponse, error)
+	// GetTopKEntity returns the top k users of an entity.
+	GetTopKEntity(context.Context, *GetTopKEntityRequest) (*GetTopKEntityResponse, error)
+	// GetRank returns a user's global and entity ranks.
+	GetRank(context.Context, *GetRankRequest) (*GetRankResponse, error)
+	// GetLeaderboardData returns a user's score, ranks and the top k lists.
+	GetLeaderboardData(context.Context, *GetLeaderboardDataRequest) (*GetLeaderboardDataResponse, error)
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+// UnimplementedLeaderboardServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardServer struct{}
+
+func (UnimplementedLeaderboardServer) AddUser(context.Context, *AddUserRequest) (*AddUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddUser not implemented")
+}
+func (UnimplementedLeaderboardServer) RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveUser not implemented")
+}
+func (UnimplementedLeaderboardServer) IncrementScore(context.Context, *IncrementScoreRequest) (*IncrementScoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IncrementScore not implemented")
+}
+func (UnimplementedLeaderboardServer) DecrementScore(context.Context, *DecrementScoreRequest) (*DecrementScoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DecrementScore not implemented")
+}
+func (UnimplementedLeaderboardServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateEntity not implemented")
+}
+func (UnimplementedLeaderboardServer) GetTopKGlobal(context.Context, *GetTopKGlobalRequest) (*GetTopKGlobalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopKGlobal not implemented")
+}
+func (UnimplementedLeaderboardServer) GetTopKEntity(context.Context, *GetTopKEntityRequest) (*GetTopKEntityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopKEntity not implemented")
+}
+func (UnimplementedLeaderboardServer) GetRank(context.Context, *GetRankRequest) (*GetRankResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRank not implemented")
+}
+func (UnimplementedLeaderboardServer) GetLeaderboardData(context.Context, *GetLeaderboardDataRequest) (*GetLeaderboardDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeaderboardData not implemented")
+}
+func (UnimplementedLeaderboardServer) mustEmbedUnimplementedLeaderboardServer() {}
+func (UnimplementedLeaderboardServer) testEmbeddedByValue()                     {}
+
+// UnsafeLeaderboardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServer will
+// result in compilation errors.
+type UnsafeLeaderboardServer interface {
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+func RegisterLeaderboardServer(s grpc.ServiceRegistrar, srv LeaderboardServer) {
+	// If the following call panics, it indicates UnimplementedLeaderboardServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Leaderboard_ServiceDesc, srv)
+}
+
+func _Leaderboard_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).AddUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_AddUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).AddUser(ctx, req.(*AddUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_RemoveUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).RemoveUser(ctx, req.(*RemoveUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_IncrementScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).IncrementScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_IncrementScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).IncrementScore(ctx, req.(*IncrementScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_DecrementScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecrementScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).DecrementScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_DecrementScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).DecrementScore(ctx, req.(*DecrementScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).UpdateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_UpdateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).UpdateEntity(ctx, req.(*UpdateEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetTopKGlobal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopKGlobalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetTopKGlobal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetTopKGlobal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetTopKGlobal(ctx, req.(*GetTopKGlobalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetTopKEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopKEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetTopKEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetTopKEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetTopKEntity(ctx, req.(*GetTopKEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetRank(ctx, req.(*GetRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetLeaderboardData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetLeaderboardData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetLeaderboardData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetLeaderboardData(ctx, req.(*GetLeaderboardDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Leaderboard_ServiceDesc is the grpc.ServiceDesc for Leaderboard service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Leaderboard_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "redisboard.v1.Leaderboard",
+	HandlerType: (*LeaderboardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddUser",
+			Handler:    _Leaderboard_AddUser_Handler,
+		},
+		{
+			MethodName: "RemoveUser",
+			Handler:    _Leaderboard_RemoveUser_Handler,
+		},
+		{
+			MethodName: "IncrementScore",
+			Handler:    _Leaderboard_IncrementScore_Handler,
+		},
+		{
+			MethodName: "DecrementScore",
+			Handler:    _Leaderboard_DecrementScore_Handler,
+		},
+		{
+			MethodName: "UpdateEntity",
+			Handler:    _Leaderboard_UpdateEntity_Handler,
+		},
+		{
+			MethodName: "GetTopKGlobal",
+			Handler:    _Leaderboard_GetTopKGlobal_Handler,
+		},
+		{
+			MethodName: "GetTopKEntity",
+			Handler:    _Leaderboard_GetTopKEntity_Handler,
+		},
+		{
+			MethodName: "GetRank",
+			Handler:    _Leaderboard_GetRank_Handler,
+		},
+		{
+			MethodName: "GetLeaderboardData",
+			Handler:    _Leaderboard_GetLeaderboardData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard.proto",
+}
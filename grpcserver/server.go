@@ -0,0 +1,192 @@
+// Package grpcserver serves a redisboard.Leaderboard over gRPC, exposing the
+// operations of the example HTTP server with the strongly typed interface of
+// leaderboardpb/leaderboard.proto. It is a module of its own, so the core
+// package doesn't depend on gRPC.
+//
+//	gs := grpc.NewServer()
+//	leaderboardpb.RegisterLeaderboardServer(gs, grpcserver.New(lb))
+//	gs.Serve(listener)
+package grpcserver
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative -I leaderboardpb leaderboardpb/leaderboard.proto
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	redisboard "github.com/lijuuu/RedisBoard"
+	"github.com/lijuuu/RedisBoard/grpcserver/leaderboardpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements leaderboardpb.LeaderboardServer on a Leaderboard.
+type Server struct {
+	leaderboardpb.UnimplementedLeaderboardServer
+
+	lb *redisboard.Leaderboard
+}
+
+// New returns a Server for lb. The caller keeps ownership of lb and closes
+// it after stopping the gRPC server.
+func New(lb *redisboard.Leaderboard) *Server {
+	return &Server{lb: lb}
+}
+
+// errorCodes maps the library's sentinel errors to gRPC status codes.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{redisboard.ErrInvalidUserID, codes.InvalidArgument},
+	{redisboard.ErrInvalidEntity, codes.InvalidArgument},
+	{redisboard.ErrInvalidScore, codes.InvalidArgument},
+	{redisboard.ErrScoreOverflow, codes.InvalidArgument},
+	{redisboard.ErrInvalidMetric, codes.InvalidArgument},
+	{redisboard.ErrInvalidSeason, codes.InvalidArgument},
+	{redisboard.ErrUserNotFound, codes.NotFound},
+	{redisboard.ErrNoNeighbor, codes.NotFound},
+	{redisboard.ErrEntityExists, codes.AlreadyExists},
+	{redisboard.ErrNamespaceExists, codes.AlreadyExists},
+	{redisboard.ErrTooManyUsers, codes.ResourceExhausted},
+	{redisboard.ErrLeaderboardFull, codes.ResourceExhausted},
+	{redisboard.ErrEntityFull, codes.ResourceExhausted},
+	{redisboard.ErrTooManyEntities, codes.ResourceExhausted},
+	{redisboard.ErrConflict, codes.Aborted},
+	{redisboard.ErrNamespaceConflict, codes.FailedPrecondition},
+	{redisboard.ErrShardingUnsupported, codes.Unimplemented},
+	{redisboard.ErrClosed, codes.Unavailable},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// statusError converts a library error to a gRPC status error: sentinel
+// errors per errorCodes, plain validation errors ("invalid ...") as
+// InvalidArgument, empty rankings as NotFound, and the rest (e.g. Redis
+// failures) as Internal.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, err.Error())
+		}
+	}
+	cause := err.Error()
+	var opErr *redisboard.OpError
+	if errors.As(err, &opErr) {
+		cause = opErr.Err.Error()
+	}
+	switch {
+	case strings.HasPrefix(cause, "invalid "):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case strings.HasPrefix(cause, "no users in "):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *Server) AddUser(_ context.Context, req *leaderboardpb.AddUserRequest) (*leaderboardpb.AddUserResponse, error) {
+	u := req.GetUser()
+	user := redisboard.User{
+		ID:       u.GetId(),
+		Entity:   u.GetEntity(),
+		Score:    u.GetScore(),
+		Metadata: u.GetMetadata(),
+		Name:     u.GetName(),
+	}
+	if err := s.lb.AddUser(user); err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.AddUserResponse{}, nil
+}
+
+func (s *Server) RemoveUser(_ context.Context, req *leaderboardpb.RemoveUserRequest) (*leaderboardpb.RemoveUserResponse, error) {
+	if err := s.lb.RemoveUser(req.GetUserId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.RemoveUserResponse{}, nil
+}
+
+func (s *Server) IncrementScore(_ context.Context, req *leaderboardpb.IncrementScoreRequest) (*leaderboardpb.IncrementScoreResponse, error) {
+	if err := s.lb.IncrementScore(req.GetUserId(), req.GetEntity(), req.GetDelta()); err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.IncrementScoreResponse{}, nil
+}
+
+func (s *Server) DecrementScore(_ context.Context, req *leaderboardpb.DecrementScoreRequest) (*leaderboardpb.DecrementScoreResponse, error) {
+	if err := s.lb.DecrementScore(req.GetUserId(), req.GetEntity(), req.GetDelta()); err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.DecrementScoreResponse{}, nil
+}
+
+func (s *Server) UpdateEntity(_ context.Context, req *leaderboardpb.UpdateEntityRequest) (*leaderboardpb.UpdateEntityResponse, error) {
+	if err := s.lb.UpdateEntityByUserID(req.GetUserId(), req.GetEntity()); err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.UpdateEntityResponse{}, nil
+}
+
+func (s *Server) GetTopKGlobal(_ context.Context, _ *leaderboardpb.GetTopKGlobalRequest) (*leaderboardpb.GetTopKGlobalResponse, error) {
+	users, err := s.lb.GetTopKGlobal()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.GetTopKGlobalResponse{Users: toProtoUsers(users)}, nil
+}
+
+func (s *Server) GetTopKEntity(_ context.Context, req *leaderboardpb.GetTopKEntityRequest) (*leaderboardpb.GetTopKEntityResponse, error) {
+	users, err := s.lb.GetTopKEntity(req.GetEntity())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.GetTopKEntityResponse{Users: toProtoUsers(users)}, nil
+}
+
+func (s *Server) GetRank(_ context.Context, req *leaderboardpb.GetRankRequest) (*leaderboardpb.GetRankResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+	ranks, err := s.lb.GetUserRanks(req.GetUserId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &leaderboardpb.GetRankResponse{GlobalRank: int64(ranks.GlobalRank), EntityRank: int64(ranks.EntityRank)}, nil
+}
+
+func (s *Server) GetLeaderboardData(_ context.Context, req *leaderboardpb.GetLeaderboardDataRequest) (*leaderboardpb.GetLeaderboardDataResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+	data, err := s.lb.GetUserLeaderboardData(req.GetUserId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if !data.Exists {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUserId())
+	}
+	return &leaderboardpb.GetLeaderboardDataResponse{
+		UserId:     data.UserID,
+		Score:      data.Score,
+		Entity:     data.Entity,
+		GlobalRank: int64(data.GlobalRank),
+		EntityRank: int64(data.EntityRank),
+		TopKGlobal: toProtoUsers(data.TopKGlobal),
+		TopKEntity: toProtoUsers(data.TopKEntity),
+		Metadata:   data.Metadata,
+		Name:       data.Name,
+	}, nil
+}
+
+// toProtoUsers converts users for a response.
+func toProtoUsers(users []redisboard.User) []*leaderboardpb.User {
+	out := make([]*leaderboardpb.User, len(users))
+	for i, u := range users {
+		out[i] = &leaderboardpb.User{Id: u.ID, Entity: u.Entity, Score: u.Score, Metadata: u.Metadata, Name: u.Name}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	redisboard "github.com/lijuuu/RedisBoard"
+	"github.com/lijuuu/RedisBoard/grpcserver/leaderboardpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves a fresh leaderboard in memory and returns a client.
+func newTestClient(t *testing.T) (leaderboardpb.LeaderboardClient, *redisboard.Leaderboard) {
+	t.Helper()
+	lb, err := redisboard.New(redisboard.Config{Namespace: "grpctest", RedisAddr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("create leaderboard: %v", err)
+	}
+	lb.ForceClearLeaderBoardWithNamespacePrefix()
+
+	listener := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	leaderboardpb.RegisterLeaderboardServer(gs, New(lb))
+	go gs.Serve(listener)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		gs.Stop()
+		lb.Close()
+	})
+	return leaderboardpb.NewLeaderboardClient(conn), lb
+}
+
+func TestServer(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	for i, score := range []float64{100, 300, 200} {
+		user := &leaderboardpb.User{Id: fmt.Sprintf("u%d", i+1), Entity: "US", Score: score}
+		if _, err := client.AddUser(ctx, &leaderboardpb.AddUserRequest{User: user}); err != nil {
+			t.Fatalf("AddUser: %v", err)
+		}
+	}
+	if _, err := client.IncrementScore(ctx, &leaderboardpb.IncrementScoreRequest{UserId: "u1", Entity: "US", Delta: 250}); err != nil {
+		t.Fatalf("IncrementScore: %v", err)
+	}
+	if _, err := client.DecrementScore(ctx, &leaderboardpb.DecrementScoreRequest{UserId: "u2", Entity: "US", Delta: 250}); err != nil {
+		t.Fatalf("DecrementScore: %v", err)
+	}
+
+	topK, err := client.GetTopKGlobal(ctx, &leaderboardpb.GetTopKGlobalRequest{})
+	if err != nil {
+		t.Fatalf("GetTopKGlobal: %v", err)
+	}
+	var ids []string
+	for _, u := range topK.GetUsers() {
+		ids = append(ids, u.GetId())
+	}
+	if fmt.Sprint(ids) != "[u1 u3 u2]" {
+		t.Errorf("expected [u1 u3 u2], got %v", ids)
+	}
+
+	if _, err := client.UpdateEntity(ctx, &leaderboardpb.UpdateEntityRequest{UserId: "u3", Entity: "UK"}); err != nil {
+		t.Fatalf("UpdateEntity: %v", err)
+	}
+	rank, err := client.GetRank(ctx, &leaderboardpb.GetRankRequest{UserId: "u3"})
+	if err != nil || rank.GetGlobalRank() != 1 || rank.GetEntityRank() != 0 {
+		t.Errorf("expected ranks 1/0, got %v, %v", rank, err)
+	}
+	data, err := client.GetLeaderboardData(ctx, &leaderboardpb.GetLeaderboardDataRequest{UserId: "u1"})
+	if err != nil || data.GetScore() != 350 || data.GetEntity() != "US" || len(data.GetTopKEntity()) != 2 {
+		t.Errorf("unexpected leaderboard data %v, %v", data, err)
+	}
+
+	if _, err := client.RemoveUser(ctx, &leaderboardpb.RemoveUserRequest{UserId: "u1"}); err != nil {
+		t.Fatalf("RemoveUser: %v", err)
+	}
+	entity, err := client.GetTopKEntity(ctx, &leaderboardpb.GetTopKEntityRequest{Entity: "US"})
+	if err != nil || len(entity.GetUsers()) != 1 || entity.GetUsers()[0].GetId() != "u2" {
+		t.Errorf("expected [u2] in US, got %v, %v", entity, err)
+	}
+}
+
+func TestServerErrorCodes(t *testing.T) {
+	client, lb := newTestClient(t)
+	ctx := context.Background()
+
+	expectCode := func(name string, err error, want codes.Code) {
+		t.Helper()
+		if got := status.Code(err); got != want {
+			t.Errorf("%s: expected %v, got %v (%v)", name, want, got, err)
+		}
+	}
+
+	_, err := client.GetTopKGlobal(ctx, &leaderboardpb.GetTopKGlobalRequest{})
+	expectCode("empty top-k", err, codes.NotFound)
+	_, err = client.AddUser(ctx, &leaderboardpb.AddUserRequest{User: &leaderboardpb.User{Id: "u1", Entity: "bad entity!"}})
+	expectCode("invalid entity", err, codes.InvalidArgument)
+	_, err = client.AddUser(ctx, &leaderboardpb.AddUserRequest{User: &leaderboardpb.User{Entity: "US"}})
+	expectCode("invalid user ID", err, codes.InvalidArgument)
+	_, err = client.UpdateEntity(ctx, &leaderboardpb.UpdateEntityRequest{UserId: "ghost", Entity: "US"})
+	expectCode("unknown user", err, codes.NotFound)
+	_, err = client.GetLeaderboardData(ctx, &leaderboardpb.GetLeaderboardDataRequest{UserId: "ghost"})
+	expectCode("missing user data", err, codes.NotFound)
+
+	lb.Close()
+	_, err = client.AddUser(ctx, &leaderboardpb.AddUserRequest{User: &leaderboardpb.User{Id: "u1", Score: 1}})
+	expectCode("closed leaderboard", err, codes.Unavailable)
+}
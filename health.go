@@ -0,0 +1,113 @@
+package redisboard
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// minHealthRetry is the first retry delay after a failed health check.
+const minHealthRetry = 100 * time.Millisecond
+
+// healthMonitor tracks Redis reachability with a background PING loop.
+// go-redis reconnects on its own; the monitor only observes, so callers
+// can report degraded status instead of failing each request blindly.
+type healthMonitor struct {
+	up atomic.Bool
+
+	mu       sync.Mutex
+	onChange func(up bool)
+
+	running   bool // run loop started
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newHealthMonitor returns a monitor that reports the connection as up.
+func newHealthMonitor() *healthMonitor {
+	h := &healthMonitor{stop: make(chan struct{}), done: make(chan struct{})}
+	h.up.Store(true)
+	return h
+}
+
+// start launches the health loop in the background.
+func (h *healthMonitor) start(lb *Leaderboard, interval time.Duration) {
+	h.running = true
+	go h.run(lb, interval)
+}
+
+// run pings Redis every interval (±20% jitter) until close. After a failed
+// check it retries sooner, backing off exponentially from minHealthRetry up
+// to interval, so an outage's end is noticed quickly without hammering Redis.
+func (h *healthMonitor) run(lb *Leaderboard, interval time.Duration) {
+	defer close(h.done)
+
+	delay := interval
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-time.After(jitter(delay)):
+		}
+
+		ctx, cancel := context.WithTimeout(lb.ctx, interval)
+		err := lb.client.Ping(ctx).Err()
+		cancel()
+		h.set(err == nil)
+
+		if err == nil {
+			delay = interval
+		} else if delay >= interval {
+			delay = min(minHealthRetry, interval)
+		} else {
+			delay = min(2*delay, interval)
+		}
+	}
+}
+
+// set records the connection state, calling onChange on transitions.
+func (h *healthMonitor) set(up bool) {
+	if h.up.Swap(up) == up {
+		return
+	}
+	h.mu.Lock()
+	fn := h.onChange
+	h.mu.Unlock()
+	if fn != nil {
+		fn(up)
+	}
+}
+
+// close stops the loop, if running, and waits for it to exit.
+func (h *healthMonitor) close() {
+	h.closeOnce.Do(func() {
+		close(h.stop)
+		if h.running {
+			<-h.done
+		}
+	})
+}
+
+// jitter spreads d by ±20% so many instances don't ping in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration((rand.Float64()*0.4-0.2)*float64(d))
+}
+
+// ConnectionState reports whether Redis answered the last health check.
+// Needs Config.HealthCheckInterval; without health checks it always
+// reports true.
+func (lb *Leaderboard) ConnectionState() bool {
+	return lb.health.up.Load()
+}
+
+// OnConnectionChange registers fn to be called from the health loop when
+// the connection goes down (up=false) or comes back (up=true). Replaces any
+// previous callback; nil unregisters. Needs Config.HealthCheckInterval.
+func (lb *Leaderboard) OnConnectionChange(fn func(up bool)) {
+	lb.health.mu.Lock()
+	defer lb.health.mu.Unlock()
+	lb.health.onChange = fn
+}
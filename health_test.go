@@ -0,0 +1,83 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// outageHook fails every command while down is set, simulating lost Redis.
+type outageHook struct {
+	down atomic.Bool
+}
+
+func (h *outageHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *outageHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if h.down.Load() {
+			err := errors.New("connection refused")
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h *outageHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestConnectionState(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+	hook := &outageHook{}
+	rawClient(lb).AddHook(hook)
+	// started by hand: hooks can't be added while the loop is running
+	lb.health.start(lb, 20*time.Millisecond)
+
+	changes := make(chan bool, 4)
+	lb.OnConnectionChange(func(up bool) { changes <- up })
+	if !lb.ConnectionState() {
+		t.Fatalf("expected connection to start up")
+	}
+
+	hook.down.Store(true)
+	select {
+	case up := <-changes:
+		if up || lb.ConnectionState() {
+			t.Errorf("expected down transition")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("outage not detected")
+	}
+
+	hook.down.Store(false)
+	select {
+	case up := <-changes:
+		if !up || !lb.ConnectionState() {
+			t.Errorf("expected up transition")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("recovery not detected")
+	}
+}
+
+func TestJitterBounds(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := jitter(time.Second); d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("jitter out of bounds: %v", d)
+		}
+	}
+}
+
+func TestHealthCheckClose(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", HealthCheckInterval: time.Hour})
+	if err := lb.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
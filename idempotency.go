@@ -0,0 +1,55 @@
+package redisboard
+
+import (
+	"fmt"
+	"time"
+)
+
+// claimScript records an idempotency key unless it is already known,
+// dropping expired keys first. Returns 1 if the key was claimed.
+// KEYS[1]: idempotency zset; ARGV: key, now (unix ms), window (ms).
+const claimScript = `
+local now = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], now + tonumber(ARGV[3]), ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`
+
+// IncrementScoreIdempotent is IncrementScore for at-least-once delivery:
+// the increment is applied once per idemKey (e.g., a message ID), and
+// retries carrying the same key within Config.IdempotencyWindow are skipped.
+// The key is claimed atomically before the increment, so concurrent
+// duplicates can't both apply; if the increment then fails the claim is
+// released and a retry may apply it. Keys are remembered per namespace.
+// Returns whether the increment was applied (false for a duplicate).
+// Returns error if:
+// - idemKey is empty
+// - any IncrementScore error
+func (lb *Leaderboard) IncrementScoreIdempotent(userID, entity string, delta float64, idemKey string) (_ bool, err error) {
+	defer lb.startOp("IncrementScoreIdempotent", userID, entity).end(&err)
+	if idemKey == "" {
+		return false, fmt.Errorf("invalid idempotency key")
+	}
+
+	now := time.Now().UnixMilli()
+	window := lb.config.IdempotencyWindow.Milliseconds()
+	res, err := lb.evalScript(claimScript, []string{lb.idempotencyKey()}, idemKey, now, window)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if claimed, _ := res.(int64); claimed == 0 {
+		return false, nil
+	}
+
+	if err := lb.IncrementScore(userID, entity, delta); err != nil {
+		if relErr := lb.client.ZRem(lb.ctx, lb.idempotencyKey(), idemKey).Err(); relErr != nil {
+			return false, fmt.Errorf("%w (failed to release idempotency key: %v)", err, relErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
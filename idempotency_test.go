@@ -0,0 +1,68 @@
+package redisboard
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIncrementScoreIdempotent(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", IdempotencyWindow: 200 * time.Millisecond})
+	defer lb.Close()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	applied := 0
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := lb.IncrementScoreIdempotent("u1", "US", 10, "msg-1")
+			if err != nil {
+				t.Errorf("IncrementScoreIdempotent: %v", err)
+			}
+			if ok {
+				mu.Lock()
+				applied++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if applied != 1 {
+		t.Errorf("expected one delivery applied, got %d", applied)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 10 {
+		t.Errorf("expected score 10, got %v", score)
+	}
+
+	if ok, _ := lb.IncrementScoreIdempotent("u1", "US", 10, "msg-2"); !ok {
+		t.Error("expected a new key to apply")
+	}
+
+	// the window expires and the key may apply again
+	time.Sleep(250 * time.Millisecond)
+	if ok, _ := lb.IncrementScoreIdempotent("u1", "US", 10, "msg-1"); !ok {
+		t.Error("expected key to be forgotten after the window")
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 30 {
+		t.Errorf("expected score 30, got %v", score)
+	}
+}
+
+func TestIncrementScoreIdempotentFailure(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if _, err := lb.IncrementScoreIdempotent("u1", "bad entity", 10, "msg-1"); !errors.Is(err, ErrInvalidEntity) {
+		t.Fatalf("expected ErrInvalidEntity, got %v", err)
+	}
+	// the failed delivery released its key
+	if ok, err := lb.IncrementScoreIdempotent("u1", "US", 10, "msg-1"); !ok || err != nil {
+		t.Errorf("expected retry to apply, got %v, %v", ok, err)
+	}
+	if _, err := lb.IncrementScoreIdempotent("u1", "US", 10, ""); err == nil {
+		t.Error("expected error for empty key")
+	}
+}
@@ -0,0 +1,45 @@
+package redisboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// strayEntities returns the entities other than entity whose ranking holds
+// userID, sorted, for CheckIntegrity. A user belongs to one entity ranking
+// at most; more point at a write that moved them without cleaning up, the
+// stray members Verify counts and Repair removes.
+// SCANs every entity key and checks each with one pipelined ZSCORE.
+func (lb *Leaderboard) strayEntities(userID, entity string) ([]string, error) {
+	prefix := lb.entityKey("")
+	entityKeys, err := lb.scanKeys(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := lb.reader.Pipeline()
+	cmds := make(map[string]*redis.FloatCmd, len(entityKeys))
+	for _, entityKey := range entityKeys {
+		if other := strings.TrimPrefix(entityKey, prefix); other != entity {
+			cmds[other] = pipe.ZScore(lb.ctx, entityKey, userID)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil, nil
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to check entity memberships: %w", err)
+	}
+
+	var strays []string
+	for other, cmd := range cmds {
+		if cmd.Err() == nil {
+			strays = append(strays, other)
+		}
+	}
+	sort.Strings(strays)
+	return strays, nil
+}
@@ -0,0 +1,45 @@
+package redisboard
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", CheckIntegrity: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 90})
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil || data.StrayEntities != nil {
+		t.Errorf("expected no stray entities, got %v, %v", data.StrayEntities, err)
+	}
+
+	// stale memberships left behind by a move that didn't clean up
+	lb.client.ZAdd(lb.ctx, lb.entityKey("UK"), redis.Z{Score: 50, Member: "u1"})
+	lb.client.ZAdd(lb.ctx, lb.entityKey("FR"), redis.Z{Score: 40, Member: "u1"})
+	data, err = lb.GetUserLeaderboardData("u1")
+	if err != nil || !reflect.DeepEqual(data.StrayEntities, []string{"FR", "UK"}) {
+		t.Errorf("expected stray entities [FR UK], got %v, %v", data.StrayEntities, err)
+	}
+	if data.Entity != "US" || data.EntityRank != 0 {
+		t.Errorf("expected the mapped entity's data, got %s rank %d", data.Entity, data.EntityRank)
+	}
+	if data, _ := lb.GetUserLeaderboardData("u2"); data.StrayEntities != nil {
+		t.Errorf("expected no stray entities for u2, got %v", data.StrayEntities)
+	}
+}
+
+func TestCheckIntegrityDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.client.ZAdd(lb.ctx, lb.entityKey("UK"), redis.Z{Score: 50, Member: "u1"})
+	if data, err := lb.GetUserLeaderboardData("u1"); err != nil || data.StrayEntities != nil {
+		t.Errorf("expected no integrity check by default, got %v, %v", data.StrayEntities, err)
+	}
+}
@@ -0,0 +1,162 @@
+package redisboard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IterateUsers calls fn for every user on the global leaderboard.
+// Walks the global ranking (each GlobalShards partition in turn) with ZSCAN
+// in batches and enriches each batch with entities (and metadata and names
+// if enabled) via HMGET, so memory stays bounded regardless of board size. Users are visited in no particular order.
+// Not a consistent snapshot: users written during iteration may be missed
+// or visited twice, as with any Redis SCAN.
+// Stops and returns fn's error if fn fails.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) IterateUsers(fn func(User) error) error {
+	for _, globalKey := range lb.globalKeys() {
+		var cursor uint64
+		for {
+			keys, next, err := lb.client.ZScan(lb.ctx, globalKey, cursor, "", int64(lb.config.BatchSize)).Result()
+			if err != nil {
+				return fmt.Errorf("failed to scan users: %w", err)
+			}
+
+			users, err := lb.scannedUsers(keys)
+			if err != nil {
+				return err
+			}
+			for _, u := range users {
+				if err := fn(u); err != nil {
+					return err
+				}
+			}
+
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// StreamTopKGlobal streams the global top k users, best first, for K too
+// large to hold in one slice (e.g., exporting the top 100,000). Pages
+// through the ranking with ZREVRANGE in batches, enriching each batch like
+// GetTopKGlobal, and sends the users as each batch is ready. TieBreakField
+// is not applied: equal scores keep Redis' order. The cache is bypassed.
+// Both channels are closed when the stream ends, users first; receive
+// from errs after draining users. A page is not a snapshot: users moving
+// between pages during the stream may be skipped or sent twice.
+// The stream stops early, sending ctx.Err() on errs, if ctx is cancelled.
+// Sends error if:
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) StreamTopKGlobal(ctx context.Context) (<-chan User, <-chan error) {
+	users := make(chan User)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(users)
+		if err := lb.streamTopKGlobal(ctx, users); err != nil {
+			errs <- err
+		}
+	}()
+	return users, errs
+}
+
+// streamTopKGlobal implements StreamTopKGlobal, sending users on out.
+func (lb *Leaderboard) streamTopKGlobal(ctx context.Context, out chan<- User) (err error) {
+	defer lb.startOp("StreamTopKGlobal", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return err
+	}
+	ctx = lb.withCloseState(ctx)
+	globalKey := lb.globalKey()
+	k := int64(lb.config.K)
+
+	batch := int64(lb.config.BatchSize)
+	for start := int64(0); start < k; start += batch {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		stop := min(start+batch, k) - 1
+		members, err := lb.reader.ZRevRangeWithScores(ctx, globalKey, start, stop).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch global top-k: %w", err)
+		}
+		page, err := lb.enrichGlobalUsers(members)
+		if err != nil {
+			return fmt.Errorf("failed to fetch entities: %w", err)
+		}
+		for _, u := range page {
+			select {
+			case out <- u:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if int64(len(members)) <= stop-start {
+			return nil // ranking exhausted
+		}
+	}
+	return nil
+}
+
+// scannedUsers converts a ZSCAN member/score batch into enriched users.
+func (lb *Leaderboard) scannedUsers(keys []string) ([]User, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	users := make([]User, 0, len(keys)/2)
+	ids := make([]string, 0, len(keys)/2)
+	for i := 0; i+1 < len(keys); i += 2 {
+		score, err := strconv.ParseFloat(keys[i+1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse score of %s: %w", keys[i], err)
+		}
+		id, _ := lb.parseMember(keys[i])
+		users = append(users, User{ID: id, Score: score})
+		ids = append(ids, id)
+	}
+
+	pipe := lb.client.Pipeline()
+	entitiesCmd := pipe.HMGet(lb.ctx, lb.entitiesKey(), ids...)
+	var metaCmd, namesCmd *redis.SliceCmd
+	if lb.config.EnableMetadata {
+		metaCmd = pipe.HMGet(lb.ctx, lb.metaKey(), ids...)
+	}
+	if lb.config.EnableNames {
+		namesCmd = pipe.HMGet(lb.ctx, lb.namesKey(), ids...)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+
+	for i, v := range entitiesCmd.Val() {
+		if entity, ok := v.(string); ok {
+			users[i].Entity = entity
+		}
+	}
+	if metaCmd != nil {
+		for i, v := range metaCmd.Val() {
+			raw, _ := v.(string)
+			meta, err := decodeMetadata(raw)
+			if err != nil {
+				return nil, err
+			}
+			users[i].Metadata = meta
+		}
+	}
+	if namesCmd != nil {
+		for i, v := range namesCmd.Val() {
+			users[i].Name, _ = v.(string)
+		}
+	}
+	return users, nil
+}
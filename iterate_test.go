@@ -0,0 +1,86 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIterateUsers(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	for i := 0; i < 25; i++ {
+		lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)})
+	}
+
+	seen := make(map[string]User)
+	err := lb.IterateUsers(func(u User) error {
+		seen[u.ID] = u
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateUsers: %v", err)
+	}
+	if len(seen) != 25 {
+		t.Errorf("expected 25 users, got %d", len(seen))
+	}
+	if u := seen["u7"]; u.Score != 7 || u.Entity != "US" {
+		t.Errorf("unexpected user: %+v", u)
+	}
+}
+
+func TestIterateUsersStopsOnError(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 1})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 2})
+
+	stop := errors.New("stop")
+	calls := 0
+	err := lb.IterateUsers(func(u User) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected stop after 1 call, got %d calls, err: %v", calls, err)
+	}
+}
+
+func TestStreamTopKGlobal(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2500})
+	defer lb.Close()
+
+	// more users than one page, fewer than K
+	users := make([]User, 2300)
+	for i := range users {
+		users[i] = User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)}
+	}
+	if err := lb.AddUsers(users, nil); err != nil {
+		t.Fatalf("AddUsers: %v", err)
+	}
+
+	stream, errs := lb.StreamTopKGlobal(context.Background())
+	n := 0
+	for u := range stream {
+		if want := fmt.Sprintf("u%d", 2299-n); u.ID != want || u.Entity != "US" {
+			t.Fatalf("position %d: expected %s in US, got %+v", n, want, u)
+		}
+		n++
+	}
+	if err := <-errs; err != nil || n != 2300 {
+		t.Errorf("expected 2300 users, got %d, err: %v", n, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, errs = lb.StreamTopKGlobal(ctx)
+	<-stream
+	cancel()
+	for range stream {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
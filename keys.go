@@ -0,0 +1,149 @@
+package redisboard
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Redis key structure (":" is Config.KeySeparator). {namespace} below is
+// prefixed with Config.KeyPrefix verbatim (e.g., "prod:game1"), and with
+// Config.Season it stands for {namespace}:s{season}:
+// {namespace}:global                         -> zset of all users and scores
+// {namespace}:global:{n}                     -> zset partition n of the global ranking (GlobalShards only, replaces global)
+// {namespace}:user:entities                  -> hash mapping users to entities
+// {namespace}:entity:{code}                  -> zset of users/scores per entity
+// {namespace}:meta                           -> hash mapping users to JSON metadata (EnableMetadata only)
+// {namespace}:names                          -> hash mapping users to display names (EnableNames only)
+// {namespace}:ranks                          -> hash mapping users to snapshot global ranks (RankSnapshotInterval only)
+// {namespace}:bestranks                      -> hash mapping users to their best global ranks (TrackBestRank only)
+// {namespace}:metrics                        -> set of known metric names
+// {namespace}:entities                       -> set of known entity codes (EntityLimitPolicy only)
+// {namespace}:totals                         -> zset of entities by summed user score (EntityTotals only)
+// {namespace}:events:rank                    -> Pub/Sub channel of RankChange events (PublishRankChanges only)
+// {namespace}:activity                       -> zset of users by last score update, unix ms (TrackActivity only)
+// {namespace}:idempotency                    -> zset of idempotency keys by expiry, unix ms
+// {namespace}:archive:{label}                -> string, JSON of the top users archived by ArchiveTopK
+// {namespace}:metric:{metric}:global         -> zset of all users and metric scores
+// {namespace}:metric:{metric}:entity:{code}  -> zset of users/metric scores per entity
+//
+// Every key is built by the methods below; never concatenate keys inline.
+
+// key joins the namespace (and season) and parts with the configured
+// separator.
+func (lb *Leaderboard) key(parts ...string) string {
+	sep := lb.config.KeySeparator
+	return lb.keyPrefix() + sep + strings.Join(parts, sep)
+}
+
+// keyPrefix returns the namespace prefix, followed by the season segment
+// if set.
+func (lb *Leaderboard) keyPrefix() string {
+	if lb.config.Season == "" {
+		return lb.namespacePrefix()
+	}
+	return lb.namespacePrefix() + lb.config.KeySeparator + seasonPrefix + lb.config.Season
+}
+
+// namespacePrefix returns Config.KeyPrefix followed by the namespace.
+func (lb *Leaderboard) namespacePrefix() string {
+	return lb.config.KeyPrefix + lb.config.Namespace
+}
+
+// keyPattern returns a SCAN pattern matching every key of the namespace
+// (or season). The default season's pattern also matches named seasons.
+func (lb *Leaderboard) keyPattern() string {
+	return lb.keyPrefix() + lb.config.KeySeparator + "*"
+}
+
+// globalKey returns the key of the global ranking.
+func (lb *Leaderboard) globalKey() string {
+	return lb.key("global")
+}
+
+// globalPartitionKey returns the key of partition n of the global ranking.
+func (lb *Leaderboard) globalPartitionKey(n int) string {
+	return lb.key("global", strconv.Itoa(n))
+}
+
+// entitiesKey returns the key of the user to entity mapping.
+func (lb *Leaderboard) entitiesKey() string {
+	return lb.key("user", "entities")
+}
+
+// entityKey returns the key of an entity's ranking.
+func (lb *Leaderboard) entityKey(code string) string {
+	return lb.key("entity", code)
+}
+
+// metaKey returns the key of the user metadata hash.
+func (lb *Leaderboard) metaKey() string {
+	return lb.key("meta")
+}
+
+// namesKey returns the key of the user display name hash.
+func (lb *Leaderboard) namesKey() string {
+	return lb.key("names")
+}
+
+// ranksKey returns the key of the global rank snapshot.
+func (lb *Leaderboard) ranksKey() string {
+	return lb.key("ranks")
+}
+
+// bestRanksKey returns the key of the users' best global ranks.
+func (lb *Leaderboard) bestRanksKey() string {
+	return lb.key("bestranks")
+}
+
+// activityKey returns the key of the users' last-activity ranking.
+func (lb *Leaderboard) activityKey() string {
+	return lb.key("activity")
+}
+
+// idempotencyKey returns the key of the processed idempotency keys.
+func (lb *Leaderboard) idempotencyKey() string {
+	return lb.key("idempotency")
+}
+
+// archiveKey returns the key of the top users archived under label.
+func (lb *Leaderboard) archiveKey(label string) string {
+	return lb.key("archive", label)
+}
+
+// rankEventsChannel returns the Pub/Sub channel of rank changes.
+func (lb *Leaderboard) rankEventsChannel() string {
+	return lb.key("events", "rank")
+}
+
+// metricsKey returns the key of the set of known metric names.
+func (lb *Leaderboard) metricsKey() string {
+	return lb.key("metrics")
+}
+
+// entityCodesKey returns the key of the set of known entity codes.
+func (lb *Leaderboard) entityCodesKey() string {
+	return lb.key("entities")
+}
+
+// entityTotalsKey returns the key of the ranking of entities by total score.
+func (lb *Leaderboard) entityTotalsKey() string {
+	return lb.key("totals")
+}
+
+// metricGlobalKey returns the global ranking key of the given metric.
+// The empty metric is the default board.
+func (lb *Leaderboard) metricGlobalKey(metric string) string {
+	if metric == "" {
+		return lb.globalKey()
+	}
+	return lb.key("metric", metric, "global")
+}
+
+// metricEntityKey returns the entity ranking key of the given metric.
+// The empty metric is the default board.
+func (lb *Leaderboard) metricEntityKey(metric, entity string) string {
+	if metric == "" {
+		return lb.entityKey(entity)
+	}
+	return lb.key("metric", metric, "entity", entity)
+}
@@ -0,0 +1,72 @@
+package redisboard
+
+import "testing"
+
+func TestKeys(t *testing.T) {
+	lb := &Leaderboard{config: Config{Namespace: "game1", KeySeparator: ":"}}
+
+	tests := []struct {
+		got, want string
+	}{
+		{lb.globalKey(), "game1:global"},
+		{lb.globalPartitionKey(3), "game1:global:3"},
+		{lb.entitiesKey(), "game1:user:entities"},
+		{lb.entityKey("US"), "game1:entity:US"},
+		{lb.metaKey(), "game1:meta"},
+		{lb.metricsKey(), "game1:metrics"},
+		{lb.entityCodesKey(), "game1:entities"},
+		{lb.entityTotalsKey(), "game1:totals"},
+		{lb.namesKey(), "game1:names"},
+		{lb.ranksKey(), "game1:ranks"},
+		{lb.activityKey(), "game1:activity"},
+		{lb.bestRanksKey(), "game1:bestranks"},
+		{lb.idempotencyKey(), "game1:idempotency"},
+		{lb.archiveKey("2024-w12"), "game1:archive:2024-w12"},
+		{lb.metricGlobalKey("kills"), "game1:metric:kills:global"},
+		{lb.metricEntityKey("kills", "US"), "game1:metric:kills:entity:US"},
+		{lb.metricGlobalKey(""), "game1:global"},
+		{lb.metricEntityKey("", "US"), "game1:entity:US"},
+		{lb.keyPattern(), "game1:*"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("expected key %s, got %s", tt.want, tt.got)
+		}
+	}
+}
+
+func TestKeysSeason(t *testing.T) {
+	lb := &Leaderboard{config: Config{Namespace: "game1", KeySeparator: ":", Season: "2024"}}
+
+	if got := lb.globalKey(); got != "game1:s2024:global" {
+		t.Errorf("expected game1:s2024:global, got %s", got)
+	}
+	if got := lb.keyPattern(); got != "game1:s2024:*" {
+		t.Errorf("expected game1:s2024:*, got %s", got)
+	}
+}
+
+func TestKeysPrefix(t *testing.T) {
+	lb := &Leaderboard{config: Config{KeyPrefix: "prod:", Namespace: "game1", KeySeparator: ":", Season: "2024"}}
+
+	if got := lb.globalKey(); got != "prod:game1:s2024:global" {
+		t.Errorf("expected prod:game1:s2024:global, got %s", got)
+	}
+	if got := lb.keyPattern(); got != "prod:game1:s2024:*" {
+		t.Errorf("expected prod:game1:s2024:*, got %s", got)
+	}
+}
+
+func TestKeysSeparator(t *testing.T) {
+	lb := &Leaderboard{config: Config{Namespace: "game1", KeySeparator: "/"}}
+
+	if got := lb.entitiesKey(); got != "game1/user/entities" {
+		t.Errorf("expected game1/user/entities, got %s", got)
+	}
+	if got := lb.metricEntityKey("xp", "UK"); got != "game1/metric/xp/entity/UK" {
+		t.Errorf("expected game1/metric/xp/entity/UK, got %s", got)
+	}
+	if got := lb.keyPattern(); got != "game1/*" {
+		t.Errorf("expected game1/*, got %s", got)
+	}
+}
@@ -0,0 +1,158 @@
+// Payload of the {namespace}:events:rank Pub/Sub channel with the
+// Config.EventCodec "protobuf" (EventCodecProtobuf). The Go package encodes
+// and decodes it through the generated leaderboard_event.pb.go
+// (RankChange.MarshalProto and UnmarshalProto); other languages can
+// generate their bindings from here.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: leaderboard_event.proto
+
+package redisboard
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LeaderboardEvent is a RankChange: a user's global rank before and after
+// AddUser, IncrementScore or DecrementScore.
+type LeaderboardEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldRank       int64                  `protobuf:"zigzag64,2,opt,name=old_rank,json=oldRank,proto3" json:"old_rank,omitempty"` // global rank before the write, -1 if not ranked
+	NewRank       int64                  `protobuf:"zigzag64,3,opt,name=new_rank,json=newRank,proto3" json:"new_rank,omitempty"` // global rank after the write, -1 if not ranked
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`                     // score after the write
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEvent) Reset() {
+	*x = LeaderboardEvent{}
+	mi := &file_leaderboard_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEvent) ProtoMessage() {}
+
+func (x *LeaderboardEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEvent.ProtoReflect.Descriptor instead.
+func (*LeaderboardEvent) Descriptor() ([]byte, []int) {
+	return file_leaderboard_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LeaderboardEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEvent) GetOldRank() int64 {
+	if x != nil {
+		return x.OldRank
+	}
+	return 0
+}
+
+func (x *LeaderboardEvent) GetNewRank() int64 {
+	if x != nil {
+		return x.NewRank
+	}
+	return 0
+}
+
+func (x *LeaderboardEvent) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_leaderboard_event_proto protoreflect.FileDescriptor
+
+const file_leaderboard_event_proto_rawDesc = "" +
+	"\n" +
+	"\x17leaderboard_event.proto\x12\n" +
+	"redisboard\"w\n" +
+	"\x10LeaderboardEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bold_rank\x18\x02 \x01(\x12R\aoldRank\x12\x19\n" +
+	"\bnew_rank\x18\x03 \x01(\x12R\anewRank\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05scoreB)Z'github.com/lijuuu/RedisBoard;redisboardb\x06proto3"
+
+var (
+	file_leaderboard_event_proto_rawDescOnce sync.Once
+	file_leaderboard_event_proto_rawDescData []byte
+)
+
+func file_leaderboard_event_proto_rawDescGZIP() []byte {
+	file_leaderboard_event_proto_rawDescOnce.Do(func() {
+		file_leaderboard_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaderboard_event_proto_rawDesc), len(file_leaderboard_event_proto_rawDesc)))
+	})
+	return file_leaderboard_event_proto_rawDescData
+}
+
+var file_leaderboard_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_leaderboard_event_proto_goTypes = []any{
+	(*LeaderboardEvent)(nil), // 0: redisboard.LeaderboardEvent
+}
+var file_leaderboard_event_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_leaderboard_event_proto_init() }
+func file_leaderboard_event_proto_init() {
+	if File_leaderboard_event_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboard_event_proto_rawDesc), len(file_leaderboard_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_leaderboard_event_proto_goTypes,
+		DependencyIndexes: file_leaderboard_event_proto_depIdxs,
+		MessageInfos:      file_leaderboard_event_proto_msgTypes,
+	}.Build()
+	File_leaderboard_event_proto = out.File
+	file_leaderboard_event_proto_goTypes = nil
+	file_leaderboard_event_proto_depIdxs = nil
+}
@@ -0,0 +1,182 @@
+package redisboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memberSeparator separates user ID and entity in global ranking members
+// with Config.EntityInMember. Entities may not contain it.
+const memberSeparator = "|"
+
+// Global ranking member encoding.
+// By default global members are plain user IDs and entities are looked up
+// in the {namespace}:user:entities hash, one HGET per top-k entry.
+// With Config.EntityInMember, global members are "{userID}|{entity}", so a
+// single ZREVRANGE yields both. The hash is still kept as the userID ->
+// entity index: point lookups (rank, score of one user) resolve the member
+// through it, costing one extra round trip there. Entity and metric rankings
+// always hold plain user IDs.
+
+// memberFor returns the global ranking member of a user in entity.
+func (lb *Leaderboard) memberFor(userID, entity string) string {
+	if !lb.config.EntityInMember {
+		return userID
+	}
+	return userID + memberSeparator + entity
+}
+
+// parseMember splits a global ranking member into user ID and entity.
+// The entity is empty in the default (hash) mode.
+func (lb *Leaderboard) parseMember(member string) (userID, entity string) {
+	if !lb.config.EntityInMember {
+		return member, ""
+	}
+	i := strings.LastIndex(member, memberSeparator)
+	if i < 0 {
+		return member, ""
+	}
+	return member[:i], member[i+len(memberSeparator):]
+}
+
+// resolveMember returns a user's global ranking member, looking up the
+// entity through c in EntityInMember mode.
+func (lb *Leaderboard) resolveMember(c RedisCommands, userID string) (string, error) {
+	if !lb.config.EntityInMember {
+		return userID, nil
+	}
+	entity, err := c.HGet(lb.ctx, lb.entitiesKey(), userID).Result()
+	if err != nil && err != redis.Nil {
+		return "", fmt.Errorf("failed to get user entity: %w", err)
+	}
+	return lb.memberFor(userID, entity), nil
+}
+
+// resolveMembers is resolveMember for many users in one round trip.
+func (lb *Leaderboard) resolveMembers(c RedisCommands, userIDs []string) ([]string, error) {
+	if !lb.config.EntityInMember || len(userIDs) == 0 {
+		return userIDs, nil
+	}
+	entities, err := c.HMGet(lb.ctx, lb.entitiesKey(), userIDs...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user entities: %w", err)
+	}
+	members := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		entity, _ := entities[i].(string)
+		members[i] = lb.memberFor(userID, entity)
+	}
+	return members, nil
+}
+
+// relocateScript points a user's entity mapping at a new entity and, if the
+// user is ranked, renames their global member to match, atomically.
+// KEYS[1]: global ranking, KEYS[2]: entity mapping; ARGV: user ID, entity.
+const relocateScript = `
+local old = redis.call('HGET', KEYS[2], ARGV[1]) or ''
+local from = ARGV[1] .. '` + memberSeparator + `' .. old
+local to = ARGV[1] .. '` + memberSeparator + `' .. ARGV[2]
+if from ~= to then
+	local score = redis.call('ZSCORE', KEYS[1], from)
+	if score then
+		redis.call('ZREM', KEYS[1], from)
+		redis.call('ZADD', KEYS[1], score, to)
+	end
+end
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+return 1
+`
+
+// setEntity queues the entity mapping update of a user on pipe. In
+// EntityInMember mode it also renames the user's global member, so it must
+// be queued before any write to memberFor(userID, entity). The relocate
+// script is sent in full rather than by SHA: a queued EVALSHA can't be
+// reloaded after NOSCRIPT (e.g. SCRIPT FLUSH or a Redis restart) before
+// the rest of the pipeline runs, and inside MULTI it would fail only that
+// command and break atomicity.
+func (lb *Leaderboard) setEntity(pipe redis.Pipeliner, userID, entity string) redis.Cmder {
+	if !lb.config.EntityInMember {
+		return pipe.HSet(lb.ctx, lb.entitiesKey(), userID, entity)
+	}
+	return pipe.Eval(lb.ctx, relocateScript, []string{lb.globalKey(), lb.entitiesKey()}, userID, entity)
+}
+
+// relocate runs relocateScript directly, for writes that can't queue
+// setEntity ahead of their global write. No-op in the default mode.
+func (lb *Leaderboard) relocate(userID, entity string) error {
+	if !lb.config.EntityInMember {
+		return nil
+	}
+	_, err := lb.evalScript(relocateScript, []string{lb.globalKey(), lb.entitiesKey()}, userID, entity)
+	if err != nil {
+		return fmt.Errorf("failed to update user entity: %w", conflictErr(err))
+	}
+	return nil
+}
+
+// MigrateEntityInMember rewrites the global ranking into the member encoding
+// selected by Config.EntityInMember: plain user IDs become "{userID}|{entity}"
+// when it is set, and encoded members become plain IDs when it is not.
+// Run it once, with writes paused, when switching the option.
+// The board is rebuilt in a temporary key in chunks and swapped in with
+// RENAME, so readers never see a half-migrated ranking.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) MigrateEntityInMember() (err error) {
+	defer lb.startOp("MigrateEntityInMember", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return err
+	}
+	defer lb.topKCache.invalidate()
+
+	globalKey := lb.globalKey()
+	tmpKey := lb.key("global", "migrating")
+	if err := lb.client.Del(lb.ctx, tmpKey).Err(); err != nil {
+		return fmt.Errorf("failed to reset migration key: %w", err)
+	}
+
+	batch := int64(lb.config.BatchSize)
+	for start := int64(0); ; start += batch {
+		members, err := lb.client.ZRangeWithScores(lb.ctx, globalKey, start, start+batch-1).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch users: %w", err)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		ids := make([]string, len(members))
+		for i, m := range members {
+			ids[i], _ = m.Member.(string)
+			if !lb.config.EntityInMember {
+				// members are still encoded: strip the entity
+				if j := strings.LastIndex(ids[i], memberSeparator); j >= 0 {
+					ids[i] = ids[i][:j]
+				}
+			}
+		}
+		converted, err := lb.resolveMembers(lb.client, ids)
+		if err != nil {
+			return err
+		}
+		for i := range members {
+			members[i].Member = converted[i]
+		}
+		if err := lb.client.ZAdd(lb.ctx, tmpKey, members...).Err(); err != nil {
+			return fmt.Errorf("failed to write migrated users: %w", err)
+		}
+	}
+
+	n, err := lb.client.Exists(lb.ctx, tmpKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check migrated users: %w", err)
+	}
+	if n == 0 {
+		return nil // empty board, nothing to swap in
+	}
+	if err := lb.client.Rename(lb.ctx, tmpKey, globalKey).Err(); err != nil {
+		return fmt.Errorf("failed to swap in migrated users: %w", err)
+	}
+	return nil
+}
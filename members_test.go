@@ -0,0 +1,189 @@
+package redisboard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEntityInMember(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5, EntityInMember: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u3", Score: 50})
+
+	if n, _ := lb.client.ZScore(lb.ctx, lb.globalKey(), "u1|US").Result(); n != 100 {
+		t.Errorf("expected encoded member u1|US with score 100, got %v", n)
+	}
+
+	topK, err := lb.GetTopKGlobal()
+	if err != nil {
+		t.Fatalf("GetTopKGlobal failed: %v", err)
+	}
+	if len(topK) != 3 || topK[0].ID != "u2" || topK[0].Entity != "UK" || topK[2].Entity != "" {
+		t.Errorf("unexpected top-k: %+v", topK)
+	}
+
+	// changing entity while scoring renames the member instead of duplicating it
+	if err := lb.IncrementScore("u1", "CA", 150); err != nil {
+		t.Fatalf("IncrementScore failed: %v", err)
+	}
+	if n := lb.client.ZCard(lb.ctx, lb.globalKey()).Val(); n != 3 {
+		t.Errorf("expected 3 global members, got %d", n)
+	}
+	if score, err := lb.GetUserScore("u1"); err != nil || score != 250 {
+		t.Errorf("expected score 250, got %v (err: %v)", score, err)
+	}
+	if rank, _ := lb.GetRankGlobal("u1"); rank != 0 {
+		t.Errorf("expected u1 at rank 0, got %d", rank)
+	}
+
+	if err := lb.UpdateEntityByUserID("u1", "DE"); err != nil {
+		t.Fatalf("UpdateEntityByUserID failed: %v", err)
+	}
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil || !data.Exists || data.Entity != "DE" || data.Score != 250 || data.GlobalRank != 0 {
+		t.Errorf("unexpected leaderboard data: %+v (err: %v)", data, err)
+	}
+	ranks, _ := lb.GetRanksGlobal([]string{"u1", "u2", "missing"})
+	if ranks["u1"] != 0 || ranks["u2"] != 1 || ranks["missing"] != -1 {
+		t.Errorf("unexpected ranks: %v", ranks)
+	}
+
+	var seen []User
+	lb.IterateUsers(func(u User) error {
+		seen = append(seen, u)
+		return nil
+	})
+	if len(seen) != 3 {
+		t.Errorf("expected 3 iterated users, got %+v", seen)
+	}
+
+	if err := lb.RemoveEntity("UK", false); err != nil {
+		t.Fatalf("RemoveEntity failed: %v", err)
+	}
+	if entity, _ := lb.GetUserEntity("u2"); entity != "" {
+		t.Errorf("expected u2 entity to be cleared, got %s", entity)
+	}
+	if score, err := lb.GetUserScore("u2"); err != nil || score != 200 {
+		t.Errorf("expected u2 to stay ranked with 200, got %v (err: %v)", score, err)
+	}
+
+	if err := lb.RemoveUser("u1"); err != nil {
+		t.Fatalf("RemoveUser failed: %v", err)
+	}
+	if n := lb.client.ZCard(lb.ctx, lb.globalKey()).Val(); n != 2 {
+		t.Errorf("expected 2 global members after removal, got %d", n)
+	}
+}
+
+func TestEntityInMemberMerge(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityInMember: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "old", Score: 10})
+	if err := lb.RenameEntity("old", "new", false); err != nil {
+		t.Fatalf("RenameEntity failed: %v", err)
+	}
+	topK, _ := lb.GetTopKGlobal()
+	if len(topK) != 1 || topK[0].Entity != "new" {
+		t.Errorf("expected u1 in new, got %+v", topK)
+	}
+}
+
+func TestEntityInMemberEviction(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUsers: 2, EvictionPolicy: EvictionLowest, EntityInMember: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 20})
+	// moving entity through AddUser is an update, not a new user
+	lb.AddUser(User{ID: "u1", Entity: "UK", Score: 30})
+	if n := lb.client.ZCard(lb.ctx, lb.globalKey()).Val(); n != 2 {
+		t.Fatalf("expected 2 global members, got %d", n)
+	}
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 40})
+	if _, err := lb.GetUserScore("u2"); err == nil {
+		t.Errorf("expected u2 to be evicted")
+	}
+	if entity, _ := lb.GetUserEntity("u2"); entity != "" {
+		t.Errorf("expected evicted u2 mapping to be removed, got %s", entity)
+	}
+}
+
+func TestMigrateEntityInMember(t *testing.T) {
+	plain := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer plain.Close()
+	encoded := newTestLeaderboard(t, Config{Namespace: "test", EntityInMember: true})
+	defer encoded.Close()
+
+	plain.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	plain.AddUser(User{ID: "u2", Score: 200})
+
+	if err := encoded.MigrateEntityInMember(); err != nil {
+		t.Fatalf("migrate to member encoding: %v", err)
+	}
+	if rank, _ := encoded.GetRankGlobal("u1"); rank != 1 {
+		t.Errorf("expected u1 at rank 1 after migration, got %d", rank)
+	}
+	topK, _ := encoded.GetTopKGlobal()
+	if len(topK) != 2 || topK[1].ID != "u1" || topK[1].Entity != "US" {
+		t.Errorf("unexpected top-k after migration: %+v", topK)
+	}
+
+	if err := plain.MigrateEntityInMember(); err != nil {
+		t.Fatalf("migrate back to plain members: %v", err)
+	}
+	if score, err := plain.GetUserScore("u1"); err != nil || score != 100 {
+		t.Errorf("expected u1 score 100 after migrating back, got %v (err: %v)", score, err)
+	}
+}
+
+func BenchmarkGetTopKGlobalEntityInMember(b *testing.B) {
+	for _, inMember := range []bool{false, true} {
+		b.Run(fmt.Sprintf("entityInMember=%v", inMember), func(b *testing.B) {
+			lb, err := New(Config{Namespace: "bench", RedisAddr: testRedisAddr, K: 100, EntityInMember: inMember})
+			if err != nil {
+				b.Skipf("redis unavailable: %v", err)
+			}
+			defer lb.Close()
+			lb.ForceClearLeaderBoardWithNamespacePrefix()
+			for i := 0; i < 1000; i++ {
+				lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)})
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := lb.GetTopKGlobal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestEntityInMemberScriptFlush(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityInMember: true})
+	defer lb.Close()
+
+	if err := lb.AddUser(User{ID: "u1", Entity: "a", Score: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawClient(lb).ScriptFlush(lb.ctx).Err(); err != nil {
+		t.Fatalf("SCRIPT FLUSH: %v", err)
+	}
+	if err := lb.AddUser(User{ID: "u1", Entity: "b", Score: 3}); err != nil {
+		t.Fatalf("AddUser after SCRIPT FLUSH: %v", err)
+	}
+	if err := lb.IncrementScore("u1", "c", 1); err != nil {
+		t.Fatalf("IncrementScore after SCRIPT FLUSH: %v", err)
+	}
+	members, err := lb.client.ZRangeWithScores(lb.ctx, lb.globalKey(), 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Member != "u1|c" || members[0].Score != 4 {
+		t.Errorf("expected u1 once as u1|c with 4, got %v", members)
+	}
+}
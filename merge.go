@@ -0,0 +1,128 @@
+package redisboard
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetTopKGlobalMerged computes the global top k from the entity rankings
+// instead of the global ranking: it fetches the top k of each entity, from
+// its shard with Config.Sharder, and merges them with a heap. Fetching k
+// per entity is what makes the result exact: any user in the global top k
+// is also in their own entity's top k.
+// Useful to cross-check or replace the global ranking when entities are
+// sharded. Users without entity, or in entities not listed, are left out.
+// Ordered by score descending, ties by descending user ID like Redis; a user
+// found in several entities (see Verify) is listed once, at their highest
+// entity score, and logged through Config.Logger if set. Includes entities
+// (and metadata if enabled).
+// Returns an empty slice when the entities have no members.
+// Returns error if:
+// - entities is empty, or any entity is empty or invalid (ErrInvalidEntity)
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKGlobalMerged(entities []string) (_ []User, err error) {
+	defer lb.startOp("GetTopKGlobalMerged", "", "").end(&err)
+	entities = lb.normalizeEntities(entities)
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("invalid entity")
+	}
+	for _, entity := range entities {
+		if entity == "" {
+			return nil, fmt.Errorf("invalid entity")
+		}
+		if err := lb.validateEntity(entity); err != nil {
+			return nil, err
+		}
+	}
+
+	// one pipeline per backend
+	k := int64(lb.config.K)
+	pipes := make(map[RedisClient]redis.Pipeliner)
+	cmds := make([]*redis.ZSliceCmd, len(entities))
+	for i, entity := range entities {
+		c := lb.entityReader(entity)
+		pipe, ok := pipes[c]
+		if !ok {
+			pipe = c.Pipeline()
+			pipes[c] = pipe
+		}
+		cmds[i] = pipe.ZRevRangeWithScores(lb.ctx, lb.entityKey(entity), 0, k-1)
+	}
+	for _, pipe := range pipes {
+		if _, err := pipe.Exec(lb.ctx); err != nil {
+			return nil, fmt.Errorf("failed to fetch entity top-k: %w", err)
+		}
+	}
+
+	h := make(mergeHeap, 0, len(entities))
+	for i, cmd := range cmds {
+		if members := cmd.Val(); len(members) > 0 {
+			h = append(h, mergeCursor{members: members, entity: entities[i]})
+		}
+	}
+	heap.Init(&h)
+
+	users := []User{}
+	seen := make(map[string]bool)
+	var dups []string
+	for len(h) > 0 && len(users) < lb.config.K {
+		cur := &h[0]
+		m := cur.members[0]
+		id := m.Member.(string)
+		if seen[id] {
+			dups = append(dups, id)
+		} else {
+			seen[id] = true
+			users = append(users, User{ID: id, Entity: cur.entity, Score: m.Score})
+		}
+		if cur.members = cur.members[1:]; len(cur.members) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+
+	if len(dups) > 0 && lb.config.Logger != nil {
+		lb.config.Logger.Warn("user ranked in several entities",
+			"namespace", lb.config.Namespace,
+			"users", dups,
+		)
+	}
+
+	if err := lb.fillUsers(users, false); err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	return users, nil
+}
+
+// mergeCursor is the unmerged rest of one entity's top k.
+type mergeCursor struct {
+	members []redis.Z
+	entity  string
+}
+
+// mergeHeap orders cursors by their next member, highest score first.
+type mergeHeap []mergeCursor
+
+func (h mergeHeap) Len() int { return len(h) }
+
+func (h mergeHeap) Less(i, j int) bool {
+	a, b := h[i].members[0], h[j].members[0]
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Member.(string) > b.Member.(string)
+}
+
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap) Push(x any) { *h = append(*h, x.(mergeCursor)) }
+
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
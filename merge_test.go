@@ -0,0 +1,74 @@
+package redisboard
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGetTopKGlobalMerged(t *testing.T) {
+	shard := redis.NewClient(&redis.Options{Addr: testRedisAddr, DB: 1})
+	defer shard.Close()
+	ctx := context.Background()
+	shard.FlushDB(ctx)
+	defer shard.FlushDB(ctx)
+
+	entities := []string{"EU", "US", "ASIA"}
+	for _, sharded := range []bool{false, true} {
+		cfg := Config{Namespace: "test", K: 7}
+		if sharded {
+			cfg.Sharder = regionSharder{eu: shard}
+		}
+		lb := newTestLeaderboard(t, cfg)
+
+		// scores in a narrow range force ties across entities
+		rng := rand.New(rand.NewPCG(1, 2))
+		for i := 0; i < 60; i++ {
+			entity := entities[rng.IntN(len(entities))]
+			lb.AddUser(User{ID: fmt.Sprintf("u%02d", i), Entity: entity, Score: float64(rng.IntN(20))})
+		}
+
+		// reference: the single global ranking
+		want, err := lb.GetTopKGlobal()
+		if err != nil {
+			t.Fatalf("GetTopKGlobal: %v", err)
+		}
+		got, err := lb.GetTopKGlobalMerged(entities)
+		if err != nil {
+			t.Fatalf("GetTopKGlobalMerged (sharded=%v): %v", sharded, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("sharded=%v: expected %d users, got %d", sharded, len(want), len(got))
+		}
+		for i := range want {
+			if got[i].ID != want[i].ID || got[i].Score != want[i].Score || got[i].Entity != want[i].Entity {
+				t.Errorf("sharded=%v: position %d: expected %+v, got %+v", sharded, i, want[i], got[i])
+			}
+		}
+		lb.Close()
+	}
+}
+
+func TestGetTopKGlobalMergedEdgeCases(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 3})
+	defer lb.Close()
+
+	if users, err := lb.GetTopKGlobalMerged([]string{"EU"}); err != nil || users == nil || len(users) != 0 {
+		t.Errorf("expected empty slice for empty entities, got %+v, %v", users, err)
+	}
+	if _, err := lb.GetTopKGlobalMerged(nil); err == nil {
+		t.Error("expected error without entities")
+	}
+
+	// u1 ends up in both US (10) and EU (5): listed once, at the higher score
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.IncrementScore("u1", "EU", 5)
+	lb.AddUser(User{ID: "u2", Entity: "EU", Score: 1})
+	users, err := lb.GetTopKGlobalMerged([]string{"US", "EU"})
+	if err != nil || len(users) != 2 || users[0].ID != "u1" || users[0].Score != 10 || users[1].ID != "u2" {
+		t.Errorf("unexpected merge: %+v, %v", users, err)
+	}
+}
@@ -0,0 +1,148 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrInvalidMetric is returned when a metric name is too long or contains
+// characters outside Config.EntityCharset. Metric names become part of
+// Redis key names, just like entities.
+var ErrInvalidMetric = errors.New("invalid metric")
+
+// validateMetric checks metric name length and characters against config.
+func (lb *Leaderboard) validateMetric(metric string) error {
+	if err := lb.validateEntity(metric); err != nil {
+		return fmt.Errorf("%w %q", ErrInvalidMetric, metric)
+	}
+	return nil
+}
+
+// AddUserMetric creates or updates user score on a named metric board
+// (e.g., "kills", "xp"). Updates both the metric's global and entity
+// rankings and the shared entity mapping.
+// Empty metric is the default board and behaves like AddUser.
+// Returns error if:
+// - user ID is empty
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - score is negative and AllowNegativeScores is unset
+// - score exceeds 2^53 with FloatScores=false (ErrScoreOverflow)
+// - score is NaN or infinite (ErrInvalidScore)
+// - metric or entity is invalid (ErrInvalidMetric, ErrInvalidEntity)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - Redis operation fails
+func (lb *Leaderboard) AddUserMetric(userID, entity, metric string, score float64) (err error) {
+	defer lb.startOp("AddUserMetric", userID, entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if metric == "" {
+		return lb.AddUser(User{ID: userID, Entity: entity, Score: score})
+	}
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if err := finiteScore(score); err != nil {
+		return err
+	}
+	if userID == "" || !lb.validScore(score) {
+		return fmt.Errorf("invalid user ID or score")
+	}
+	if err := lb.validateUserID(userID); err != nil {
+		return err
+	}
+	if err := lb.validateMetric(metric); err != nil {
+		return err
+	}
+	if err := lb.validateEntity(entity); err != nil {
+		return err
+	}
+
+	score, err = lb.normalizeScore(score)
+	if err != nil {
+		return err
+	}
+	if err := lb.admitEntity(entity); err != nil {
+		return err
+	}
+
+	pipe := lb.client.Pipeline()
+	pipe.SAdd(lb.ctx, lb.metricsKey(), metric)
+	pipe.ZAdd(lb.ctx, lb.metricGlobalKey(metric), redis.Z{Score: score, Member: userID})
+	lb.setEntity(pipe, userID, entity)
+	if entity != "" {
+		pipe.ZAdd(lb.ctx, lb.metricEntityKey(metric, entity), redis.Z{Score: score, Member: userID})
+	}
+	_, err = pipe.Exec(lb.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add user metric %s: %w", metric, err)
+	}
+	return nil
+}
+
+// GetTopKMetric returns top k users on a metric board.
+// Ordered by metric score descending.
+// Empty metric is the default board and behaves like GetTopKGlobal.
+// Returns error if:
+// - metric is invalid (ErrInvalidMetric)
+// - no users have the metric
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKMetric(metric string) (_ []User, err error) {
+	defer lb.startOp("GetTopKMetric", "", "").end(&err)
+	if metric == "" {
+		return lb.GetTopKGlobal()
+	}
+	if err := lb.validateMetric(metric); err != nil {
+		return nil, err
+	}
+
+	members, err := lb.reader.ZRevRangeWithScores(lb.ctx, lb.metricGlobalKey(metric), 0, int64(lb.config.K-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric %s top-k: %w", metric, err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no users in metric %s", metric)
+	}
+
+	users, err := lb.enrichUsers(members, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	return users, nil
+}
+
+// GetTopKMetricEntity returns top k users of an entity on a metric board.
+// Ordered by metric score descending.
+// Empty metric is the default board and behaves like GetTopKEntity.
+// Returns error if:
+// - metric is invalid (ErrInvalidMetric)
+// - no users in entity have the metric
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKMetricEntity(metric, entity string) (_ []User, err error) {
+	defer lb.startOp("GetTopKMetricEntity", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if err := lb.unsharded(); err != nil {
+		return nil, err
+	}
+	if metric == "" {
+		return lb.GetTopKEntity(entity)
+	}
+	if err := lb.validateMetric(metric); err != nil {
+		return nil, err
+	}
+
+	entityKey := lb.metricEntityKey(metric, entity)
+	members, err := lb.reader.ZRevRangeWithScores(lb.ctx, entityKey, 0, int64(lb.config.K-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric %s entity %s top-k: %w", metric, entity, err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no users in metric %s entity %s", metric, entity)
+	}
+
+	users, err := lb.enrichUsers(members, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric %s entity %s metadata: %w", metric, entity, err)
+	}
+	return users, nil
+}
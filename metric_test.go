@@ -0,0 +1,74 @@
+package redisboard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddUserMetric(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	if err := lb.AddUserMetric("u1", "US", "kills", 5); err != nil {
+		t.Fatalf("AddUserMetric: %v", err)
+	}
+	if err := lb.AddUserMetric("u2", "US", "kills", 7); err != nil {
+		t.Fatalf("AddUserMetric: %v", err)
+	}
+
+	topK, err := lb.GetTopKMetric("kills")
+	if err != nil {
+		t.Fatalf("GetTopKMetric: %v", err)
+	}
+	if len(topK) != 2 || topK[0].ID != "u2" || topK[0].Score != 7 || topK[1].Entity != "US" {
+		t.Errorf("unexpected metric topK: %+v", topK)
+	}
+
+	// default board is untouched by the metric
+	score, err := lb.GetUserScore("u1")
+	if err != nil || score != 10 {
+		t.Errorf("expected default score 10, got %f, err: %v", score, err)
+	}
+	if _, err := lb.GetUserScore("u2"); err == nil {
+		t.Error("expected u2 to be absent from default board")
+	}
+}
+
+func TestGetTopKMetricEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUserMetric("u1", "US", "xp", 100)
+	lb.AddUserMetric("u2", "UK", "xp", 200)
+
+	topK, err := lb.GetTopKMetricEntity("xp", "US")
+	if err != nil {
+		t.Fatalf("GetTopKMetricEntity: %v", err)
+	}
+	if len(topK) != 1 || topK[0].ID != "u1" || topK[0].Score != 100 {
+		t.Errorf("unexpected metric entity topK: %+v", topK)
+	}
+}
+
+func TestMetricRemoveUser(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUserMetric("u1", "US", "kills", 5)
+	if err := lb.RemoveUser("u1"); err != nil {
+		t.Fatalf("RemoveUser: %v", err)
+	}
+	if _, err := lb.GetTopKMetric("kills"); err == nil {
+		t.Error("expected metric board to be empty after RemoveUser")
+	}
+}
+
+func TestInvalidMetric(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if err := lb.AddUserMetric("u1", "US", "a:b", 5); !errors.Is(err, ErrInvalidMetric) {
+		t.Errorf("expected ErrInvalidMetric, got %v", err)
+	}
+}
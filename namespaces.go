@@ -0,0 +1,65 @@
+package redisboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ListNamespaces returns the namespaces holding a leaderboard on client,
+// sorted, e.g. for admin tooling hosting one board per game. A namespace is
+// found by its {namespace}:global key, so boards without users yet are not
+// listed. pattern filters namespaces with SCAN MATCH syntax (e.g., "game*");
+// empty matches all. On a cluster client every master is scanned.
+// Uses SCAN, never KEYS, so it doesn't block Redis on large keyspaces.
+// Only boards with the default ":" KeySeparator are recognized.
+// Returns error if Redis operation fails.
+func ListNamespaces(client RedisClient, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	match := pattern + ":global"
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	found := make(map[string]bool)
+	scan := func(ctx context.Context, c RedisCommands) error {
+		iter := c.Scan(ctx, 0, match, batchSize).Iterator()
+		for iter.Next(ctx) {
+			ns := strings.TrimSuffix(iter.Val(), ":global")
+			if strings.Contains(ns, ":metric:") {
+				continue // {namespace}:metric:{metric}:global
+			}
+			mu.Lock()
+			found[ns] = true
+			mu.Unlock()
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("failed to scan namespaces: %w", err)
+		}
+		return nil
+	}
+
+	var err error
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	} else {
+		err = scan(ctx, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0, len(found))
+	for ns := range found {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
@@ -0,0 +1,43 @@
+package redisboard
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestListNamespaces(t *testing.T) {
+	var boards []*Leaderboard
+	for _, ns := range []string{"lsgame1", "lsgame2", "lsother"} {
+		lb := newTestLeaderboard(t, Config{Namespace: ns})
+		defer lb.Close()
+		defer lb.ForceClearLeaderBoardWithNamespacePrefix()
+		lb.AddUser(User{ID: "u1", Score: 10})
+		boards = append(boards, lb)
+	}
+	// metric boards don't count as namespaces
+	boards[0].AddUserMetric("u1", "", "kills", 3)
+
+	client := redis.NewClient(&redis.Options{Addr: testRedisAddr})
+	defer client.Close()
+	got, err := ListNamespaces(client, "lsgame*")
+	if err != nil {
+		t.Fatalf("ListNamespaces: %v", err)
+	}
+	if want := []string{"lsgame1", "lsgame2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	all, err := ListNamespaces(client, "")
+	if err != nil {
+		t.Fatalf("ListNamespaces: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, ns := range all {
+		seen[ns] = true
+	}
+	if !seen["lsgame1"] || !seen["lsother"] || seen["lsgame1:metric:kills"] {
+		t.Errorf("unexpected namespaces: %v", all)
+	}
+}
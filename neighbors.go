@@ -0,0 +1,73 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNoNeighbor is returned by GetUserAbove for the top-ranked user and by
+// GetUserBelow for the bottom-ranked one.
+var ErrNoNeighbor = errors.New("no neighbor at that rank")
+
+// GetUserAbove returns the user ranked right above userID globally, with
+// entity (and metadata if enabled), e.g. for "50 more points to pass
+// PlayerX" messages. Tied users keep Redis' order even with
+// TieBreakField, so neighbors match GetRankGlobal.
+// Returns error if:
+// - user not found (ErrUserNotFound)
+// - the user is ranked first (ErrNoNeighbor)
+// - Redis operation fails
+func (lb *Leaderboard) GetUserAbove(userID string) (_ User, err error) {
+	defer lb.startOp("GetUserAbove", userID, "").end(&err)
+	return lb.neighbor(userID, -1)
+}
+
+// GetUserBelow returns the user ranked right below userID globally, like
+// GetUserAbove.
+// Returns error if:
+// - user not found (ErrUserNotFound)
+// - the user is ranked last (ErrNoNeighbor)
+// - Redis operation fails
+func (lb *Leaderboard) GetUserBelow(userID string) (_ User, err error) {
+	defer lb.startOp("GetUserBelow", userID, "").end(&err)
+	return lb.neighbor(userID, 1)
+}
+
+// neighbor returns the user offset ranks away from userID.
+func (lb *Leaderboard) neighbor(userID string, offset int64) (User, error) {
+	if err := lb.unpartitioned(); err != nil {
+		return User{}, err
+	}
+	globalKey := lb.globalKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return User{}, err
+	}
+
+	rank, err := lb.reader.ZRevRank(lb.ctx, globalKey, member).Result()
+	if err == redis.Nil {
+		return User{}, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get global rank: %w", err)
+	}
+	target := rank + offset
+	if target < 0 {
+		return User{}, fmt.Errorf("%w: %s is ranked first", ErrNoNeighbor, userID)
+	}
+
+	members, err := lb.reader.ZRevRangeWithScores(lb.ctx, globalKey, target, target).Result()
+	if err != nil {
+		return User{}, fmt.Errorf("failed to fetch neighbor: %w", err)
+	}
+	if len(members) == 0 {
+		return User{}, fmt.Errorf("%w: %s is ranked last", ErrNoNeighbor, userID)
+	}
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	return users[0], nil
+}
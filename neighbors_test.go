@@ -0,0 +1,34 @@
+package redisboard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetUserAboveBelow(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 300})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u3", Score: 100})
+
+	above, err := lb.GetUserAbove("u2")
+	if err != nil || above.ID != "u1" || above.Score != 300 || above.Entity != "US" {
+		t.Errorf("expected u1 above u2, got %+v, %v", above, err)
+	}
+	below, err := lb.GetUserBelow("u2")
+	if err != nil || below.ID != "u3" || below.Score != 100 {
+		t.Errorf("expected u3 below u2, got %+v, %v", below, err)
+	}
+
+	if _, err := lb.GetUserAbove("u1"); !errors.Is(err, ErrNoNeighbor) {
+		t.Errorf("expected ErrNoNeighbor above the leader, got %v", err)
+	}
+	if _, err := lb.GetUserBelow("u3"); !errors.Is(err, ErrNoNeighbor) {
+		t.Errorf("expected ErrNoNeighbor below the last user, got %v", err)
+	}
+	if _, err := lb.GetUserAbove("ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
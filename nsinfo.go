@@ -0,0 +1,124 @@
+package redisboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NamespaceInfo describes a leaderboard's namespace as found by NewWithInfo.
+type NamespaceInfo struct {
+	Users    int64 // users on the global ranking
+	Entities int64 // entity rankings (VerifyNamespace only)
+	Keys     int64 // keys of the namespace or season, named seasons excluded (VerifyNamespace only)
+}
+
+// NewWithInfo is New also reporting the namespace's user count, e.g. as a
+// startup log line or readiness signal. With VerifyNamespace the entity
+// rankings and keys found by its scan are counted too.
+// Returns error like New.
+func NewWithInfo(cfg Config) (*Leaderboard, NamespaceInfo, error) {
+	verify := cfg.VerifyNamespace
+	cfg.VerifyNamespace = false // scanned below, counting as it goes
+	lb, err := New(cfg)
+	if err != nil {
+		return nil, NamespaceInfo{}, err
+	}
+	lb.config.VerifyNamespace = verify
+
+	info, err := lb.namespaceInfo(verify)
+	if err != nil {
+		lb.Close()
+		return nil, NamespaceInfo{}, err
+	}
+	return lb, info, nil
+}
+
+// namespaceInfo counts the users and, if scan is set, verifies every key
+// of the namespace with verifyNamespace.
+func (lb *Leaderboard) namespaceInfo(scan bool) (NamespaceInfo, error) {
+	var info NamespaceInfo
+	if scan {
+		var err error
+		if info, err = lb.verifyNamespace(); err != nil {
+			return info, err
+		}
+	}
+
+	pipe := lb.client.Pipeline()
+	globalKeys := lb.globalKeys()
+	cmds := make([]*redis.IntCmd, len(globalKeys))
+	for i, key := range globalKeys {
+		cmds[i] = pipe.ZCard(lb.ctx, key)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return info, fmt.Errorf("failed to count users: %w", err)
+	}
+	for _, cmd := range cmds {
+		info.Users += cmd.Val()
+	}
+	return info, nil
+}
+
+// verifyNamespace checks the Redis type of every key of the namespace (or
+// season) with SCAN, including the entity and metric rankings
+// checkNamespace leaves to the first write. Keys of unknown shape are
+// counted but not checked. The default season skips the named seasons,
+// which are checked when opened.
+func (lb *Leaderboard) verifyNamespace() (NamespaceInfo, error) {
+	var info NamespaceInfo
+	fixed := lb.fixedKeyTypes()
+	entityPrefix := lb.entityKey("")
+	metricPrefix := lb.key("metric", "")
+	archivePrefix := lb.archiveKey("")
+	seasonsPrefix := lb.namespacePrefix() + lb.config.KeySeparator + seasonPrefix
+
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.Scan(lb.ctx, cursor, lb.keyPattern(), int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return info, fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		pipe := lb.client.Pipeline()
+		checked := make(map[string]string)
+		typeCmds := make(map[string]*redis.StatusCmd)
+		for _, key := range keys {
+			if lb.config.Season == "" && strings.HasPrefix(key, seasonsPrefix) {
+				continue
+			}
+			info.Keys++
+			want, ok := fixed[key]
+			switch {
+			case ok:
+			case strings.HasPrefix(key, entityPrefix):
+				info.Entities++
+				want = "zset"
+			case strings.HasPrefix(key, metricPrefix):
+				want = "zset"
+			case strings.HasPrefix(key, archivePrefix):
+				want = "string"
+			default:
+				continue
+			}
+			checked[key] = want
+			typeCmds[key] = pipe.Type(lb.ctx, key)
+		}
+		if len(typeCmds) > 0 {
+			if _, err := pipe.Exec(lb.ctx); err != nil {
+				return info, fmt.Errorf("failed to check namespace keys: %w", err)
+			}
+		}
+		for key, want := range checked {
+			if got := typeCmds[key].Val(); got != "none" && got != want {
+				return info, fmt.Errorf("%w: key %s holds a %s, expected %s", ErrNamespaceConflict, key, got, want)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return info, nil
+		}
+	}
+}
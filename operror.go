@@ -0,0 +1,84 @@
+package redisboard
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// OpError describes a failed leaderboard operation: the method, the user
+// and entity it concerned, and the underlying error. Leaderboard methods
+// (other than Close and IterateUsers) wrap their errors in an OpError, so
+// logs show which call failed on what; errors.Is and errors.As still see
+// the sentinel or Redis error below.
+type OpError struct {
+	Op     string // method that failed (e.g., "AddUser")
+	UserID string // user concerned, if any
+	Entity string // entity concerned, if any
+	Err    error  // underlying error
+}
+
+// Error returns e.g. "AddUser user=u1 entity=US: failed to add user: ...".
+func (e *OpError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.UserID != "" {
+		b.WriteString(" user=")
+		b.WriteString(e.UserID)
+	}
+	if e.Entity != "" {
+		b.WriteString(" entity=")
+		b.WriteString(e.Entity)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// opSpan is an exported method call in progress.
+type opSpan struct {
+	lb     *Leaderboard
+	op     string
+	userID string
+	entity string
+	start  time.Time // zero without Config.SlowThreshold
+}
+
+// startOp starts op for the user and entity it concerns. Exported methods
+// with a named error result defer lb.startOp(op, userID, entity).end(&err).
+func (lb *Leaderboard) startOp(op, userID, entity string) opSpan {
+	s := opSpan{lb: lb, op: op, userID: userID, entity: entity}
+	if lb.config.SlowThreshold > 0 {
+		s.start = time.Now()
+	}
+	return s
+}
+
+// end logs the call if it took Config.SlowThreshold or longer, and wraps
+// *err in an OpError.
+func (s opSpan) end(err *error) {
+	if !s.start.IsZero() {
+		if d := time.Since(s.start); d >= s.lb.config.SlowThreshold {
+			s.lb.logSlowOp(s, d, *err)
+		}
+	}
+	wrapOp(err, s.op, s.userID, s.entity)
+}
+
+// wrapOp wraps *err in an OpError for op. Errors that already carry an
+// OpError, from a method called internally, keep the innermost context.
+func wrapOp(err *error, op, userID, entity string) {
+	if *err == nil {
+		return
+	}
+	var opErr *OpError
+	if errors.As(*err, &opErr) {
+		return
+	}
+	*err = &OpError{Op: op, UserID: userID, Entity: entity, Err: *err}
+}
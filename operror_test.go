@@ -0,0 +1,42 @@
+package redisboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOpError(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	err := lb.AddUser(User{ID: "u1", Entity: "bad entity", Score: 10})
+	var opErr *OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected *OpError, got %T: %v", err, err)
+	}
+	if opErr.Op != "AddUser" || opErr.UserID != "u1" || opErr.Entity != "bad entity" {
+		t.Errorf("unexpected context: %+v", opErr)
+	}
+	if !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("expected errors.Is(err, ErrInvalidEntity), got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "AddUser user=u1 entity=bad entity: ") {
+		t.Errorf("unexpected message: %q", err.Error())
+	}
+
+	_, err = lb.GetUserScore("nobody")
+	if !errors.Is(err, ErrUserNotFound) || err.Error() != "GetUserScore user=nobody: user not found: nobody" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// an internal call keeps the innermost context instead of nesting
+	_, err = lb.GetUserScoreRounded("nobody", 2)
+	if !errors.As(err, &opErr) || opErr.Op != "GetUserScore" || strings.Count(err.Error(), "user=") != 1 {
+		t.Errorf("expected single GetUserScore context, got %v", err)
+	}
+
+	if err := lb.AddUser(User{ID: "u1", Score: 10}); err != nil {
+		t.Errorf("expected nil error to stay nil, got %v", err)
+	}
+}
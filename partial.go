@@ -0,0 +1,79 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrPartialWrite marks a write whose pipeline was only partly applied,
+// e.g. the global ZADD succeeded but the entity ZADD failed. The rankings
+// stay inconsistent until the write is retried or Repair runs.
+var ErrPartialWrite = errors.New("partial write")
+
+// PartialWriteError reports the commands of a partly applied write pipeline
+// that failed. Commands not listed were applied.
+type PartialWriteError struct {
+	Failed []string // failed commands, as "{command} {key}"
+	Err    error    // first failure
+}
+
+// Error lists the failed commands and the first failure.
+func (e *PartialWriteError) Error() string {
+	return fmt.Sprintf("%v: %d commands failed (%s): %v", ErrPartialWrite, len(e.Failed), strings.Join(e.Failed, ", "), e.Err)
+}
+
+// Unwrap matches both ErrPartialWrite and the first failure.
+func (e *PartialWriteError) Unwrap() []error {
+	return []error{ErrPartialWrite, e.Err}
+}
+
+// pipelineErr inspects the commands of a failed pipeline. Returns err as is
+// if none of them was applied, and a *PartialWriteError listing the failed
+// ones otherwise. unsent counts the entity shard commands that were never
+// sent because the main pipeline failed.
+func pipelineErr(cmds []redis.Cmder, unsent int, err error) error {
+	var failed []string
+	var first error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && cmdErr != redis.Nil {
+			failed = append(failed, cmdString(cmd))
+			if first == nil {
+				first = cmdErr
+			}
+		}
+	}
+	if len(failed) == len(cmds) {
+		return err // nothing applied
+	}
+	if unsent > 0 {
+		failed = append(failed, fmt.Sprintf("%d entity shard commands not sent", unsent))
+	}
+	if first == nil {
+		first = err
+	}
+	return &PartialWriteError{Failed: failed, Err: conflictErr(first)}
+}
+
+// cmdString names a command by its name and first key.
+func cmdString(cmd redis.Cmder) string {
+	if key := cmdKey(cmd); key != "" {
+		return cmd.Name() + " " + key
+	}
+	return cmd.Name()
+}
+
+// cmdKey returns the first key of a command, or "" if it has none.
+func cmdKey(cmd redis.Cmder) string {
+	args := cmd.Args()
+	key := 1
+	if name := cmd.Name(); name == "eval" || name == "evalsha" {
+		key = 3 // script, numkeys, keys...
+	}
+	if key >= len(args) {
+		return ""
+	}
+	return fmt.Sprint(args[key])
+}
@@ -0,0 +1,81 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// failKeyHook fails the pipelined commands on key (all commands if empty)
+// without sending them, with err or an injected failure, until disabled.
+type failKeyHook struct {
+	key      string
+	err      error
+	disabled bool
+}
+
+func (*failKeyHook) DialHook(next redis.DialHook) redis.DialHook          { return next }
+func (*failKeyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (h *failKeyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if h.disabled {
+			return next(ctx, cmds)
+		}
+		injected := h.err
+		if injected == nil {
+			injected = errors.New("injected failure")
+		}
+		var sent []redis.Cmder
+		for _, cmd := range cmds {
+			if args := cmd.Args(); h.key == "" || len(args) > 1 && args[1] == h.key {
+				cmd.SetErr(injected)
+				continue
+			}
+			sent = append(sent, cmd)
+		}
+		if len(sent) > 0 {
+			if err := next(ctx, sent); err != nil {
+				return err
+			}
+		}
+		if len(sent) < len(cmds) {
+			return injected
+		}
+		return nil
+	}
+}
+
+func TestPartialWrite(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	hook := &failKeyHook{key: lb.entityKey("US")}
+	rawClient(lb).AddHook(hook)
+	err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	var partial *PartialWriteError
+	if !errors.As(err, &partial) || !errors.Is(err, ErrPartialWrite) {
+		t.Fatalf("expected PartialWriteError, got %v", err)
+	}
+	if len(partial.Failed) != 1 || partial.Failed[0] != "zadd "+lb.entityKey("US") {
+		t.Errorf("unexpected failed commands: %v", partial.Failed)
+	}
+
+	// the global write went through, leaving the entity ranking behind
+	hook.disabled = true
+	if report, _ := lb.Verify(); report.MissingEntityMembers != 1 {
+		t.Errorf("expected one missing entity member, got %+v", report)
+	}
+
+	// a write failing entirely is not partial
+	plain := newTestLeaderboard(t, Config{Namespace: "test2"})
+	defer plain.Close()
+	defer plain.ForceClearLeaderBoardWithNamespacePrefix()
+	rawClient(plain).AddHook(&failKeyHook{})
+	err = plain.AddUser(User{ID: "u1", Score: 100})
+	if err == nil || errors.Is(err, ErrPartialWrite) {
+		t.Errorf("expected plain failure, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package redisboard
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Global ranking partitions.
+// With Config.GlobalShards = N > 1, the global ranking is split into N
+// ZSETs {namespace}:global:0..N-1 and each user lives in the partition
+// picked by hashing their ID, so write-heavy boards no longer funnel every
+// update into one hot key: on Redis Cluster the partitions land on
+// different slots and nodes, and on a single instance each ZSET stays N
+// times smaller.
+// Reads merge the partitions. Users with equal scores are ordered by
+// partition, then as within a single ZSET, so top-k and ranks agree.
+// Operations walking or counting the whole global ranking through one ZSET
+// command fail with ErrShardingUnsupported. N must not change once users
+// are stored: they would be looked up in the wrong partition.
+
+// validatePartitions rejects options whose scripts, caches or encodings
+// assume a single global ranking key.
+func validatePartitions(cfg Config) error {
+	if cfg.GlobalShards <= 1 {
+		return nil
+	}
+	switch {
+	case cfg.EvictionPolicy != EvictionNone || cfg.MaxUsersPerEntity > 0:
+		return fmt.Errorf("%w: GlobalShards with eviction policies and user caps", ErrShardingUnsupported)
+	case cfg.EntityInMember:
+		return fmt.Errorf("%w: GlobalShards with EntityInMember", ErrShardingUnsupported)
+	case cfg.CoalesceInterval > 0:
+		return fmt.Errorf("%w: GlobalShards with CoalesceInterval", ErrShardingUnsupported)
+	case cfg.RequireExistingUser:
+		return fmt.Errorf("%w: GlobalShards with RequireExistingUser", ErrShardingUnsupported)
+	case cfg.RankSnapshotInterval > 0:
+		return fmt.Errorf("%w: GlobalShards with RankSnapshotInterval", ErrShardingUnsupported)
+	case cfg.PublishRankChanges:
+		return fmt.Errorf("%w: GlobalShards with PublishRankChanges", ErrShardingUnsupported)
+	case cfg.TrackBestRank:
+		return fmt.Errorf("%w: GlobalShards with TrackBestRank", ErrShardingUnsupported)
+	}
+	return nil
+}
+
+// partitioned reports whether the global ranking is split.
+func (lb *Leaderboard) partitioned() bool {
+	return lb.config.GlobalShards > 1
+}
+
+// unpartitioned fails operations not supported with Config.GlobalShards.
+func (lb *Leaderboard) unpartitioned() error {
+	if lb.partitioned() {
+		return ErrShardingUnsupported
+	}
+	return nil
+}
+
+// partitionOf returns the global partition holding userID (FNV-1a).
+func (lb *Leaderboard) partitionOf(userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return int(h.Sum32() % uint32(lb.config.GlobalShards))
+}
+
+// userGlobalKey returns the global ranking key holding userID.
+func (lb *Leaderboard) userGlobalKey(userID string) string {
+	if !lb.partitioned() {
+		return lb.globalKey()
+	}
+	return lb.globalPartitionKey(lb.partitionOf(userID))
+}
+
+// globalKeys returns every key of the global ranking.
+func (lb *Leaderboard) globalKeys() []string {
+	if !lb.partitioned() {
+		return []string{lb.globalKey()}
+	}
+	keys := make([]string, lb.config.GlobalShards)
+	for i := range keys {
+		keys[i] = lb.globalPartitionKey(i)
+	}
+	return keys
+}
+
+// globalTopK returns the top k members of the global ranking read through
+// c, merging the partitions' own top k.
+func (lb *Leaderboard) globalTopK(c RedisCommands) ([]redis.Z, error) {
+	k := int64(lb.config.K)
+	if !lb.partitioned() {
+		return c.ZRevRangeWithScores(lb.ctx, lb.globalKey(), 0, k-1).Result()
+	}
+
+	pipe := c.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, lb.config.GlobalShards)
+	for i, key := range lb.globalKeys() {
+		cmds[i] = pipe.ZRevRangeWithScores(lb.ctx, key, 0, k-1)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return nil, err
+	}
+
+	heads := make([][]redis.Z, len(cmds))
+	for i, cmd := range cmds {
+		heads[i] = cmd.Val()
+	}
+	members, dups := mergePartitions(heads, lb.config.K)
+	if len(dups) > 0 {
+		lb.config.Logger.Warn("user ranked in several global partitions",
+			"namespace", lb.config.Namespace,
+			"users", dups,
+		)
+	}
+	return members, nil
+}
+
+// mergePartitions merges the partitions' top members, each sorted by score
+// descending, into the top k. A user is in one partition only, but one
+// found in several (left by a crash or a change of GlobalShards) is listed
+// once, at their highest score, and returned in dups.
+func mergePartitions(heads [][]redis.Z, k int) (members []redis.Z, dups []string) {
+	// N is small: pick the best head by linear scan, lowest partition first
+	// on equal scores
+	seen := make(map[string]bool)
+	for len(members) < k {
+		best := -1
+		for i, head := range heads {
+			if len(head) > 0 && (best < 0 || head[0].Score > heads[best][0].Score) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		z := heads[best][0]
+		heads[best] = heads[best][1:]
+		member := z.Member.(string)
+		if seen[member] {
+			dups = append(dups, member)
+			continue
+		}
+		seen[member] = true
+		members = append(members, z)
+	}
+	return members, dups
+}
+
+// partitionRank turns a user's rank within their partition into their
+// global rank: it adds the users scoring higher in every other partition,
+// and those scoring the same in lower partitions, in one pipeline.
+func (lb *Leaderboard) partitionRank(c RedisCommands, userID string, score float64, localRank int64) (int64, error) {
+	own := lb.partitionOf(userID)
+	s := strconv.FormatFloat(score, 'g', -1, 64)
+
+	pipe := c.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, lb.config.GlobalShards-1)
+	for i, key := range lb.globalKeys() {
+		switch {
+		case i < own:
+			cmds = append(cmds, pipe.ZCount(lb.ctx, key, s, "+inf"))
+		case i > own:
+			cmds = append(cmds, pipe.ZCount(lb.ctx, key, "("+s, "+inf"))
+		}
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return -1, fmt.Errorf("failed to count higher scores: %w", err)
+	}
+	rank := localRank
+	for _, cmd := range cmds {
+		rank += cmd.Val()
+	}
+	return rank, nil
+}
@@ -0,0 +1,189 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGlobalShards(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5, GlobalShards: 4})
+	defer lb.Close()
+
+	for i := 0; i < 20; i++ {
+		entity := "US"
+		if i%2 == 0 {
+			entity = "EU"
+		}
+		lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: entity, Score: float64(i % 7)})
+	}
+	if n, _ := lb.client.Exists(lb.ctx, lb.globalKey()).Result(); n != 0 {
+		t.Error("expected no unpartitioned global key")
+	}
+	var stored int64
+	for _, key := range lb.globalKeys() {
+		stored += lb.client.ZCard(lb.ctx, key).Val()
+	}
+	if stored != 20 {
+		t.Errorf("expected 20 users over the partitions, got %d", stored)
+	}
+
+	// ranks agree with the merged top-k and are unique
+	top, err := lb.GetTopKGlobal()
+	if err != nil || len(top) != 5 || top[0].Score != 6 {
+		t.Fatalf("unexpected top-k: %+v, %v", top, err)
+	}
+	for i, u := range top {
+		if rank, err := lb.GetRankGlobal(u.ID); err != nil || rank != i {
+			t.Errorf("%s: expected rank %d, got %d, err: %v", u.ID, i, rank, err)
+		}
+		if i > 0 && u.Score > top[i-1].Score {
+			t.Errorf("top-k not ordered: %+v", top)
+		}
+	}
+	seen := make(map[int]bool)
+	for i := 0; i < 20; i++ {
+		rank, _ := lb.GetRankGlobal(fmt.Sprintf("u%d", i))
+		if rank < 0 || rank >= 20 || seen[rank] {
+			t.Errorf("u%d: unexpected or duplicate rank %d", i, rank)
+		}
+		seen[rank] = true
+	}
+
+	lb.IncrementScore("u1", "US", 100)
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil || data.GlobalRank != 0 || data.Score != 101 || data.TopKGlobal[0].ID != "u1" {
+		t.Errorf("unexpected leaderboard data: %+v, %v", data, err)
+	}
+	want, _ := lb.GetRankGlobal("u3")
+	if top, self, err := lb.GetTopKWithUser("u3"); err != nil || len(top) != 5 || self.Rank != want {
+		t.Errorf("expected u3 at rank %d, got %+v, %+v, %v", want, top, self, err)
+	}
+	lb.RemoveUser("u1")
+	if rank, _ := lb.GetRankGlobal("u1"); rank != -1 {
+		t.Errorf("expected removed user unranked, got %d", rank)
+	}
+
+	if _, err := lb.GetRanksGlobal([]string{"u2"}); !errors.Is(err, ErrShardingUnsupported) {
+		t.Errorf("expected ErrShardingUnsupported, got %v", err)
+	}
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, GlobalShards: 4, EntityInMember: true}); !errors.Is(err, ErrShardingUnsupported) {
+		t.Errorf("expected ErrShardingUnsupported from New, got %v", err)
+	}
+}
+
+// keyWriteHook counts pipelined writes per key.
+type keyWriteHook struct {
+	mu     sync.Mutex
+	writes map[string]int
+}
+
+func (*keyWriteHook) DialHook(next redis.DialHook) redis.DialHook          { return next }
+func (*keyWriteHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (h *keyWriteHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.mu.Lock()
+		for _, cmd := range cmds {
+			if args := cmd.Args(); cmd.Name() == "zincrby" {
+				key, _ := args[1].(string)
+				if strings.Contains(key, ":global") {
+					h.writes[key]++
+				}
+			}
+		}
+		h.mu.Unlock()
+		return next(ctx, cmds)
+	}
+}
+
+// BenchmarkIncrementScoreGlobalShards runs concurrent increments against
+// one global key and against partitions. hottest-key-share is the fraction
+// of global writes landing on the busiest key: the load a single Redis
+// Cluster node (or key) absorbs.
+func BenchmarkIncrementScoreGlobalShards(b *testing.B) {
+	for _, shards := range []int{1, 8} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			lb, err := New(Config{Namespace: "bench", RedisAddr: testRedisAddr, GlobalShards: shards})
+			if err != nil {
+				b.Skipf("redis unavailable: %v", err)
+			}
+			defer lb.Close()
+			lb.ForceClearLeaderBoardWithNamespacePrefix()
+			defer lb.ForceClearLeaderBoardWithNamespacePrefix()
+
+			hook := &keyWriteHook{writes: make(map[string]int)}
+			rawClient(lb).AddHook(hook)
+			var n atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					id := fmt.Sprintf("u%d", n.Add(1)%1000)
+					if err := lb.IncrementScore(id, "", 1); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.StopTimer()
+
+			var total, hottest int
+			for _, w := range hook.writes {
+				total += w
+				hottest = max(hottest, w)
+			}
+			if total > 0 {
+				b.ReportMetric(float64(hottest)/float64(total), "hottest-key-share")
+			}
+		})
+	}
+}
+
+func TestMergePartitionsDedup(t *testing.T) {
+	heads := [][]redis.Z{
+		{{Score: 90, Member: "u1"}, {Score: 50, Member: "u2"}},
+		{{Score: 80, Member: "u3"}, {Score: 70, Member: "u1"}},
+		{{Score: 60, Member: "u2"}, {Score: 40, Member: "u4"}},
+	}
+	members, dups := mergePartitions(heads, 4)
+
+	want := []redis.Z{{Score: 90, Member: "u1"}, {Score: 80, Member: "u3"}, {Score: 60, Member: "u2"}, {Score: 40, Member: "u4"}}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("expected %v, got %v", want, members)
+	}
+	if !reflect.DeepEqual(dups, []string{"u1", "u2"}) {
+		t.Errorf("expected duplicates [u1 u2], got %v", dups)
+	}
+}
+
+func TestGlobalShardsDuplicateLogged(t *testing.T) {
+	var out lockedBuffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 3, GlobalShards: 4, Logger: logger})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 10})
+	lb.AddUser(User{ID: "u2", Score: 20})
+	// a stale copy of u2 in another partition, e.g. after resharding
+	other := (lb.partitionOf("u2") + 1) % 4
+	lb.client.ZAdd(lb.ctx, lb.globalPartitionKey(other), redis.Z{Score: 30, Member: "u2"})
+
+	top, err := lb.GetTopKGlobal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].ID != "u2" || top[0].Score != 30 || top[1].ID != "u1" {
+		t.Errorf("expected unique users u2 (30), u1, got %+v", top)
+	}
+	if !strings.Contains(out.String(), "user ranked in several global partitions") {
+		t.Errorf("expected a duplicate warning, got %q", out.String())
+	}
+}
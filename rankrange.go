@@ -0,0 +1,66 @@
+package redisboard
+
+import "fmt"
+
+// GetUsersByRankRange returns the users ranked startRank through endRank
+// globally, both inclusive and 0-based like GetRankGlobal, e.g. 49, 74 for
+// "ranks 50 through 75". Each user carries their absolute rank (1-based
+// with OneBasedRanks), entity and, if enabled, metadata and name. Users
+// keep Redis' order even with TieBreakField, so the ranks match
+// GetRankGlobal. A range past the last user returns fewer users or none.
+// Returns error if:
+// - startRank is negative or endRank is below startRank
+// - the range spans more than Config.MaxReadSize users (ErrTooManyUsers)
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) GetUsersByRankRange(startRank, endRank int) (_ []RankedUser, err error) {
+	defer lb.startOp("GetUsersByRankRange", "", "").end(&err)
+	if startRank < 0 || endRank < startRank {
+		return nil, fmt.Errorf("invalid rank range: %d to %d", startRank, endRank)
+	}
+	if endRank-startRank >= lb.config.MaxReadSize {
+		return nil, fmt.Errorf("%w: rank range spans more than %d users", ErrTooManyUsers, lb.config.MaxReadSize)
+	}
+	if err := lb.unpartitioned(); err != nil {
+		return nil, err
+	}
+
+	members, err := lb.reader.ZRevRangeWithScores(lb.ctx, lb.globalKey(), int64(startRank), int64(endRank)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank range: %w", err)
+	}
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	return lb.rankUsers(users, startRank), nil
+}
+
+// GetAll returns every user of a small board, ordered like GetTopKGlobal
+// (score descending, equal scores by TieBreakField if set), with entity
+// and, if enabled, metadata and name.
+// Returns error if:
+// - the board has more than Config.MaxReadSize users (ErrTooManyUsers)
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) GetAll() (_ []User, err error) {
+	defer lb.startOp("GetAll", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra member to detect oversized boards in one round-trip
+	members, err := lb.reader.ZRevRangeWithScores(lb.ctx, lb.globalKey(), 0, int64(lb.config.MaxReadSize)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+	if len(members) > lb.config.MaxReadSize {
+		return nil, fmt.Errorf("%w: board has more than %d users", ErrTooManyUsers, lb.config.MaxReadSize)
+	}
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	lb.sortTies(users)
+	return users, nil
+}
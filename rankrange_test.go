@@ -0,0 +1,80 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGetUsersByRankRange(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxReadSize: 5})
+	defer lb.Close()
+
+	for i := 0; i < 10; i++ {
+		lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(100 - i)})
+	}
+
+	users, err := lb.GetUsersByRankRange(3, 5)
+	if err != nil {
+		t.Fatalf("GetUsersByRankRange: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got %+v", users)
+	}
+	for i, u := range users {
+		if wantID := fmt.Sprintf("u%d", 3+i); u.ID != wantID || u.Rank != 3+i || u.Entity != "US" {
+			t.Errorf("position %d: expected %s at rank %d in US, got %+v", i, wantID, 3+i, u)
+		}
+		if rank, _ := lb.GetRankGlobal(u.ID); rank != u.Rank {
+			t.Errorf("%s: rank %d disagrees with GetRankGlobal %d", u.ID, u.Rank, rank)
+		}
+	}
+
+	if users, err := lb.GetUsersByRankRange(8, 12); err != nil || len(users) != 2 {
+		t.Errorf("expected the last 2 users, got %+v, %v", users, err)
+	}
+	if users, err := lb.GetUsersByRankRange(20, 21); err != nil || len(users) != 0 {
+		t.Errorf("expected no users past the end, got %+v, %v", users, err)
+	}
+
+	for _, r := range [][2]int{{-1, 2}, {5, 4}} {
+		if _, err := lb.GetUsersByRankRange(r[0], r[1]); err == nil {
+			t.Errorf("expected error for range %v", r)
+		}
+	}
+	if _, err := lb.GetUsersByRankRange(0, 5); !errors.Is(err, ErrTooManyUsers) {
+		t.Errorf("expected ErrTooManyUsers beyond MaxReadSize, got %v", err)
+	}
+
+	oneBased := newTestLeaderboard(t, Config{Namespace: "test", OneBasedRanks: true})
+	defer oneBased.Close()
+	oneBased.AddUser(User{ID: "u0", Score: 100})
+	if users, _ := oneBased.GetUsersByRankRange(0, 0); len(users) != 1 || users[0].ID != "u0" || users[0].Rank != 1 {
+		t.Errorf("expected u0 at rank 1 with OneBasedRanks, got %+v", users)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxReadSize: 3})
+	defer lb.Close()
+
+	if users, err := lb.GetAll(); err != nil || len(users) != 0 {
+		t.Errorf("expected empty board, got %+v, %v", users, err)
+	}
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 30})
+	lb.AddUser(User{ID: "u3", Score: 20})
+	users, err := lb.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if fmt.Sprint(users) != fmt.Sprint([]User{{ID: "u2", Entity: "UK", Score: 30}, {ID: "u3", Score: 20}, {ID: "u1", Entity: "US", Score: 10}}) {
+		t.Errorf("unexpected users %+v", users)
+	}
+
+	// the guard trips once the board outgrows MaxReadSize
+	lb.AddUser(User{ID: "u4", Score: 5})
+	if _, err := lb.GetAll(); !errors.Is(err, ErrTooManyUsers) {
+		t.Errorf("expected ErrTooManyUsers, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package redisboard
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rankSnapshotTTLFactor bounds the snapshot's lifetime to this many
+// intervals, so a board whose refreshers all stopped falls back to exact
+// ranks instead of serving ever staler ones.
+const rankSnapshotTTLFactor = 3
+
+// rankSnapshot periodically copies every user's global rank into the
+// {namespace}:ranks hash, so GetRankGlobal costs one HGET instead of a
+// ZREVRANK on hot boards.
+type rankSnapshot struct {
+	interval time.Duration
+
+	running   bool // refresh loop started
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newRankSnapshot returns a snapshot refresher, or nil if interval <= 0.
+func newRankSnapshot(interval time.Duration) *rankSnapshot {
+	if interval <= 0 {
+		return nil
+	}
+	return &rankSnapshot{
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// start launches the refresh loop in the background.
+func (s *rankSnapshot) start(lb *Leaderboard) {
+	s.running = true
+	go s.run(lb)
+}
+
+// run refreshes the snapshot every interval until close. Failed refreshes
+// are retried on the next tick; reads fall back to exact ranks for users
+// missing from the snapshot meanwhile.
+func (s *rankSnapshot) run(lb *Leaderboard) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			lb.refreshRankSnapshot()
+		}
+	}
+}
+
+// close stops the refresh loop, if running, and waits for it to exit.
+func (s *rankSnapshot) close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		if s.running {
+			<-s.done
+		}
+	})
+}
+
+// ForceRefresh rebuilds the rank snapshot served by GetRankGlobal now,
+// rather than waiting for the next Config.RankSnapshotInterval tick, e.g.
+// right after a bulk import.
+// Returns error if:
+// - RankSnapshotInterval is unset
+// - Redis operation fails
+func (lb *Leaderboard) ForceRefresh() (err error) {
+	defer lb.startOp("ForceRefresh", "", "").end(&err)
+	if lb.rankSnapshot == nil {
+		return fmt.Errorf("rank snapshots disabled")
+	}
+	return lb.refreshRankSnapshot()
+}
+
+// refreshRankSnapshot writes every global rank into a temporary hash in
+// chunks and swaps it in with RENAME, so readers never see a partial
+// snapshot. Each build uses its own temporary key, as several instances
+// may refresh at once.
+func (lb *Leaderboard) refreshRankSnapshot() error {
+	globalKey := lb.globalKey()
+	ranksKey := lb.ranksKey()
+	tmpKey := lb.key("ranks", "building", strconv.FormatUint(rand.Uint64(), 36))
+	ttl := rankSnapshotTTLFactor * lb.rankSnapshot.interval
+
+	batch := int64(lb.config.BatchSize)
+	for start := int64(0); ; start += batch {
+		members, err := lb.client.ZRevRange(lb.ctx, globalKey, start, start+batch-1).Result()
+		if err != nil {
+			lb.client.Del(lb.ctx, tmpKey)
+			return fmt.Errorf("failed to fetch users: %w", err)
+		}
+		if len(members) == 0 {
+			break
+		}
+		ranks := make(map[string]any, len(members))
+		for i, member := range members {
+			id, _ := lb.parseMember(member)
+			ranks[id] = start + int64(i)
+		}
+		pipe := lb.client.Pipeline()
+		pipe.HSet(lb.ctx, tmpKey, ranks)
+		pipe.PExpire(lb.ctx, tmpKey, ttl)
+		if _, err := pipe.Exec(lb.ctx); err != nil {
+			lb.client.Del(lb.ctx, tmpKey)
+			return fmt.Errorf("failed to write rank snapshot: %w", err)
+		}
+	}
+
+	n, err := lb.client.Exists(lb.ctx, tmpKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check rank snapshot: %w", err)
+	}
+	if n == 0 {
+		// empty board: drop the previous snapshot
+		if err := lb.client.Del(lb.ctx, ranksKey).Err(); err != nil {
+			return fmt.Errorf("failed to clear rank snapshot: %w", err)
+		}
+		return nil
+	}
+	if err := lb.client.Rename(lb.ctx, tmpKey, ranksKey).Err(); err != nil {
+		return fmt.Errorf("failed to swap in rank snapshot: %w", err)
+	}
+	return nil
+}
+
+// snapshotRank returns userID's rank from the snapshot; ok is false if the
+// user isn't in it (added since, or no snapshot yet).
+func (lb *Leaderboard) snapshotRank(userID string) (rank int, ok bool, err error) {
+	rank, err = lb.reader.HGet(lb.ctx, lb.ranksKey(), userID).Int()
+	if err == redis.Nil {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, fmt.Errorf("failed to get snapshot rank: %w", err)
+	}
+	return rank, true, nil
+}
@@ -0,0 +1,65 @@
+package redisboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankSnapshot(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", RankSnapshotInterval: time.Hour})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 300})
+	lb.AddUser(User{ID: "u2", Score: 200})
+	if err := lb.ForceRefresh(); err != nil {
+		t.Fatalf("ForceRefresh: %v", err)
+	}
+	if rank, _ := lb.GetRankGlobal("u2"); rank != 1 {
+		t.Errorf("expected snapshot rank 1, got %d", rank)
+	}
+
+	// a new leader: snapshot ranks go stale until the next refresh
+	lb.AddUser(User{ID: "u3", Score: 400})
+	if rank, _ := lb.GetRankGlobal("u2"); rank != 1 {
+		t.Errorf("expected stale snapshot rank 1, got %d", rank)
+	}
+	if rank, _ := lb.GetRankGlobalExact("u2"); rank != 2 {
+		t.Errorf("expected exact rank 2, got %d", rank)
+	}
+	if rank, _ := lb.GetRankGlobal("u3"); rank != 0 {
+		t.Errorf("expected user missing from snapshot to read exact rank 0, got %d", rank)
+	}
+	if rank, _ := lb.GetRankGlobal("ghost"); rank != -1 {
+		t.Errorf("expected -1 for unknown user, got %d", rank)
+	}
+
+	if err := lb.ForceRefresh(); err != nil {
+		t.Fatalf("ForceRefresh: %v", err)
+	}
+	if rank, _ := lb.GetRankGlobal("u2"); rank != 2 {
+		t.Errorf("expected refreshed rank 2, got %d", rank)
+	}
+	if ttl, _ := lb.client.PTTL(lb.ctx, lb.ranksKey()).Result(); ttl <= 0 || ttl > rankSnapshotTTLFactor*time.Hour {
+		t.Errorf("expected snapshot to expire within %d intervals, got %v", rankSnapshotTTLFactor, ttl)
+	}
+}
+
+func TestRankSnapshotDisabled(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if err := lb.ForceRefresh(); err == nil {
+		t.Error("expected error without RankSnapshotInterval")
+	}
+}
+
+func TestRankSnapshotLoop(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", RankSnapshotInterval: 50 * time.Millisecond})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	time.Sleep(150 * time.Millisecond)
+	if n, _ := rawClient(lb).HLen(lb.ctx, lb.ranksKey()).Result(); n != 1 {
+		t.Errorf("expected background refresh to snapshot 1 user, got %d", n)
+	}
+}
@@ -2,12 +2,20 @@ package redisboard
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/redis/go-redis/v9"
 )
 
-
 // Package redisboard provides a Redis-based ranking system with support for:
 // - global rankings across all users
 // - entity-based rankings (e.g., by country)
@@ -19,12 +27,98 @@ import (
 // Config defines settings for leaderboard initialization.
 type Config struct {
 	Namespace   string // prefix for redis keys (e.g., "game1")
+	KeyPrefix   string // prepended verbatim to the namespace of every key (e.g., "prod:")
+	Season      string // optional season within the namespace; keys become {namespace}:s{season}:... ("": default season)
 	K           int    // number of top users to track (e.g., 10)
-	MaxUsers    int    // maximum allowed users (e.g., 1M)
-	MaxEntities int    // maximum allowed entities (e.g., 200)
+	MaxUsers    int    // maximum allowed users (e.g., 1M), enforced per EvictionPolicy
+	MaxEntities int    // maximum allowed entities (e.g., 200), enforced per EntityLimitPolicy
 	FloatScores bool   // true: keep decimals, false: round to integers
 	RedisAddr   string // redis connection address (e.g., "localhost:6379")
 	RedisPass   string // optional redis authentication
+	ReplicaAddr string // optional replica address serving top-k, rank and score reads
+
+	Client RedisClient // optional existing connection used instead of RedisAddr (e.g., miniredis or a mock in tests); not closed by Close; enable ContextTimeoutEnabled for ctx deadlines
+
+	ConnectTimeout time.Duration // max wait for the initial PING in New (e.g., 5s)
+
+	VerifyNamespace bool // true: New also checks the type of every namespace key with SCAN, e.g. entity rankings (slow on large namespaces)
+
+	HealthCheckInterval time.Duration // background PING interval for ConnectionState (0: disabled)
+
+	SlowThreshold time.Duration // log method calls slower than this, Redis round trips included, through Logger (0: disabled)
+	Logger        Logger        // receives slow operation, EntityLimitLog, GlobalShards, DecayInterval and CoalesceInterval warnings (default: JSON lines on stderr)
+
+	EnableMetadata bool // true: store and return per-user metadata
+
+	EnableNames bool // true: store User.Name and return it in user results
+
+	TieBreakField string // metadata field ordering equal-score users in top-k reads, ascending (needs EnableMetadata)
+
+	TrackActivity bool // true: record each user's last score update for PruneInactive
+
+	PublishRankChanges bool // true: publish each user's rank change for SubscribeRankCrossings (two extra lookups per write)
+
+	TrackBestRank bool // true: keep each user's best global rank for GetBestRank (one extra script call per write)
+
+	EventCodec string // rank change payload encoding: EventCodecJSON (default) or EventCodecProtobuf
+
+	AllowNegativeScores bool // true: accept negative scores (e.g., penalty or golf scoring)
+
+	RequireExistingUser bool // true: IncrementScore/DecrementScore fail with ErrUserNotFound instead of creating users
+
+	StrictEntity bool // true: increments naming an entity other than the user's fail with ErrEntityMismatch
+
+	OneBasedRanks bool // true: RankedUser.Rank starts at 1 instead of 0
+
+	CheckIntegrity bool // true: GetUserLeaderboardData* report stray entity memberships in StrayEntities (scans every entity key per read)
+
+	RollbackPartialWrites bool // true: AddUser undoes a partly applied write instead of returning ErrPartialWrite (two extra lookups per write)
+
+	EntityMaxLength int    // maximum entity length (e.g., 64)
+	MaxUserIDLength int    // maximum user ID length in bytes (e.g., 256)
+	EntityCharset   string // allowed entity characters (default: letters, digits, "-", "_")
+
+	EntityCaseInsensitive bool // true: lowercase entities, so "US", "us" and "Us" are one entity
+
+	EntityMergeAggregate string // how MergeEntities combines colliding scores: "MAX" (default) or "SUM"
+
+	PrimaryEntity string // entity that entity ranks resolve to first when the user is ranked in it (optional)
+
+	MinEntitySize int // GetTopKEntityOrGlobal serves the global top k for entities with fewer users (0: never)
+
+	TopKCacheTTL time.Duration // cache GetTopKGlobal/GetTopKEntity results in memory (0: disabled)
+
+	CachedTopKTTL time.Duration // max age of GetTopKGlobalCached results (0: until InvalidateCache)
+
+	ApproxRankTTL time.Duration // how long GetApproximateRank reuses its score histogram (e.g., 1m)
+
+	RankSnapshotInterval time.Duration // serve GetRankGlobal from a rank snapshot rebuilt this often (0: exact ranks)
+
+	DecayInterval time.Duration // run ApplyDecay(DecayFactor) this often in the background (0: disabled)
+	DecayFactor   float64       // factor DecayInterval multiplies every score by, in (0, 1] (e.g., 0.99)
+
+	IdempotencyWindow time.Duration // how long IncrementScoreIdempotent remembers idempotency keys (e.g., 24h)
+
+	CoalesceInterval time.Duration // buffer IncrementScore/DecrementScore deltas and flush them this often (0: disabled)
+
+	MaxReadSize int // maximum users returned by unbounded reads (e.g., 10,000)
+
+	BatchSize int // maximum users, updates or members per pipeline, page or SCAN batch of bulk operations (e.g., 1000)
+
+	KeySeparator string // separator between key parts (default ":")
+
+	EvictionPolicy    string // MaxUsers enforcement: EvictionNone (default), EvictionReject or EvictionLowest
+	MaxUsersPerEntity int    // maximum users per entity ranking (0: unlimited), evicting only under EvictionLowest
+
+	EntityLimitPolicy string // MaxEntities enforcement: EntityLimitNone (default), EntityLimitReject or EntityLimitLog
+
+	EntityTotals bool // true: maintain a ranking of entities by summed user score for GetEntityRank
+
+	EntityInMember bool // true: encode the entity into global members ("user|entity") instead of hash lookups
+
+	Sharder Sharder // optional routing of entity rankings to other Redis backends (nil: all on RedisAddr)
+
+	GlobalShards int // split the global ranking into this many ZSETs by user ID hash (0 or 1: one key)
 }
 
 // User represents a single leaderboard entry with score and grouping.
@@ -32,30 +126,122 @@ type User struct {
 	ID     string  // unique user identifier
 	Entity string  // grouping key (e.g., country code)
 	Score  float64 // current score (rounded if FloatScores=false)
+
+	Metadata map[string]string // optional extra data (e.g., avatar URL), needs EnableMetadata
+
+	Name string // optional display name for opaque IDs, needs EnableNames
+}
+
+// RankedUser is a User with its explicit position in a ranking.
+type RankedUser struct {
+	User
+	Rank int // position in ranking (0-based, 1-based if OneBasedRanks)
+
+	InTopK bool // GetTopKWithUser only: the user is also listed in the top-k
 }
 
 // LeaderboardData holds complete ranking information for a user.
 type LeaderboardData struct {
 	UserID     string  `json:"userID"`     // user identifier
+	Exists     bool    `json:"exists"`     // false if user is not on the leaderboard
 	Score      float64 `json:"score"`      // current score
 	Entity     string  `json:"entity"`     // grouping identifier
 	GlobalRank int     `json:"globalRank"` // position across all users (0-based)
 	EntityRank int     `json:"entityRank"` // position within entity (0-based)
 	TopKGlobal []User  `json:"topKGlobal"` // top k users globally
 	TopKEntity []User  `json:"topKEntity"` // top k users in same entity
+
+	Metadata map[string]string `json:"metadata,omitempty"` // user metadata (EnableMetadata only)
+	Name     string            `json:"name,omitempty"`     // display name (EnableNames only)
+
+	TopKEntities map[string][]User `json:"topKEntities,omitempty"` // top k users of other entities (GetUserLeaderboardDataWithEntities only)
+
+	StrayEntities []string `json:"strayEntities,omitempty"` // other entity rankings also holding the user (CheckIntegrity only)
 }
 
 // Leaderboard manages the ranking system using Redis backend.
 type Leaderboard struct {
 	config Config          // configuration settings
-	client *redis.Client   // redis connection
+	client RedisClient     // redis connection
+	reader RedisClient     // replica connection for reads (client if no ReplicaAddr)
 	ctx    context.Context // context for redis operations
+
+	ownsClient bool // client dialed by New rather than Config.Client
+	ownsReader bool // reader dialed by New for ReplicaAddr
+
+	closed *closeState // commands in flight, refused after Close
+
+	topKCache *topKCache     // optional top-k cache (nil: disabled)
+	scripts   *scriptLoader  // lazily loaded Lua scripts
+	health    *healthMonitor // connection state tracking
+
+	rankHistogram *rankHistogram // score histogram for GetApproximateRank
+	coalescer     *coalescer     // buffered increments (nil: disabled)
+	rankSnapshot  *rankSnapshot  // periodic rank snapshot (nil: disabled)
+	decay         *decayLoop     // periodic score decay (nil: disabled)
+
+	knownEntities sync.Map // entity codes admitted under EntityLimitPolicy
+
+	pinnedTopK *pinnedTopK // GetTopKGlobalCached result
+
+	resets *resetScheduler // resets pending from ScheduleReset
 }
 
-// Redis key structure:
-// {namespace}:global         -> zset of all users and scores
-// {namespace}:user:entities  -> hash mapping users to entities
-// {namespace}:entity:{code}  -> zset of users/scores per entity
+var (
+	// ErrInvalidEntity is returned when an entity is too long or contains
+	// characters outside Config.EntityCharset. Entities become part of Redis
+	// key names, so they are validated before any write.
+	ErrInvalidEntity = errors.New("invalid entity")
+
+	// ErrInvalidUserID is returned when a user ID is longer than
+	// Config.MaxUserIDLength or contains control characters, which would
+	// bloat every ranking and hash or garble members and logs.
+	ErrInvalidUserID = errors.New("invalid user ID")
+
+	// ErrUserNotFound is returned when an operation requires an existing user.
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrNamespaceConflict is returned when a namespace key already holds
+	// data of another Redis type, i.e. the namespace is shared with
+	// unrelated data.
+	ErrNamespaceConflict = errors.New("namespace conflict")
+
+	// ErrTooManyUsers is returned by unbounded reads when the result would
+	// exceed Config.MaxReadSize.
+	ErrTooManyUsers = errors.New("too many users")
+
+	// ErrConflict is returned when an optimistic transaction keeps failing
+	// because of concurrent writes. The operation can be retried.
+	ErrConflict = errors.New("too much contention")
+
+	// ErrScoreOverflow is returned when FloatScores=false and an integer
+	// score or delta exceeds maxExactScore, beyond which Redis cannot store
+	// it exactly.
+	ErrScoreOverflow = errors.New("score exceeds exact integer range")
+
+	// ErrInvalidScore is returned when a score, delta or weight is NaN or
+	// infinite. Redis rejects NaN outright and an infinite score can never
+	// be incremented back to a finite one.
+	ErrInvalidScore = errors.New("score is NaN or infinite")
+)
+
+// defaultEntityCharset is used when Config.EntityCharset is empty.
+const defaultEntityCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+
+// defaultConnectTimeout is used when Config.ConnectTimeout is unset.
+const defaultConnectTimeout = 5 * time.Second
+
+// batchSize is the default Config.BatchSize, and the SCAN count hint of
+// ListNamespaces.
+const batchSize = 1000
+
+// maxExactScore is the largest integer (2^53) a Redis sorted set score,
+// stored as a float64, represents exactly. Larger integers silently lose
+// precision, which breaks cumulative integer scores.
+const maxExactScore = 1 << 53
+
+// maxTxRetries bounds optimistic (WATCH) transaction retries on contention.
+const maxTxRetries = 10
 
 // New creates leaderboard instance with given config.
 // Validates config values and sets defaults if needed:
@@ -63,8 +249,29 @@ type Leaderboard struct {
 // - K: 10 if <= 0
 // - MaxUsers: 1M if <= 0
 // - MaxEntities: 200 if <= 0
-// - RedisAddr: "localhost:6379" if empty
-// Returns error if Redis connection fails.
+// - RedisAddr: "localhost:6379" if empty (unused with Client)
+// - EntityMaxLength: 64 if <= 0
+// - MaxUserIDLength: 256 if <= 0
+// - EntityCharset: letters, digits, "-" and "_" if empty
+// - EntityMergeAggregate: "MAX" if empty
+// - MaxReadSize: 10,000 if <= 0
+// - BatchSize: 1000 if <= 0
+// - KeySeparator: ":" if empty
+// - ConnectTimeout: 5s if <= 0
+// - Logger: JSON lines on stderr if nil and SlowThreshold, EntityLimitLog, GlobalShards, DecayInterval or CoalesceInterval is set
+// - ApproxRankTTL: 1m if <= 0
+// - IdempotencyWindow: 24h if <= 0
+// - EventCodec: EventCodecJSON if empty
+// Returns error if EvictionPolicy, EntityLimitPolicy or EventCodec is
+// unknown, EntityCharset contains "|" with EntityInMember, Sharder is combined with eviction, user caps, PrimaryEntity
+// or CoalesceInterval (ErrShardingUnsupported), GlobalShards is combined
+// with options assuming one global key (ErrShardingUnsupported),
+// RequireExistingUser, StrictEntity or TrackBestRank is
+// combined with CoalesceInterval, TieBreakField is set without
+// EnableMetadata, DecayFactor is outside (0, 1] with DecayInterval, PrimaryEntity is invalid (ErrInvalidEntity), Season is
+// invalid (ErrInvalidSeason), Redis (or
+// replica) connection fails or the namespace keys (every key with
+// VerifyNamespace) hold other data types (ErrNamespaceConflict).
 func New(cfg Config) (*Leaderboard, error) {
 	if cfg.Namespace == "" {
 		cfg.Namespace = "default"
@@ -81,240 +288,1145 @@ func New(cfg Config) (*Leaderboard, error) {
 	if cfg.RedisAddr == "" {
 		cfg.RedisAddr = "localhost:6379"
 	}
+	if cfg.EntityMaxLength <= 0 {
+		cfg.EntityMaxLength = 64
+	}
+	if cfg.MaxUserIDLength <= 0 {
+		cfg.MaxUserIDLength = 256
+	}
+	if cfg.EntityCharset == "" {
+		cfg.EntityCharset = defaultEntityCharset
+	}
+	if cfg.EntityCaseInsensitive {
+		cfg.PrimaryEntity = strings.ToLower(cfg.PrimaryEntity)
+	}
+	if cfg.EntityMergeAggregate == "" {
+		cfg.EntityMergeAggregate = "MAX"
+	}
+	if cfg.MaxReadSize <= 0 {
+		cfg.MaxReadSize = 10_000
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = batchSize
+	}
+	if cfg.KeySeparator == "" {
+		cfg.KeySeparator = ":"
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+	if (cfg.SlowThreshold > 0 || cfg.EntityLimitPolicy == EntityLimitLog || cfg.GlobalShards > 1 || cfg.DecayInterval > 0 || cfg.CoalesceInterval > 0) && cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+	if cfg.ApproxRankTTL <= 0 {
+		cfg.ApproxRankTTL = time.Minute
+	}
+	if cfg.IdempotencyWindow <= 0 {
+		cfg.IdempotencyWindow = 24 * time.Hour
+	}
+	if cfg.EntityInMember && strings.Contains(cfg.EntityCharset, memberSeparator) {
+		return nil, fmt.Errorf("entity charset must not contain %q with EntityInMember", memberSeparator)
+	}
+	switch cfg.EvictionPolicy {
+	case EvictionNone, EvictionReject, EvictionLowest:
+	default:
+		return nil, fmt.Errorf("invalid eviction policy %q", cfg.EvictionPolicy)
+	}
+	switch cfg.EventCodec {
+	case "":
+		cfg.EventCodec = EventCodecJSON
+	case EventCodecJSON, EventCodecProtobuf:
+	default:
+		return nil, fmt.Errorf("invalid event codec %q", cfg.EventCodec)
+	}
+	switch cfg.EntityLimitPolicy {
+	case EntityLimitNone, EntityLimitReject, EntityLimitLog:
+	default:
+		return nil, fmt.Errorf("invalid entity limit policy %q", cfg.EntityLimitPolicy)
+	}
+	if cfg.EntityTotals && (cfg.EvictionPolicy != EvictionNone || cfg.MaxUsersPerEntity > 0) {
+		return nil, fmt.Errorf("EntityTotals can't be combined with eviction policies or user caps")
+	}
+	if cfg.RollbackPartialWrites && (cfg.EvictionPolicy != EvictionNone || cfg.MaxUsersPerEntity > 0) {
+		return nil, fmt.Errorf("RollbackPartialWrites can't undo evictions")
+	}
+	if err := validateSharding(cfg); err != nil {
+		return nil, err
+	}
+	if err := validatePartitions(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.TieBreakField != "" && !cfg.EnableMetadata {
+		return nil, fmt.Errorf("TieBreakField needs EnableMetadata")
+	}
+	if cfg.RequireExistingUser && cfg.CoalesceInterval > 0 {
+		return nil, fmt.Errorf("RequireExistingUser can't be checked with CoalesceInterval")
+	}
+	if cfg.StrictEntity && cfg.CoalesceInterval > 0 {
+		return nil, fmt.Errorf("StrictEntity can't be checked with CoalesceInterval")
+	}
+	if cfg.TrackBestRank && cfg.CoalesceInterval > 0 {
+		return nil, fmt.Errorf("TrackBestRank can't follow coalesced increments")
+	}
+	if cfg.DecayInterval > 0 {
+		if err := validateDecayFactor(cfg.DecayFactor); err != nil {
+			return nil, err
+		}
+	}
+
+	var client RedisClient = cfg.Client
+	ownsClient := client == nil
+	if ownsClient {
+		client = dial(cfg.RedisAddr, cfg.RedisPass)
+	} else {
+		client = closeGuard{client} // not ours to hook
+	}
+	ctx := context.Background()
+
+	if err := ping(ctx, client, cfg.ConnectTimeout); err != nil {
+		if !ownsClient {
+			return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		}
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", cfg.RedisAddr, err)
+	}
+
+	reader := client
+	if cfg.ReplicaAddr != "" {
+		reader = dial(cfg.ReplicaAddr, cfg.RedisPass)
+		if err := ping(ctx, reader, cfg.ConnectTimeout); err != nil {
+			reader.Close()
+			if ownsClient {
+				client.Close()
+			}
+			return nil, fmt.Errorf("failed to connect to Redis replica at %s: %w", cfg.ReplicaAddr, err)
+		}
+	}
+
+	return newLeaderboard(cfg, client, reader, ownsClient, reader != client)
+}
+
+// newLeaderboard builds a leaderboard on connected clients and starts its
+// background loops. ownsClient and ownsReader tell Close which to close.
+func newLeaderboard(cfg Config, client, reader RedisClient, ownsClient, ownsReader bool) (*Leaderboard, error) {
+	closed := newCloseState()
+	lb := &Leaderboard{
+		config:    cfg,
+		client:    client,
+		ctx:       context.WithValue(context.Background(), closeStateKey{}, closed),
+		reader:    reader,
+		topKCache: newTopKCache(cfg.TopKCacheTTL),
+		scripts:   newScriptLoader(),
+		health:    newHealthMonitor(),
+
+		rankHistogram: newRankHistogram(cfg.ApproxRankTTL),
+		coalescer:     newCoalescer(cfg.CoalesceInterval),
+		rankSnapshot:  newRankSnapshot(cfg.RankSnapshotInterval),
+		decay:         newDecayLoop(cfg.DecayInterval, cfg.DecayFactor),
+
+		ownsClient: ownsClient,
+		ownsReader: ownsReader,
+		closed:     closed,
+
+		pinnedTopK: &pinnedTopK{ttl: cfg.CachedTopKTTL},
+
+		resets: newResetScheduler(),
+	}
+	if err := lb.validateEntity(cfg.PrimaryEntity); err != nil {
+		lb.Close()
+		return nil, err
+	}
+	if err := lb.validateSeason(cfg.Season); err != nil {
+		lb.Close()
+		return nil, err
+	}
+	if err := lb.checkNamespace(); err != nil {
+		lb.Close()
+		return nil, err
+	}
+	if cfg.VerifyNamespace {
+		if _, err := lb.verifyNamespace(); err != nil {
+			lb.Close()
+			return nil, err
+		}
+	}
+	if cfg.EntityLimitPolicy != EntityLimitNone {
+		if err := lb.seedEntityCodes(); err != nil {
+			lb.Close()
+			return nil, err
+		}
+	}
+	if cfg.HealthCheckInterval > 0 {
+		lb.health.start(lb, cfg.HealthCheckInterval)
+	}
+	if lb.coalescer != nil {
+		lb.coalescer.start(lb, cfg.CoalesceInterval)
+	}
+	if lb.rankSnapshot != nil {
+		lb.rankSnapshot.start(lb)
+	}
+	if lb.decay != nil {
+		lb.decay.start(lb)
+	}
+	return lb, nil
+}
 
+// dial returns a client to addr, hooked to fail the commands of closed
+// leaderboards with ErrClosed.
+func dial(addr, password string) *redis.Client {
 	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.RedisAddr,
-		Password: cfg.RedisPass,
+		Addr:     addr,
+		Password: password,
 		DB:       0,
+
+		ContextTimeoutEnabled: true, // abort commands at the caller's deadline, not ReadTimeout
 	})
-	ctx := context.Background()
+	client.AddHook(closeHook{})
+	return client
+}
 
-	_, err := client.Ping(ctx).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+// ping checks that client answers within timeout, so a wrong address fails
+// New fast instead of hanging for the dial and retry timeouts.
+func ping(ctx context.Context, client RedisClient, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := client.Ping(ctx).Err()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("no response within %v: %w", timeout, err)
+	}
+	return err
+}
+
+// checkNamespace verifies that the namespace's fixed keys either don't exist
+// or hold the expected Redis type, so a namespace shared with unrelated data
+// fails fast with ErrNamespaceConflict instead of opaque WRONGTYPE errors.
+func (lb *Leaderboard) checkNamespace() error {
+	expected := lb.fixedKeyTypes()
+	pipe := lb.client.Pipeline()
+	typeCmds := make(map[string]*redis.StatusCmd, len(expected))
+	for key := range expected {
+		typeCmds[key] = pipe.Type(lb.ctx, key)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return fmt.Errorf("failed to check namespace keys: %w", err)
+	}
+	for key, want := range expected {
+		if got := typeCmds[key].Val(); got != "none" && got != want {
+			return fmt.Errorf("%w: key %s holds a %s, expected %s", ErrNamespaceConflict, key, got, want)
+		}
+	}
+	return nil
+}
+
+// fixedKeyTypes maps the namespace's fixed keys to their Redis type.
+func (lb *Leaderboard) fixedKeyTypes() map[string]string {
+	expected := map[string]string{
+		lb.globalKey():       "zset",
+		lb.entitiesKey():     "hash",
+		lb.metaKey():         "hash",
+		lb.namesKey():        "hash",
+		lb.ranksKey():        "hash",
+		lb.metricsKey():      "set",
+		lb.entityCodesKey():  "set",
+		lb.entityTotalsKey(): "zset",
+		lb.activityKey():     "zset",
+		lb.idempotencyKey():  "zset",
+		lb.bestRanksKey():    "hash",
 	}
+	if lb.partitioned() {
+		for _, key := range lb.globalKeys() {
+			expected[key] = "zset"
+		}
+	}
+	return expected
+}
 
-	return &Leaderboard{
-		config: cfg,
-		client: client,
-		ctx:    ctx,
-	}, nil
+// conflictErr marks Redis WRONGTYPE errors as ErrNamespaceConflict.
+// Entity keys are created lazily, so collisions there surface on write.
+func conflictErr(err error) error {
+	if err != nil && strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		return fmt.Errorf("%w: %v", ErrNamespaceConflict, err)
+	}
+	return err
 }
 
 // Close properly shuts down Redis connection.
+// A Config.Client is left open for its owner to close.
 // Should be called when leaderboard is no longer needed.
+// Cancels resets pending from ScheduleReset, waiting for one running, and
+// stops DecayInterval, waiting for a decay in progress.
+// Flushes increments buffered with CoalesceInterval first; deltas that
+// still fail are lost and reported in the returned error.
+// Safe to call while other goroutines use the leaderboard: Redis commands
+// already sent complete before the connections close, and every later
+// command fails with ErrClosed, so an operation caught midway returns
+// ErrClosed (possibly having applied its earlier commands).
 func (lb *Leaderboard) Close() error {
-	return lb.client.Close()
+	lb.resets.close()
+	var flushErr error
+	if lb.coalescer != nil {
+		lb.coalescer.close()
+		flushErr = lb.Flush()
+	}
+	lb.health.close()
+	if lb.rankSnapshot != nil {
+		lb.rankSnapshot.close()
+	}
+	if lb.decay != nil {
+		lb.decay.close()
+	}
+	lb.closed.close()
+	if lb.ownsReader {
+		lb.reader.Close()
+	}
+	if !lb.ownsClient {
+		return flushErr
+	}
+	return errors.Join(flushErr, lb.client.Close())
 }
 
 // AddUser creates or updates user score in rankings.
 // Updates both global and entity-specific rankings.
+// Stores user.Metadata when EnableMetadata is set and it is non-empty;
+// existing metadata is kept otherwise. Same for user.Name with EnableNames.
 // Uses atomic operations via Redis pipeline.
+// With an EvictionPolicy, MaxUsers is enforced on the global ranking, and
+// MaxUsersPerEntity on the entity ranking; under EvictionLowest a user
+// scoring below a full board's lowest is not stored.
+// Consistency: the entity mapping is written first, then the rankings,
+// metadata, name and activity. If only some of these commands fail, the
+// user is left half-written and a *PartialWriteError lists the failures;
+// retry the write or run Repair. With RollbackPartialWrites the commands
+// that succeeded are undone instead, from a snapshot read before the
+// write, so the user ends up either fully written or as before. The
+// rollback is not atomic: writes of the user by others between the
+// snapshot and the rollback are overwritten.
 // Returns error if:
 // - user ID is empty
-// - score is negative
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - score is negative and AllowNegativeScores is unset
+// - score exceeds 2^53 with FloatScores=false (ErrScoreOverflow)
+// - score is NaN or infinite (ErrInvalidScore)
+// - entity is invalid (ErrInvalidEntity)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - the leaderboard is full under EvictionReject (ErrLeaderboardFull)
+// - the entity is full and doesn't evict (ErrEntityFull)
+// - a key holds another data type (ErrNamespaceConflict)
+// - only part of the pipeline was applied (ErrPartialWrite, as *PartialWriteError; rolled back with RollbackPartialWrites)
+// - publishing the rank change fails (PublishRankChanges; the write is applied)
+// - recording the best rank fails (TrackBestRank; the write is applied)
 // - Redis operation fails
-func (lb *Leaderboard) AddUser(user User) error {
-	if user.ID == "" || user.Score < 0 {
-		return fmt.Errorf("invalid user ID or score")
+func (lb *Leaderboard) AddUser(user User) (err error) {
+	defer lb.startOp("AddUser", user.ID, user.Entity).end(&err)
+	user.Entity = lb.normalizeEntity(user.Entity)
+	score, meta, err := lb.prepareUser(user)
+	if err != nil {
+		return err
+	}
+	defer lb.topKCache.invalidate()
+
+	before, err := lb.rankBefore(user.ID)
+	if err != nil {
+		return err
 	}
 
-	score := user.Score
-	if !lb.config.FloatScores {
-		score = float64(int(score))
+	// With a cap the ranking writes go through the admit script first;
+	// users evicted on the way in are not stored.
+	capped := lb.capped(user.Entity)
+	if capped {
+		admitted, err := lb.admitUser(user.ID, user.Entity, score)
+		if err != nil || !admitted {
+			return err
+		}
 	}
 
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
-	entityKey := lb.config.Namespace + ":entity:" + user.Entity
+	var state userState
+	if lb.config.RollbackPartialWrites {
+		if state, err = lb.snapshotUser(user.ID, user.Entity); err != nil {
+			return fmt.Errorf("failed to read user for rollback: %w", err)
+		}
+	}
 
 	pipe := lb.client.Pipeline()
-	pipe.ZAdd(lb.ctx, globalKey, redis.Z{Score: score, Member: user.ID})
-	pipe.HSet(lb.ctx, entitiesKey, user.ID, user.Entity)
-	if user.Entity != "" {
-		pipe.ZAdd(lb.ctx, entityKey, redis.Z{Score: score, Member: user.ID})
+	entityPipe := lb.entityPipeline(pipe, user.Entity)
+	if err := lb.queueAddUser(pipe, entityPipe, user, score, meta, !capped); err != nil {
+		return err
 	}
-	_, err := pipe.Exec(lb.ctx)
+	err = lb.execPipelines(pipe, entityPipe)
+	var partial *PartialWriteError
+	if lb.config.RollbackPartialWrites && errors.As(err, &partial) {
+		if rbErr := lb.restoreUser(state, user); rbErr != nil {
+			return fmt.Errorf("failed to add user: %w (rollback failed: %v)", err, rbErr)
+		}
+		return fmt.Errorf("failed to add user (rolled back): %w", partial.Err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add user: %w", conflictErr(err))
+	}
+	return lb.afterWrite(user.ID, before)
+}
+
+// prepareUser validates a user for AddUser, admits its entity and returns
+// the score to store and the encoded metadata (nil: none to store).
+func (lb *Leaderboard) prepareUser(user User) (float64, []byte, error) {
+	if err := finiteScore(user.Score); err != nil {
+		return 0, nil, err
+	}
+	if user.ID == "" || !lb.validScore(user.Score) {
+		return 0, nil, fmt.Errorf("invalid user ID or score")
+	}
+	if err := lb.validateUserID(user.ID); err != nil {
+		return 0, nil, err
+	}
+	if err := lb.validateEntity(user.Entity); err != nil {
+		return 0, nil, err
+	}
+	score, err := lb.normalizeScore(user.Score)
 	if err != nil {
-		return fmt.Errorf("failed to add user: %w", err)
+		return 0, nil, err
+	}
+	if err := lb.admitEntity(user.Entity); err != nil {
+		return 0, nil, err
+	}
+
+	var meta []byte
+	if lb.config.EnableMetadata && len(user.Metadata) > 0 {
+		meta, err = json.Marshal(user.Metadata)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+	}
+	return score, meta, nil
+}
+
+// queueAddUser queues AddUser's writes of a validated user: the entity
+// mapping, metadata, name and activity on pipe, and, if rank is set, the
+// global and entity rankings (the latter on entityPipe).
+func (lb *Leaderboard) queueAddUser(pipe, entityPipe redis.Pipeliner, user User, score float64, meta []byte, rank bool) error {
+	lb.setEntity(pipe, user.ID, user.Entity)
+	if rank {
+		pipe.ZAdd(lb.ctx, lb.userGlobalKey(user.ID), redis.Z{Score: score, Member: lb.memberFor(user.ID, user.Entity)})
+		if user.Entity != "" {
+			lb.entityWrite(entityPipe, entitySet, user.Entity, user.ID, score)
+		}
+	}
+	if meta != nil {
+		pipe.HSet(lb.ctx, lb.metaKey(), user.ID, meta)
 	}
+	if lb.config.EnableNames && user.Name != "" {
+		pipe.HSet(lb.ctx, lb.namesKey(), user.ID, user.Name)
+	}
+	lb.touch(pipe, user.ID)
 	return nil
 }
 
 // IncrementScore adds to user's current score.
 // Updates both global and entity rankings atomically.
+// A zero increment is a no-op and returns nil without touching Redis.
+// entity becomes the user's entity mapping, but only its ranking is
+// incremented: passing another entity than the user's leaves them in their
+// old entity ranking with a stale score. StrictEntity rejects such calls
+// (one extra lookup); move users with UpdateEntityByUserID.
+// With CoalesceInterval the increment is validated and buffered, and only
+// reaches Redis on the next Flush.
 // Returns error if:
 // - user ID is empty
-// - increment is zero
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - increment exceeds 2^53 with FloatScores=false (ErrScoreOverflow)
+// - increment is NaN or infinite (ErrInvalidScore)
+// - user doesn't exist with RequireExistingUser (ErrUserNotFound)
+// - entity is invalid (ErrInvalidEntity)
+// - the user has another entity with StrictEntity (ErrEntityMismatch)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - a key holds another data type (ErrNamespaceConflict)
+// - only part of the pipeline was applied (ErrPartialWrite, as *PartialWriteError)
+// - publishing the rank change fails (PublishRankChanges; the write is applied)
+// - recording the best rank fails (TrackBestRank; the write is applied)
 // - Redis operation fails
-func (lb *Leaderboard) IncrementScore(userID, entity string, scoreIncrement float64) error {
-	if userID == "" || scoreIncrement == 0 {
+func (lb *Leaderboard) IncrementScore(userID, entity string, scoreIncrement float64) (err error) {
+	defer lb.startOp("IncrementScore", userID, entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if userID == "" {
 		return fmt.Errorf("invalid user ID or score increment")
 	}
+	if err := lb.validateUserID(userID); err != nil {
+		return err
+	}
+	if scoreIncrement == 0 {
+		return nil
+	}
+	if err := lb.validateEntity(entity); err != nil {
+		return err
+	}
+	scoreIncrement, err = lb.normalizeScore(scoreIncrement)
+	if err != nil {
+		return err
+	}
+	if err := lb.checkEntity(userID, entity); err != nil {
+		return err
+	}
+	if err := lb.admitEntity(entity); err != nil {
+		return err
+	}
+	if lb.coalescer != nil {
+		lb.coalescer.add(userID, entity, scoreIncrement)
+		return nil
+	}
+	defer lb.topKCache.invalidate()
+
+	before, err := lb.rankBefore(userID)
+	if err != nil {
+		return err
+	}
 
-	if !lb.config.FloatScores {
-		scoreIncrement = float64(int(scoreIncrement))
+	if lb.config.RequireExistingUser {
+		if err := lb.incrementExisting(userID, entity, scoreIncrement); err != nil {
+			return fmt.Errorf("failed to increment score: %w", err)
+		}
+		return lb.afterWrite(userID, before)
 	}
 
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
-	entityKey := lb.config.Namespace + ":entity:" + entity
+	globalKey := lb.userGlobalKey(userID)
 
 	pipe := lb.client.Pipeline()
-	pipe.ZIncrBy(lb.ctx, globalKey, scoreIncrement, userID)
-	pipe.HSet(lb.ctx, entitiesKey, userID, entity) // Always update
+	entityPipe := lb.entityPipeline(pipe, entity)
+	lb.setEntity(pipe, userID, entity) // Always update
+	pipe.ZIncrBy(lb.ctx, globalKey, scoreIncrement, lb.memberFor(userID, entity))
 	if entity != "" {
-		pipe.ZIncrBy(lb.ctx, entityKey, scoreIncrement, userID)
+		lb.entityWrite(entityPipe, entityIncr, entity, userID, scoreIncrement)
 	}
-	_, err := pipe.Exec(lb.ctx)
+	lb.touch(pipe, userID)
+	err = lb.execPipelines(pipe, entityPipe)
 	if err != nil {
-		return fmt.Errorf("failed to increment score: %w", err)
+		return fmt.Errorf("failed to increment score: %w", conflictErr(err))
 	}
-	return nil
+	return lb.afterWrite(userID, before)
+}
+
+// IncrementScoreWeighted adds base*weight to user's current score, e.g.
+// for double-XP weekends (weight 2) without every caller multiplying.
+// The product is rounded per FloatScores and written like IncrementScore.
+// Returns error if:
+// - weight or the product is NaN or infinite (ErrInvalidScore)
+// - any IncrementScore error
+func (lb *Leaderboard) IncrementScoreWeighted(userID, entity string, base, weight float64) (err error) {
+	defer lb.startOp("IncrementScoreWeighted", userID, entity).end(&err)
+	if err := finiteScore(weight); err != nil {
+		return fmt.Errorf("invalid weight: %w", err)
+	}
+	delta := base * weight
+	if err := finiteScore(delta); err != nil {
+		return fmt.Errorf("invalid weighted score %v * %v: %w", base, weight, err)
+	}
+	return lb.IncrementScore(userID, entity, delta)
 }
 
 // DecrementScore subtracts from user's current score.
 // Updates both global and entity rankings atomically.
+// A zero decrement is a no-op and returns nil without touching Redis.
+// Buffered like IncrementScore with CoalesceInterval.
 // Returns error if:
 // - user ID is empty
-// - decrement is zero
+// - user ID is too long or contains control characters (ErrInvalidUserID)
+// - decrement exceeds 2^53 with FloatScores=false (ErrScoreOverflow)
+// - decrement is NaN or infinite (ErrInvalidScore)
+// - user doesn't exist with RequireExistingUser (ErrUserNotFound)
+// - entity is invalid (ErrInvalidEntity)
+// - the user has another entity with StrictEntity (ErrEntityMismatch)
+// - entity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - a key holds another data type (ErrNamespaceConflict)
+// - only part of the pipeline was applied (ErrPartialWrite, as *PartialWriteError)
+// - publishing the rank change fails (PublishRankChanges; the write is applied)
+// - recording the best rank fails (TrackBestRank; the write is applied)
 // - Redis operation fails
-func (lb *Leaderboard) DecrementScore(userID, entity string, scoreDecrement float64) error {
-	if userID == "" || scoreDecrement == 0 {
-			return fmt.Errorf("invalid user ID or score decrement")
+func (lb *Leaderboard) DecrementScore(userID, entity string, scoreDecrement float64) (err error) {
+	defer lb.startOp("DecrementScore", userID, entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if userID == "" {
+		return fmt.Errorf("invalid user ID or score decrement")
+	}
+	if err := lb.validateUserID(userID); err != nil {
+		return err
+	}
+	if scoreDecrement == 0 {
+		return nil
+	}
+	if err := lb.validateEntity(entity); err != nil {
+		return err
+	}
+	scoreDecrement, err = lb.normalizeScore(scoreDecrement)
+	if err != nil {
+		return err
+	}
+	if err := lb.checkEntity(userID, entity); err != nil {
+		return err
+	}
+	if err := lb.admitEntity(entity); err != nil {
+		return err
+	}
+	if lb.coalescer != nil {
+		lb.coalescer.add(userID, entity, -scoreDecrement)
+		return nil
+	}
+	defer lb.topKCache.invalidate()
+
+	before, err := lb.rankBefore(userID)
+	if err != nil {
+		return err
 	}
 
-	if !lb.config.FloatScores {
-			scoreDecrement = float64(int(scoreDecrement))
+	if lb.config.RequireExistingUser {
+		if err := lb.incrementExisting(userID, entity, -scoreDecrement); err != nil {
+			return fmt.Errorf("failed to decrement score: %w", err)
+		}
+		return lb.afterWrite(userID, before)
 	}
 
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
-	entityKey := lb.config.Namespace + ":entity:" + entity
+	globalKey := lb.userGlobalKey(userID)
 
 	pipe := lb.client.Pipeline()
-	pipe.ZIncrBy(lb.ctx, globalKey, -scoreDecrement, userID) // Use negative value for decrement
-	pipe.HSet(lb.ctx, entitiesKey, userID, entity) // Always update
+	entityPipe := lb.entityPipeline(pipe, entity)
+	lb.setEntity(pipe, userID, entity)                                             // Always update
+	pipe.ZIncrBy(lb.ctx, globalKey, -scoreDecrement, lb.memberFor(userID, entity)) // Use negative value for decrement
 	if entity != "" {
-			pipe.ZIncrBy(lb.ctx, entityKey, -scoreDecrement, userID)
+		lb.entityWrite(entityPipe, entityIncr, entity, userID, -scoreDecrement)
 	}
-	_, err := pipe.Exec(lb.ctx)
+	lb.touch(pipe, userID)
+	err = lb.execPipelines(pipe, entityPipe)
 	if err != nil {
-			return fmt.Errorf("failed to decrement score: %w", err)
+		return fmt.Errorf("failed to decrement score: %w", conflictErr(err))
 	}
-	return nil
+	return lb.afterWrite(userID, before)
+}
+
+// incrementExistingScript adds a delta to a ranked user's global and entity
+// scores and points their mapping (and member, in EntityInMember mode) at
+// the entity, checking membership in the same script so a concurrent
+// RemoveUser can't be undone. Returns 0 without writing if the user isn't
+// ranked globally.
+// KEYS[1]: global ranking, KEYS[2]: entity mapping, KEYS[3]: entity ranking
+// (optional), KEYS[4]: entity totals (EntityTotals only); ARGV: user ID,
+// entity, delta, "1" with EntityInMember.
+const incrementExistingScript = `
+local member, to = ARGV[1], ARGV[1]
+if ARGV[4] == '1' then
+	member = ARGV[1] .. '` + memberSeparator + `' .. (redis.call('HGET', KEYS[2], ARGV[1]) or '')
+	to = ARGV[1] .. '` + memberSeparator + `' .. ARGV[2]
+end
+local score = redis.call('ZSCORE', KEYS[1], member)
+if not score then
+	return 0
+end
+if member ~= to then
+	redis.call('ZREM', KEYS[1], member)
+	redis.call('ZADD', KEYS[1], score, to)
+end
+redis.call('ZINCRBY', KEYS[1], ARGV[3], to)
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+if KEYS[3] then
+	redis.call('ZINCRBY', KEYS[3], ARGV[3], ARGV[1])
+	if KEYS[4] then
+		redis.call('ZINCRBY', KEYS[4], ARGV[3], ARGV[2])
+	end
+end
+return 1
+`
+
+// incrementExisting applies delta like IncrementScore, but only to a user
+// already ranked globally.
+// With Config.Sharder the entity ranking is incremented after the script,
+// on its shard.
+func (lb *Leaderboard) incrementExisting(userID, entity string, delta float64) error {
+	keys := []string{lb.globalKey(), lb.entitiesKey()}
+	sharded := entity != "" && lb.entityClient(entity) != lb.client
+	if entity != "" && !sharded {
+		keys = append(keys, lb.entityKey(entity))
+		if lb.config.EntityTotals {
+			keys = append(keys, lb.entityTotalsKey())
+		}
+	}
+	inMember := "0"
+	if lb.config.EntityInMember {
+		inMember = "1"
+	}
+
+	res, err := lb.evalScript(incrementExistingScript, keys, userID, entity, delta, inMember)
+	if err != nil {
+		return conflictErr(err)
+	}
+	if res != int64(1) {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	pipe := lb.client.Pipeline()
+	entityPipe := lb.entityPipeline(pipe, entity)
+	if sharded {
+		entityPipe.ZIncrBy(lb.ctx, lb.entityKey(entity), delta, userID)
+	}
+	lb.touch(pipe, userID)
+	return lb.execPipelines(pipe, entityPipe)
 }
 
 // RemoveUser deletes user from all rankings.
-// Removes from global ranking and entity ranking.
-// Cleans up entity mapping.
+// Removes from global ranking, entity ranking and every metric board.
+// Cleans up entity mapping and metadata.
+// Runs as an optimistic WATCH/MULTI transaction over the entity mapping,
+// retried on conflict, so an entity change racing the removal (e.g.
+// UpdateEntityByUserID) can't leave the user in an entity ranking the
+// removal never read. With a Sharder the entity ranking lives on another
+// backend and the removal is two plain pipelines instead.
 // Returns error if:
 // - user ID is empty
+// - concurrent writes keep conflicting (ErrConflict)
+// - only part of the pipeline was applied (ErrPartialWrite, as *PartialWriteError; Sharder only)
 // - Redis operation fails
-func (lb *Leaderboard) RemoveUser(userID string) error {
+func (lb *Leaderboard) RemoveUser(userID string) (err error) {
+	defer lb.startOp("RemoveUser", userID, "").end(&err)
 	if userID == "" {
 		return fmt.Errorf("invalid user ID")
 	}
+	defer lb.topKCache.invalidate()
 
-	entitiesKey := lb.config.Namespace + ":user:entities"
-	globalKey := lb.config.Namespace + ":global"
+	if lb.config.Sharder != nil {
+		return lb.removeUserSharded(userID)
+	}
 
-	entity, err := lb.client.HGet(lb.ctx, entitiesKey, userID).Result()
-	if err != nil && err != redis.Nil {
-		return fmt.Errorf("failed to get user entity: %w", err)
+	entitiesKey := lb.entitiesKey()
+	metricsKey := lb.metricsKey()
+	remove := func(tx *redis.Tx) error {
+		entity, err := tx.HGet(lb.ctx, entitiesKey, userID).Result()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to get user entity: %w", err)
+		}
+		metrics, err := tx.SMembers(lb.ctx, metricsKey).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+
+		_, err = tx.TxPipelined(lb.ctx, func(pipe redis.Pipeliner) error {
+			lb.queueRemove(pipe, pipe, userID, entity, metrics)
+			return nil
+		})
+		if err != nil && err != redis.TxFailedErr {
+			return fmt.Errorf("failed to remove user: %w", err)
+		}
+		return err
 	}
 
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		err := lb.client.Watch(lb.ctx, remove, entitiesKey, metricsKey)
+		if err == redis.TxFailedErr {
+			continue // entity mapping or metrics changed, retry with fresh data
+		}
+		return err
+	}
+	return fmt.Errorf("failed to remove user: %w after %d attempts", ErrConflict, maxTxRetries)
+}
+
+// removeUserSharded is RemoveUser with entity rankings on other backends:
+// the entity is read first, then the rankings are written in two
+// pipelines. Entity moves are unsupported with a Sharder, but a concurrent
+// AddUser changing the user's entity can still leave them in the new
+// entity ranking.
+func (lb *Leaderboard) removeUserSharded(userID string) error {
 	pipe := lb.client.Pipeline()
-	pipe.ZRem(lb.ctx, globalKey, userID)
-	pipe.HDel(lb.ctx, entitiesKey, userID)
-	if entity != "" {
-		entityKey := lb.config.Namespace + ":entity:" + entity
-		pipe.ZRem(lb.ctx, entityKey, userID)
+	entityCmd := pipe.HGet(lb.ctx, lb.entitiesKey(), userID)
+	metricsCmd := pipe.SMembers(lb.ctx, lb.metricsKey())
+	_, err := pipe.Exec(lb.ctx)
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to get user entity: %w", err)
 	}
-	_, err = pipe.Exec(lb.ctx)
+	entity := entityCmd.Val()
+
+	pipe = lb.client.Pipeline()
+	entityPipe := lb.entityPipeline(pipe, entity)
+	lb.queueRemove(pipe, entityPipe, userID, entity, metricsCmd.Val())
+	err = lb.execPipelines(pipe, entityPipe)
 	if err != nil {
 		return fmt.Errorf("failed to remove user: %w", err)
 	}
 	return nil
 }
 
+// queueRemove queues the removal of a user in entity from every ranking
+// and hash on pipe, and from the entity rankings on entityPipe (see
+// entityPipeline), returning the queued commands.
+func (lb *Leaderboard) queueRemove(pipe, entityPipe redis.Pipeliner, userID, entity string, metrics []string) []redis.Cmder {
+	cmds := []redis.Cmder{
+		pipe.ZRem(lb.ctx, lb.userGlobalKey(userID), lb.memberFor(userID, entity)),
+		pipe.HDel(lb.ctx, lb.entitiesKey(), userID),
+	}
+	if entity != "" {
+		cmds = append(cmds, lb.entityWrite(entityPipe, entityRem, entity, userID, 0))
+	}
+	for _, metric := range metrics {
+		cmds = append(cmds, pipe.ZRem(lb.ctx, lb.metricGlobalKey(metric), userID))
+		if entity != "" {
+			cmds = append(cmds, entityPipe.ZRem(lb.ctx, lb.metricEntityKey(metric, entity), userID))
+		}
+	}
+	if lb.config.EnableMetadata {
+		cmds = append(cmds, pipe.HDel(lb.ctx, lb.metaKey(), userID))
+	}
+	if lb.config.EnableNames {
+		cmds = append(cmds, pipe.HDel(lb.ctx, lb.namesKey(), userID))
+	}
+	if lb.config.TrackActivity {
+		cmds = append(cmds, pipe.ZRem(lb.ctx, lb.activityKey(), userID))
+	}
+	if lb.config.TrackBestRank {
+		cmds = append(cmds, pipe.HDel(lb.ctx, lb.bestRanksKey(), userID))
+	}
+	return cmds
+}
+
 // UpdateEntityByUserID changes a user's entity, updating rankings atomically.
 // Removes the user from the old entity's sorted set, adds to the new entity's
 // sorted set with the same score, and updates the entity mapping.
+// The stored score moves verbatim: FloatScores=false rounds submitted
+// scores only, so a move never changes a score.
+// Runs as an optimistic WATCH/MULTI transaction, retried on conflict.
+// MaxUsersPerEntity is checked in the same transaction; under EvictionLowest
+// the new entity's lowest users are evicted to make room.
 // Returns error if:
 // - userID is empty
-// - user doesn't exist
+// - user doesn't exist (ErrUserNotFound)
 // - newEntity is empty
+// - newEntity is invalid (ErrInvalidEntity)
+// - newEntity is new and MaxEntities is reached under EntityLimitReject (ErrTooManyEntities)
+// - newEntity is full and doesn't evict (ErrEntityFull)
+// - concurrent writes keep conflicting (ErrConflict)
 // - Redis operation fails
-func (lb *Leaderboard) UpdateEntityByUserID(userID, newEntity string) error {
+func (lb *Leaderboard) UpdateEntityByUserID(userID, newEntity string) (err error) {
+	defer lb.startOp("UpdateEntityByUserID", userID, newEntity).end(&err)
+	newEntity = lb.normalizeEntity(newEntity)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
 	if userID == "" {
 		return fmt.Errorf("invalid user ID")
 	}
 	if newEntity == "" {
 		return fmt.Errorf("invalid new entity")
 	}
-
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
-
-	// Check if user exists and get current entity
-	pipe := lb.client.Pipeline()
-	scoreCmd := pipe.ZScore(lb.ctx, globalKey, userID)
-	entityCmd := pipe.HGet(lb.ctx, entitiesKey, userID)
-	_, err := pipe.Exec(lb.ctx)
-	if err != nil && err != redis.Nil {
-		return fmt.Errorf("failed to fetch user data: %w", err)
-	}
-	if scoreCmd.Err() == redis.Nil {
-		return fmt.Errorf("user %s not found", userID)
+	if err := lb.validateEntity(newEntity); err != nil {
+		return err
 	}
-	if scoreCmd.Err() != nil {
-		return fmt.Errorf("failed to get user score: %w", scoreCmd.Err())
+	if err := lb.admitEntity(newEntity); err != nil {
+		return err
 	}
-	oldEntity := entityCmd.Val()
-	score := scoreCmd.Val()
-
-	// Round score if FloatScores=false
-	if !lb.config.FloatScores {
-		score = float64(int(score))
+	defer lb.topKCache.invalidate()
+
+	globalKey := lb.userGlobalKey(userID)
+	entitiesKey := lb.entitiesKey()
+	newEntityKey := lb.entityKey(newEntity)
+
+	// WATCH the global ranking and entity mapping so a concurrent score or
+	// entity change between the read and the write aborts the transaction
+	// instead of re-adding a stale score to the new entity.
+	var evicted []string
+	move := func(tx *redis.Tx) error {
+		// Check if user exists and get current entity
+		oldEntity, err := tx.HGet(lb.ctx, entitiesKey, userID).Result()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to fetch user data: %w", err)
+		}
+		oldMember := lb.memberFor(userID, oldEntity)
+		score, err := tx.ZScore(lb.ctx, globalKey, oldMember).Result()
+		if err == redis.Nil {
+			return fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user score: %w", err)
+		}
+
+		evicted, err = lb.entityRoom(tx, newEntityKey, userID, score)
+		if err != nil {
+			return err
+		}
+
+		// Update entity and rankings
+		_, err = tx.TxPipelined(lb.ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(lb.ctx, entitiesKey, userID, newEntity)
+			if newMember := lb.memberFor(userID, newEntity); newMember != oldMember {
+				pipe.ZRem(lb.ctx, globalKey, oldMember)
+				pipe.ZAdd(lb.ctx, globalKey, redis.Z{Score: score, Member: newMember})
+			}
+			for _, id := range evicted {
+				pipe.ZRem(lb.ctx, newEntityKey, id)
+			}
+			lb.entityWrite(pipe, entitySet, newEntity, userID, score)
+			if oldEntity != "" && oldEntity != newEntity {
+				lb.entityWrite(pipe, entityRem, oldEntity, userID, 0)
+			}
+			return nil
+		})
+		if err != nil && err != redis.TxFailedErr {
+			return fmt.Errorf("failed to update entity: %w", err)
+		}
+		return err
 	}
 
-	// Update entity and rankings
-	newEntityKey := lb.config.Namespace + ":entity:" + newEntity
-	pipe = lb.client.Pipeline()
-	pipe.HSet(lb.ctx, entitiesKey, userID, newEntity)
-	pipe.ZAdd(lb.ctx, newEntityKey, redis.Z{Score: score, Member: userID})
-	if oldEntity != "" && oldEntity != newEntity {
-		oldEntityKey := lb.config.Namespace + ":entity:" + oldEntity
-		pipe.ZRem(lb.ctx, oldEntityKey, userID)
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		err := lb.client.Watch(lb.ctx, move, globalKey, entitiesKey, newEntityKey)
+		if err == redis.TxFailedErr {
+			continue // watched key changed, retry with fresh data
+		}
+		if err != nil {
+			return err
+		}
+		// users evicted from the new entity leave the leaderboard entirely
+		for _, id := range evicted {
+			if err := lb.RemoveUser(id); err != nil {
+				return fmt.Errorf("failed to clean up evicted user %s: %w", id, err)
+			}
+		}
+		return nil
 	}
-	_, err = pipe.Exec(lb.ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update entity: %w", err)
+	return fmt.Errorf("failed to update entity: %w after %d attempts", ErrConflict, maxTxRetries)
+}
+
+// validateEntity checks entity length and characters against config.
+// Empty entity is valid (user has no entity).
+func (lb *Leaderboard) validateEntity(entity string) error {
+	if len(entity) > lb.config.EntityMaxLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidEntity, lb.config.EntityMaxLength)
+	}
+	for _, r := range entity {
+		if !strings.ContainsRune(lb.config.EntityCharset, r) {
+			return fmt.Errorf("%w: disallowed character %q", ErrInvalidEntity, r)
+		}
 	}
 	return nil
 }
 
+// validateUserID rejects user IDs longer than Config.MaxUserIDLength or
+// containing control characters with ErrInvalidUserID. Empty IDs are
+// rejected by the callers, with their own messages.
+func (lb *Leaderboard) validateUserID(userID string) error {
+	if len(userID) > lb.config.MaxUserIDLength {
+		return fmt.Errorf("%w: longer than %d bytes", ErrInvalidUserID, lb.config.MaxUserIDLength)
+	}
+	for _, r := range userID {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: control character %q", ErrInvalidUserID, r)
+		}
+	}
+	return nil
+}
+
+// normalizeEntity lowercases entity with EntityCaseInsensitive, so "US",
+// "us" and "Us" share one ranking. Exported methods normalize the entities
+// they are given before validating them or building keys.
+func (lb *Leaderboard) normalizeEntity(entity string) string {
+	if !lb.config.EntityCaseInsensitive {
+		return entity
+	}
+	return strings.ToLower(entity)
+}
+
+// normalizeEntities returns entities normalized, as a copy.
+func (lb *Leaderboard) normalizeEntities(entities []string) []string {
+	if !lb.config.EntityCaseInsensitive {
+		return entities
+	}
+	normalized := make([]string, len(entities))
+	for i, entity := range entities {
+		normalized[i] = lb.normalizeEntity(entity)
+	}
+	return normalized
+}
+
+// validScore reports whether an absolute score is accepted: scores must
+// be non-negative unless Config.AllowNegativeScores is set.
+func (lb *Leaderboard) validScore(score float64) bool {
+	return score >= 0 || lb.config.AllowNegativeScores
+}
+
+// finiteScore rejects NaN and infinite scores with ErrInvalidScore.
+func finiteScore(score float64) error {
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidScore, score)
+	}
+	return nil
+}
+
+// normalizeScore applies the FloatScores rounding to a score or delta.
+// NaN and infinite values are rejected with ErrInvalidScore.
+// With FloatScores=false, values whose integer part exceeds maxExactScore
+// are rejected with ErrScoreOverflow rather than stored with lost precision.
+// Float scores are returned untouched: they are approximate by nature.
+func (lb *Leaderboard) normalizeScore(score float64) (float64, error) {
+	if err := finiteScore(score); err != nil {
+		return 0, err
+	}
+	if lb.config.FloatScores {
+		return score, nil
+	}
+	if math.Abs(score) > maxExactScore {
+		return 0, fmt.Errorf("%w: %v", ErrScoreOverflow, score)
+	}
+	return float64(int64(score)), nil
+}
+
+// RemoveEntity deletes a whole entity ranking.
+// Removes every member from the entity's sorted set in pipelines of
+// Config.BatchSize members, then deletes the entity key.
+// If alsoGlobal is true, members are removed everywhere as by RemoveUser:
+// from the global ranking, every metric board, the entity mapping and
+// metadata; otherwise they stay ranked globally with their entity mapping
+// cleared.
+// Returns error if:
+// - entity is empty
+// - Redis operation fails
+func (lb *Leaderboard) RemoveEntity(entity string, alsoGlobal bool) (err error) {
+	defer lb.startOp("RemoveEntity", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if entity == "" {
+		return fmt.Errorf("invalid entity")
+	}
+	defer lb.topKCache.invalidate()
+
+	entityKey := lb.entityKey(entity)
+	var metrics []string
+	if alsoGlobal {
+		if metrics, err = lb.client.SMembers(lb.ctx, lb.metricsKey()).Result(); err != nil {
+			return fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+	}
+
+	for {
+		members, err := lb.client.ZRange(lb.ctx, entityKey, 0, int64(lb.config.BatchSize)-1).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch entity %s members: %w", entity, err)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		pipe := lb.client.Pipeline()
+		for _, userID := range members {
+			if alsoGlobal {
+				lb.queueRemove(pipe, pipe, userID, entity, metrics)
+			} else {
+				lb.entityWrite(pipe, entityRem, entity, userID, 0)
+				lb.setEntity(pipe, userID, "")
+			}
+		}
+		if _, err := pipe.Exec(lb.ctx); err != nil {
+			return fmt.Errorf("failed to remove entity %s members: %w", entity, err)
+		}
+	}
+
+	if err := lb.client.Del(lb.ctx, entityKey).Err(); err != nil {
+		return fmt.Errorf("failed to delete entity %s: %w", entity, err)
+	}
+	return lb.replaceEntityCodes([]string{entity}, "")
+}
+
 // GetUserLeaderboardData fetches complete ranking data.
 // Includes:
 // - current score
 // - global and entity ranks
 // - top k users globally
 // - top k users in same entity
+// - user metadata (EnableMetadata only)
+// Entity rank and top-k use PrimaryEntity if the user is ranked in it, and
+// the user's entity mapping otherwise, as GetRankEntity; Entity is always
+// the mapped entity.
+// Unknown users get Exists=false, -1 ranks and zero score.
+// With TopKCacheTTL both top-k lists come from the top-k cache shared with
+// GetTopKGlobal/GetTopKEntity, so only the user's own rows are fetched.
 // Returns error if Redis operations fail.
-func (lb *Leaderboard) GetUserLeaderboardData(userID string) (LeaderboardData, error) {
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
+func (lb *Leaderboard) GetUserLeaderboardData(userID string) (_ LeaderboardData, err error) {
+	defer lb.startOp("GetUserLeaderboardData", userID, "").end(&err)
+	return lb.userLeaderboardData(userID, "", true)
+}
+
+// GetUserLeaderboardDataForEntity is GetUserLeaderboardData with EntityRank
+// and TopKEntity computed against entity instead of the user's own entity,
+// e.g. for "where do I stand in this region" views. Entity still reports
+// the user's stored entity; EntityRank is -1 if the user isn't ranked there.
+// Returns error if:
+// - entity is empty or invalid (ErrInvalidEntity)
+// - Redis operations fail
+func (lb *Leaderboard) GetUserLeaderboardDataForEntity(userID, entity string) (_ LeaderboardData, err error) {
+	defer lb.startOp("GetUserLeaderboardDataForEntity", userID, entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if entity == "" {
+		return LeaderboardData{}, fmt.Errorf("invalid entity")
+	}
+	if err := lb.validateEntity(entity); err != nil {
+		return LeaderboardData{}, err
+	}
+	return lb.userLeaderboardData(userID, entity, false)
+}
+
+// GetUserLeaderboardDataWithEntities is GetUserLeaderboardData plus the
+// top k of other entities (e.g., rival guilds) in TopKEntities, keyed by
+// entity, for "compare me to these groups" screens. The extra top-k lists
+// are fetched together like GetTopKEntities and share its cache; entities
+// without users map to an empty list. Without entities TopKEntities is nil.
+// Returns error if:
+// - an entity is empty or invalid (ErrInvalidEntity)
+// - entities are sharded (ErrShardingUnsupported)
+// - Redis operations fail
+func (lb *Leaderboard) GetUserLeaderboardDataWithEntities(userID string, entities []string) (_ LeaderboardData, err error) {
+	defer lb.startOp("GetUserLeaderboardDataWithEntities", userID, "").end(&err)
+	for _, entity := range entities {
+		if err := lb.validateEntity(lb.normalizeEntity(entity)); err != nil {
+			return LeaderboardData{}, err
+		}
+	}
+	data, err := lb.userLeaderboardData(userID, "", true)
+	if err != nil || len(entities) == 0 {
+		return data, err
+	}
+	data.TopKEntities, err = lb.GetTopKEntities(entities)
+	if err != nil {
+		return LeaderboardData{}, err
+	}
+	return data, nil
+}
+
+// userLeaderboardData backs GetUserLeaderboardData*. The entity section is
+// computed for the user's stored entity if useStored, else for entity.
+func (lb *Leaderboard) userLeaderboardData(userID, entity string, useStored bool) (LeaderboardData, error) {
+	globalKey := lb.userGlobalKey(userID)
+	entitiesKey := lb.entitiesKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return LeaderboardData{}, err
+	}
 
 	// Pipeline all Redis queries
-	pipe := lb.client.Pipeline()
-	globalRankCmd := pipe.ZRevRank(lb.ctx, globalKey, userID)
+	topKGlobal, globalCached := lb.topKCache.get("")
+	pipe := lb.reader.Pipeline()
+	globalRankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
 	entityCmd := pipe.HGet(lb.ctx, entitiesKey, userID)
-	scoreCmd := pipe.ZScore(lb.ctx, globalKey, userID)
-	topKGlobalCmd := pipe.ZRevRangeWithScores(lb.ctx, globalKey, 0, int64(lb.config.K-1))
+	scoreCmd := pipe.ZScore(lb.ctx, globalKey, member)
+	var topKGlobalCmd *redis.ZSliceCmd
+	if !globalCached && !lb.partitioned() {
+		topKGlobalCmd = pipe.ZRevRangeWithScores(lb.ctx, globalKey, 0, int64(lb.config.K-1))
+	}
+	var metaCmd *redis.StringCmd
+	if lb.config.EnableMetadata {
+		metaCmd = pipe.HGet(lb.ctx, lb.metaKey(), userID)
+	}
+	var nameCmd *redis.StringCmd
+	if lb.config.EnableNames {
+		nameCmd = pipe.HGet(lb.ctx, lb.namesKey(), userID)
+	}
+	var primaryCmd *redis.IntCmd
+	if useStored {
+		primaryCmd = lb.primaryRank(pipe, userID)
+	}
 	var entityRankCmd *redis.IntCmd
 	var topKEntityCmd *redis.ZSliceCmd
-	_, err := pipe.Exec(lb.ctx)
+	_, err = pipe.Exec(lb.ctx)
 	if err != nil && err != redis.Nil {
 		return LeaderboardData{}, fmt.Errorf("failed to fetch leaderboard data: %w", err)
 	}
@@ -336,37 +1448,63 @@ func (lb *Leaderboard) GetUserLeaderboardData(userID string) (LeaderboardData, e
 		return LeaderboardData{}, fmt.Errorf("failed to get score: %w", scoreCmd.Err())
 	} else {
 		data.Score = scoreCmd.Val()
+		data.Exists = true
 	}
-
-	// Top-k global
-	if topKGlobalCmd.Err() != nil {
-		return LeaderboardData{}, fmt.Errorf("failed to fetch top-k global: %w", topKGlobalCmd.Err())
+	if lb.partitioned() && data.Exists {
+		rank, err := lb.partitionRank(lb.reader, userID, data.Score, int64(data.GlobalRank))
+		if err != nil {
+			return LeaderboardData{}, err
+		}
+		data.GlobalRank = int(rank)
 	}
-	pipe = lb.client.Pipeline()
-	entityCmds := make(map[string]*redis.StringCmd)
-	for _, m := range topKGlobalCmd.Val() {
-		userID := m.Member.(string)
-		entityCmds[userID] = pipe.HGet(lb.ctx, entitiesKey, userID)
+	if metaCmd != nil {
+		data.Metadata, err = decodeMetadata(metaCmd.Val())
+		if err != nil {
+			return LeaderboardData{}, err
+		}
 	}
-	_, err = pipe.Exec(lb.ctx)
-	if err != nil && err != redis.Nil {
-		return LeaderboardData{}, fmt.Errorf("failed to fetch top-k entities: %w", err)
+	if nameCmd != nil {
+		data.Name = nameCmd.Val()
 	}
-	for _, m := range topKGlobalCmd.Val() {
-		userID := m.Member.(string)
-		data.TopKGlobal = append(data.TopKGlobal, User{
-			ID:     userID,
-			Entity: entityCmds[userID].Val(),
-			Score:  m.Score,
-		})
+
+	// Top-k global
+	if globalCached {
+		data.TopKGlobal = topKGlobal
+	} else {
+		var members []redis.Z
+		if topKGlobalCmd != nil {
+			members, err = topKGlobalCmd.Result()
+		} else {
+			members, err = lb.globalTopK(lb.reader)
+		}
+		if err != nil {
+			return LeaderboardData{}, fmt.Errorf("failed to fetch top-k global: %w", err)
+		}
+		if len(members) > 0 {
+			data.TopKGlobal, err = lb.enrichGlobalUsers(members)
+			if err != nil {
+				return LeaderboardData{}, fmt.Errorf("failed to fetch top-k entities: %w", err)
+			}
+			lb.sortTies(data.TopKGlobal)
+			lb.topKCache.set("", data.TopKGlobal)
+		}
 	}
 
 	// Entity data if applicable
-	if data.Entity != "" {
-		entityKey := lb.config.Namespace + ":entity:" + data.Entity
-		pipe = lb.client.Pipeline()
+	if useStored {
+		entity = data.Entity
+		if primaryCmd != nil && primaryCmd.Err() == nil {
+			entity = lb.config.PrimaryEntity
+		}
+	}
+	if entity != "" {
+		entityKey := lb.entityKey(entity)
+		topKEntity, entityCached := lb.topKCache.get("entity:" + entity)
+		pipe = lb.entityReader(entity).Pipeline()
 		entityRankCmd = pipe.ZRevRank(lb.ctx, entityKey, userID)
-		topKEntityCmd = pipe.ZRevRangeWithScores(lb.ctx, entityKey, 0, int64(lb.config.K-1))
+		if !entityCached {
+			topKEntityCmd = pipe.ZRevRangeWithScores(lb.ctx, entityKey, 0, int64(lb.config.K-1))
+		}
 		_, err = pipe.Exec(lb.ctx)
 		if err != nil && err != redis.Nil {
 			return LeaderboardData{}, fmt.Errorf("failed to fetch entity data: %w", err)
@@ -380,32 +1518,77 @@ func (lb *Leaderboard) GetUserLeaderboardData(userID string) (LeaderboardData, e
 			data.EntityRank = int(entityRankCmd.Val())
 		}
 
-		if topKEntityCmd.Err() != nil {
+		if entityCached {
+			data.TopKEntity = topKEntity
+		} else if topKEntityCmd.Err() != nil {
 			return LeaderboardData{}, fmt.Errorf("failed to fetch top-k entity: %w", topKEntityCmd.Err())
-		}
-		for _, m := range topKEntityCmd.Val() {
-			data.TopKEntity = append(data.TopKEntity, User{
-				ID:     m.Member.(string),
-				Entity: data.Entity,
-				Score:  m.Score,
-			})
+		} else if len(topKEntityCmd.Val()) > 0 {
+			data.TopKEntity, err = lb.enrichUsers(topKEntityCmd.Val(), entity)
+			if err != nil {
+				return LeaderboardData{}, fmt.Errorf("failed to fetch top-k entity metadata: %w", err)
+			}
+			lb.sortTies(data.TopKEntity)
+			lb.topKCache.set("entity:"+entity, data.TopKEntity)
 		}
 	} else {
 		data.EntityRank = -1
 	}
 
+	if lb.config.CheckIntegrity {
+		data.StrayEntities, err = lb.strayEntities(userID, data.Entity)
+		if err != nil {
+			return LeaderboardData{}, err
+		}
+	}
 	return data, nil
 }
 
 // GetTopKGlobal returns top k users across all entities.
-// Ordered by score descending.
-// Includes entity information for each user.
+// Ordered by score descending, equal scores by TieBreakField if set.
+// Includes entity information (and metadata if enabled) for each user.
+// Served from the in-memory cache when TopKCacheTTL is set.
 // Returns error if no users exist or Redis fails.
-func (lb *Leaderboard) GetTopKGlobal() ([]User, error) {
-	globalKey := lb.config.Namespace + ":global"
-	entitiesKey := lb.config.Namespace + ":user:entities"
+func (lb *Leaderboard) GetTopKGlobal() (_ []User, err error) {
+	defer lb.startOp("GetTopKGlobal", "", "").end(&err)
+	return lb.topKGlobal()
+}
 
-	members, err := lb.client.ZRevRangeWithScores(lb.ctx, globalKey, 0, int64(lb.config.K-1)).Result()
+// GetTopKGlobalCached is GetTopKGlobal served from a cache the caller
+// controls, for read-mostly boards updated on a schedule: the result is
+// kept until InvalidateCache is called or CachedTopKTTL elapses (never,
+// if unset). Unlike TopKCacheTTL, writes don't drop it, so reads are stale
+// until invalidation, even for writes made through this Leaderboard.
+// Safe for concurrent use; concurrent misses may each fetch the top k.
+// Returns error like GetTopKGlobal; errors aren't cached.
+func (lb *Leaderboard) GetTopKGlobalCached() (_ []User, err error) {
+	defer lb.startOp("GetTopKGlobalCached", "", "").end(&err)
+	users, generation, ok := lb.pinnedTopK.get()
+	if ok {
+		return users, nil
+	}
+	users, err = lb.topKGlobal()
+	if err != nil {
+		return nil, err
+	}
+	lb.pinnedTopK.set(generation, users)
+	return users, nil
+}
+
+// InvalidateCache drops the GetTopKGlobalCached result, and the
+// TopKCacheTTL entries, so the next reads fetch from Redis. A fetch running
+// concurrently isn't cached, so no result older than the call survives it.
+func (lb *Leaderboard) InvalidateCache() {
+	lb.pinnedTopK.invalidate()
+	lb.topKCache.invalidate()
+}
+
+// topKGlobal implements GetTopKGlobal.
+func (lb *Leaderboard) topKGlobal() ([]User, error) {
+	if users, ok := lb.topKCache.get(""); ok {
+		return users, nil
+	}
+
+	members, err := lb.globalTopK(lb.reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch global top-k: %w", err)
 	}
@@ -413,37 +1596,154 @@ func (lb *Leaderboard) GetTopKGlobal() ([]User, error) {
 		return nil, fmt.Errorf("no users in global leaderboard")
 	}
 
-	pipe := lb.client.Pipeline()
-	entityCmds := make(map[string]*redis.StringCmd)
-	for _, m := range members {
-		userID := m.Member.(string)
-		entityCmds[userID] = pipe.HGet(lb.ctx, entitiesKey, userID)
-	}
-	_, err = pipe.Exec(lb.ctx)
-	if err != nil && err != redis.Nil {
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch entities: %w", err)
 	}
-	users := make([]User, 0, len(members))
-	for _, m := range members {
-		userID := m.Member.(string)
-		users = append(users, User{
-			ID:     userID,
-			Entity: entityCmds[userID].Val(),
-			Score:  m.Score,
-		})
-	}
+	lb.sortTies(users)
+	lb.topKCache.set("", users)
 	return users, nil
 }
 
+// GetTopKGlobalRanked returns the same users as GetTopKGlobal, each with
+// its rank filled in so callers don't derive positions from slice indexes.
+// Ranks are 0-based unless OneBasedRanks is set.
+// Returns error if no users exist or Redis fails.
+func (lb *Leaderboard) GetTopKGlobalRanked() (_ []RankedUser, err error) {
+	defer lb.startOp("GetTopKGlobalRanked", "", "").end(&err)
+	users, err := lb.GetTopKGlobal()
+	if err != nil {
+		return nil, err
+	}
+	return lb.rankUsers(users, 0), nil
+}
+
+// GetTopKWithUser returns the global top k together with the requesting
+// user's own row, for screens showing the top 10 plus "your rank 4,321"
+// pinned below. self.InTopK tells whether the user is already listed.
+// Fetches both in one pipeline, plus the top-k enrichment pipeline (none
+// when the top-k is cached).
+// If the user isn't ranked, self has only the ID set and Rank -1.
+// Returns error if:
+// - userID is empty
+// - no users exist
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKWithUser(userID string) (topK []User, self RankedUser, err error) {
+	defer lb.startOp("GetTopKWithUser", userID, "").end(&err)
+	if userID == "" {
+		return nil, RankedUser{}, fmt.Errorf("invalid user ID")
+	}
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return nil, RankedUser{}, err
+	}
+
+	globalKey := lb.userGlobalKey(userID)
+	topK, cached := lb.topKCache.get("")
+
+	pipe := lb.reader.Pipeline()
+	var topCmd *redis.ZSliceCmd
+	if !cached && !lb.partitioned() {
+		topCmd = pipe.ZRevRangeWithScores(lb.ctx, globalKey, 0, int64(lb.config.K-1))
+	}
+	rankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+	scoreCmd := pipe.ZScore(lb.ctx, globalKey, member)
+	entityCmd := pipe.HGet(lb.ctx, lb.entitiesKey(), userID)
+	var metaCmd *redis.StringCmd
+	if lb.config.EnableMetadata {
+		metaCmd = pipe.HGet(lb.ctx, lb.metaKey(), userID)
+	}
+	var nameCmd *redis.StringCmd
+	if lb.config.EnableNames {
+		nameCmd = pipe.HGet(lb.ctx, lb.namesKey(), userID)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return nil, RankedUser{}, fmt.Errorf("failed to fetch top-k and user: %w", err)
+	}
+
+	if !cached {
+		var members []redis.Z
+		if topCmd != nil {
+			members = topCmd.Val()
+		} else if members, err = lb.globalTopK(lb.reader); err != nil {
+			return nil, RankedUser{}, fmt.Errorf("failed to fetch global top-k: %w", err)
+		}
+		if len(members) == 0 {
+			return nil, RankedUser{}, fmt.Errorf("no users in global leaderboard")
+		}
+		topK, err = lb.enrichGlobalUsers(members)
+		if err != nil {
+			return nil, RankedUser{}, fmt.Errorf("failed to fetch entities: %w", err)
+		}
+		lb.sortTies(topK)
+		lb.topKCache.set("", topK)
+	}
+
+	self = RankedUser{User: User{ID: userID}, Rank: -1}
+	if rankCmd.Err() == redis.Nil {
+		return topK, self, nil
+	}
+	rank := int(rankCmd.Val())
+	self.Score = scoreCmd.Val()
+	if lb.partitioned() {
+		globalRank, err := lb.partitionRank(lb.reader, userID, self.Score, int64(rank))
+		if err != nil {
+			return nil, RankedUser{}, err
+		}
+		rank = int(globalRank)
+	}
+	self.Entity = entityCmd.Val()
+	if metaCmd != nil {
+		self.Metadata, err = decodeMetadata(metaCmd.Val())
+		if err != nil {
+			return nil, RankedUser{}, err
+		}
+	}
+	if nameCmd != nil {
+		self.Name = nameCmd.Val()
+	}
+	self.InTopK = rank < len(topK)
+	self.Rank = rank
+	if lb.config.OneBasedRanks {
+		self.Rank++
+	}
+	return topK, self, nil
+}
+
+// rankUsers attaches ranks to users listed from position offset onwards.
+func (lb *Leaderboard) rankUsers(users []User, offset int) []RankedUser {
+	base := offset
+	if lb.config.OneBasedRanks {
+		base++
+	}
+	ranked := make([]RankedUser, len(users))
+	for i, user := range users {
+		ranked[i] = RankedUser{User: user, Rank: base + i}
+	}
+	return ranked
+}
+
 // GetTopKEntity returns top k users in specific entity.
-// Ordered by score descending.
+// Ordered by score descending, equal scores by TieBreakField if set.
+// Includes metadata for each user if enabled.
+// Served from the in-memory cache when TopKCacheTTL is set.
 // Returns error if:
 // - no users in entity
 // - Redis operation fails
-func (lb *Leaderboard) GetTopKEntity(entity string) ([]User, error) {
-	entityKey := lb.config.Namespace + ":entity:" + entity
+func (lb *Leaderboard) GetTopKEntity(entity string) (_ []User, err error) {
+	defer lb.startOp("GetTopKEntity", "", entity).end(&err)
+	return lb.topKEntity(lb.normalizeEntity(entity))
+}
+
+// topKEntity is GetTopKEntity for a normalized entity.
+func (lb *Leaderboard) topKEntity(entity string) ([]User, error) {
+	if users, ok := lb.topKCache.get("entity:" + entity); ok {
+		return users, nil
+	}
 
-	members, err := lb.client.ZRevRangeWithScores(lb.ctx, entityKey, 0, int64(lb.config.K-1)).Result()
+	entityKey := lb.entityKey(entity)
+
+	members, err := lb.entityReader(entity).ZRevRangeWithScores(lb.ctx, entityKey, 0, int64(lb.config.K-1)).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch entity %s top-k: %w", entity, err)
 	}
@@ -451,25 +1751,283 @@ func (lb *Leaderboard) GetTopKEntity(entity string) ([]User, error) {
 		return nil, fmt.Errorf("no users in entity %s", entity)
 	}
 
+	users, err := lb.enrichUsers(members, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entity %s metadata: %w", entity, err)
+	}
+	lb.sortTies(users)
+	lb.topKCache.set("entity:"+entity, users)
+	return users, nil
+}
+
+// GetTopKGlobalMinScore returns top k users across all entities scoring at
+// least minScore, e.g. a featured board of players with 1000+ points.
+// Ordered by score descending, enriched like GetTopKGlobal.
+// Unlike GetTopKGlobal, returns an empty slice when nobody qualifies and is
+// never served from the cache.
+// Returns error if:
+// - minScore is NaN (ErrInvalidScore)
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKGlobalMinScore(minScore float64) (_ []User, err error) {
+	defer lb.startOp("GetTopKGlobalMinScore", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return nil, err
+	}
+	members, err := lb.topKMinScore(lb.reader, lb.globalKey(), minScore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch global top-k: %w", err)
+	}
+	if len(members) == 0 {
+		return []User{}, nil
+	}
+
+	users, err := lb.enrichGlobalUsers(members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	return users, nil
+}
+
+// GetTopKEntityMinScore returns top k users in entity scoring at least
+// minScore. Same semantics as GetTopKGlobalMinScore.
+// Returns error if:
+// - minScore is NaN (ErrInvalidScore)
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKEntityMinScore(entity string, minScore float64) (_ []User, err error) {
+	defer lb.startOp("GetTopKEntityMinScore", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	members, err := lb.topKMinScore(lb.entityReader(entity), lb.entityKey(entity), minScore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entity %s top-k: %w", entity, err)
+	}
+	if len(members) == 0 {
+		return []User{}, nil
+	}
+
+	users, err := lb.enrichUsers(members, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entity %s metadata: %w", entity, err)
+	}
+	return users, nil
+}
+
+// topKMinScore fetches on c the top k members of key within [minScore, +inf].
+func (lb *Leaderboard) topKMinScore(c RedisCommands, key string, minScore float64) ([]redis.Z, error) {
+	if math.IsNaN(minScore) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScore, minScore)
+	}
+	return c.ZRevRangeByScoreWithScores(lb.ctx, key, &redis.ZRangeBy{
+		Min:   formatScore(minScore),
+		Max:   "+inf",
+		Count: int64(lb.config.K),
+	}).Result()
+}
+
+// GetTopKEntities returns top k users of several entities keyed by entity,
+// fetching all of them in a single pipeline (e.g., top 3 of each region on
+// a home screen). Entities without members map to empty slices.
+// Served from the in-memory cache when TopKCacheTTL is set.
+// Returns error if:
+// - any entity is empty
+// - Redis operation fails
+func (lb *Leaderboard) GetTopKEntities(entities []string) (_ map[string][]User, err error) {
+	defer lb.startOp("GetTopKEntities", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return nil, err
+	}
+	result := make(map[string][]User, len(entities))
+	var missing []string
+	for _, entity := range lb.normalizeEntities(entities) {
+		if entity == "" {
+			return nil, fmt.Errorf("invalid entity")
+		}
+		if _, ok := result[entity]; ok {
+			continue
+		}
+		if users, ok := lb.topKCache.get("entity:" + entity); ok {
+			result[entity] = users
+			continue
+		}
+		result[entity] = []User{}
+		missing = append(missing, entity)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	pipe := lb.reader.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, len(missing))
+	for i, entity := range missing {
+		cmds[i] = pipe.ZRevRangeWithScores(lb.ctx, lb.entityKey(entity), 0, int64(lb.config.K-1))
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch entities top-k: %w", err)
+	}
+
+	// enrich every entity's users in one pipeline
+	var all []User
+	for i, entity := range missing {
+		for _, m := range cmds[i].Val() {
+			all = append(all, User{ID: m.Member.(string), Entity: entity, Score: m.Score})
+		}
+	}
+	if err := lb.fillUsers(all, false); err != nil {
+		return nil, fmt.Errorf("failed to fetch entities metadata: %w", err)
+	}
+	for i, entity := range missing {
+		n := len(cmds[i].Val())
+		if n == 0 {
+			continue
+		}
+		result[entity], all = all[:n:n], all[n:]
+		lb.sortTies(result[entity])
+		lb.topKCache.set("entity:"+entity, result[entity])
+	}
+	return result, nil
+}
+
+// enrichUsers converts ranked members into users.
+// Looks up each user's entity when entity is empty and their metadata
+// when EnableMetadata is set, all in a single pipeline.
+func (lb *Leaderboard) enrichUsers(members []redis.Z, entity string) ([]User, error) {
 	users := make([]User, 0, len(members))
 	for _, m := range members {
-		userID := m.Member.(string)
 		users = append(users, User{
-			ID:     userID,
+			ID:     m.Member.(string),
 			Entity: entity,
 			Score:  m.Score,
 		})
 	}
+	if err := lb.fillUsers(users, entity == ""); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// enrichGlobalUsers is enrichUsers for the default global ranking, whose
+// members already carry the entity with EntityInMember.
+func (lb *Leaderboard) enrichGlobalUsers(members []redis.Z) ([]User, error) {
+	if !lb.config.EntityInMember {
+		return lb.enrichUsers(members, "")
+	}
+	users := make([]User, 0, len(members))
+	for _, m := range members {
+		id, entity := lb.parseMember(m.Member.(string))
+		users = append(users, User{ID: id, Entity: entity, Score: m.Score})
+	}
+	if err := lb.fillUsers(users, false); err != nil {
+		return nil, err
+	}
 	return users, nil
 }
 
+// fillUsers looks up the entities (if lookupEntity), metadata (if
+// EnableMetadata) and names (if EnableNames) of users in a single pipeline.
+func (lb *Leaderboard) fillUsers(users []User, lookupEntity bool) error {
+	if len(users) == 0 || (!lookupEntity && !lb.config.EnableMetadata && !lb.config.EnableNames) {
+		return nil
+	}
+
+	entitiesKey := lb.entitiesKey()
+	metaKey := lb.metaKey()
+	namesKey := lb.namesKey()
+
+	pipe := lb.reader.Pipeline()
+	entityCmds := make([]*redis.StringCmd, len(users))
+	metaCmds := make([]*redis.StringCmd, len(users))
+	nameCmds := make([]*redis.StringCmd, len(users))
+	for i, u := range users {
+		if lookupEntity {
+			entityCmds[i] = pipe.HGet(lb.ctx, entitiesKey, u.ID)
+		}
+		if lb.config.EnableMetadata {
+			metaCmds[i] = pipe.HGet(lb.ctx, metaKey, u.ID)
+		}
+		if lb.config.EnableNames {
+			nameCmds[i] = pipe.HGet(lb.ctx, namesKey, u.ID)
+		}
+	}
+	_, err := pipe.Exec(lb.ctx)
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	for i := range users {
+		if entityCmds[i] != nil {
+			users[i].Entity = entityCmds[i].Val()
+		}
+		if metaCmds[i] != nil {
+			users[i].Metadata, err = decodeMetadata(metaCmds[i].Val())
+			if err != nil {
+				return err
+			}
+		}
+		if nameCmds[i] != nil {
+			users[i].Name = nameCmds[i].Val()
+		}
+	}
+	return nil
+}
+
+// decodeMetadata parses a stored metadata value.
+// Returns nil map for empty (missing) values.
+func decodeMetadata(raw string) (map[string]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var meta map[string]string
+	if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return meta, nil
+}
+
 // GetRankGlobal returns user's position in global ranking.
 // 0-based ranking (0 is highest score).
+// With RankSnapshotInterval the rank comes from the last snapshot, at most
+// one interval (plus build time) stale; users missing from it fall back to
+// GetRankGlobalExact.
 // Returns -1 if user not found.
-func (lb *Leaderboard) GetRankGlobal(userID string) (int, error) {
-	globalKey := lb.config.Namespace + ":global"
+func (lb *Leaderboard) GetRankGlobal(userID string) (_ int, err error) {
+	defer lb.startOp("GetRankGlobal", userID, "").end(&err)
+	if lb.rankSnapshot != nil {
+		rank, ok, err := lb.snapshotRank(userID)
+		if err != nil || ok {
+			return rank, err
+		}
+	}
+	return lb.rankGlobal(userID)
+}
+
+// GetRankGlobalExact is GetRankGlobal always reading the live ranking with
+// ZREVRANK, bypassing the rank snapshot (e.g., for prize payouts).
+// Returns -1 if user not found.
+func (lb *Leaderboard) GetRankGlobalExact(userID string) (_ int, err error) {
+	defer lb.startOp("GetRankGlobalExact", userID, "").end(&err)
+	return lb.rankGlobal(userID)
+}
+
+// rankGlobal reads userID's live global rank.
+func (lb *Leaderboard) rankGlobal(userID string) (int, error) {
+	globalKey := lb.userGlobalKey(userID)
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return -1, err
+	}
+
+	if lb.partitioned() {
+		pipe := lb.reader.Pipeline()
+		rankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+		scoreCmd := pipe.ZScore(lb.ctx, globalKey, member)
+		if _, err := pipe.Exec(lb.ctx); err == redis.Nil {
+			return -1, nil
+		} else if err != nil {
+			return -1, fmt.Errorf("failed to get global rank: %w", err)
+		}
+		rank, err := lb.partitionRank(lb.reader, userID, scoreCmd.Val(), rankCmd.Val())
+		return int(rank), err
+	}
 
-	rank, err := lb.client.ZRevRank(lb.ctx, globalKey, userID).Result()
+	rank, err := lb.reader.ZRevRank(lb.ctx, globalKey, member).Result()
 	if err == redis.Nil {
 		return -1, nil
 	}
@@ -479,28 +2037,139 @@ func (lb *Leaderboard) GetRankGlobal(userID string) (int, error) {
 	return int(rank), nil
 }
 
+// GetRankFraction returns a user's global rank scaled to 0..1 for progress
+// bars: rank/(count-1), so 0 is the top of the board and 1 the bottom ("top
+// 12%" is 0.12). This is the reverse of a percentile, where higher is better.
+// Reads the live rank and the user count in one round trip. A user alone on
+// the board is at the top (0).
+// Returns error if:
+// - user not found (ErrUserNotFound)
+// - Redis operation fails
+func (lb *Leaderboard) GetRankFraction(userID string) (_ float64, err error) {
+	defer lb.startOp("GetRankFraction", userID, "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return 0, err
+	}
+	globalKey := lb.globalKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	pipe := lb.reader.Pipeline()
+	rankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+	countCmd := pipe.ZCard(lb.ctx, globalKey)
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return 0, fmt.Errorf("failed to get global rank: %w", err)
+	}
+	if rankCmd.Err() == redis.Nil {
+		return 0, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	count := countCmd.Val()
+	if count <= 1 {
+		return 0, nil
+	}
+	return float64(rankCmd.Val()) / float64(count-1), nil
+}
+
+// GetRankAndTotal returns a user's live global rank (0-based, as
+// GetRankGlobalExact) together with the number of users on the board, for
+// "rank 42 of 10,000" displays, in one round trip.
+// Returns rank -1 if user not found; total is reported either way.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) GetRankAndTotal(userID string) (rank int, total int64, err error) {
+	defer lb.startOp("GetRankAndTotal", userID, "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return -1, 0, err
+	}
+	globalKey := lb.globalKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	pipe := lb.reader.Pipeline()
+	rankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+	countCmd := pipe.ZCard(lb.ctx, globalKey)
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return -1, 0, fmt.Errorf("failed to get global rank: %w", err)
+	}
+	if rankCmd.Err() == redis.Nil {
+		return -1, countCmd.Val(), nil
+	}
+	return int(rankCmd.Val()), countCmd.Val(), nil
+}
+
+// GetRankInScoreRange returns a user's position among the users scoring
+// within [min, max], e.g. their rank inside a "Gold" tier of 1000..2000.
+// 0-based like GetRankGlobal: the global rank minus the users scoring above
+// max, read in one round trip, so ties order as in the global ranking.
+// Returns -1 if the user is not found or scores outside the window.
+// Returns error if:
+// - min or max is NaN (ErrInvalidScore); infinities are valid bounds
+// - min is greater than max
+// - Redis operation fails
+func (lb *Leaderboard) GetRankInScoreRange(userID string, min, max float64) (_ int, err error) {
+	defer lb.startOp("GetRankInScoreRange", userID, "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return -1, err
+	}
+	if math.IsNaN(min) || math.IsNaN(max) {
+		return -1, fmt.Errorf("%w: score range [%v, %v]", ErrInvalidScore, min, max)
+	}
+	if min > max {
+		return -1, fmt.Errorf("invalid score range [%v, %v]", min, max)
+	}
+	globalKey := lb.globalKey()
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return -1, err
+	}
+
+	pipe := lb.reader.Pipeline()
+	scoreCmd := pipe.ZScore(lb.ctx, globalKey, member)
+	rankCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+	aboveCmd := pipe.ZCount(lb.ctx, globalKey, "("+formatScore(max), "+inf")
+	if _, err := pipe.Exec(lb.ctx); err == redis.Nil {
+		return -1, nil
+	} else if err != nil {
+		return -1, fmt.Errorf("failed to get rank in score range: %w", err)
+	}
+	if score := scoreCmd.Val(); score < min || score > max {
+		return -1, nil
+	}
+	return int(rankCmd.Val() - aboveCmd.Val()), nil
+}
+
 // GetRankEntity returns user's position in entity ranking.
 // 0-based ranking (0 is highest score).
+// The entity is resolved in this order:
+// - PrimaryEntity, if set and the user is ranked in it
+// - the user's entity mapping
 // Returns -1 if:
 // - user not found
 // - user has no entity
 // - user not in entity ranking
-func (lb *Leaderboard) GetRankEntity(userID string) (int, error) {
-	entitiesKey := lb.config.Namespace + ":user:entities"
+func (lb *Leaderboard) GetRankEntity(userID string) (_ int, err error) {
+	defer lb.startOp("GetRankEntity", userID, "").end(&err)
+	entitiesKey := lb.entitiesKey()
 
-	entity, err := lb.client.HGet(lb.ctx, entitiesKey, userID).Result()
-	if err == redis.Nil {
-		return -1, nil
-	}
-	if err != nil {
+	pipe := lb.reader.Pipeline()
+	entityCmd := pipe.HGet(lb.ctx, entitiesKey, userID)
+	primaryCmd := lb.primaryRank(pipe, userID)
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
 		return -1, fmt.Errorf("failed to get user entity: %w", err)
 	}
+	if primaryCmd != nil && primaryCmd.Err() == nil {
+		return int(primaryCmd.Val()), nil
+	}
+	entity := entityCmd.Val()
 	if entity == "" {
 		return -1, nil
 	}
 
-	entityKey := lb.config.Namespace + ":entity:" + entity
-	rank, err := lb.client.ZRevRank(lb.ctx, entityKey, userID).Result()
+	entityKey := lb.entityKey(entity)
+	rank, err := lb.entityReader(entity).ZRevRank(lb.ctx, entityKey, userID).Result()
 	if err == redis.Nil {
 		return -1, nil
 	}
@@ -510,15 +2179,155 @@ func (lb *Leaderboard) GetRankEntity(userID string) (int, error) {
 	return int(rank), nil
 }
 
+// GetRanksGlobal returns the global ranks of many users in one pipeline,
+// e.g. for rendering a table. 0-based like GetRankGlobal.
+// Users not on the leaderboard map to -1.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) GetRanksGlobal(userIDs []string) (_ map[string]int, err error) {
+	defer lb.startOp("GetRanksGlobal", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return nil, err
+	}
+	globalKey := lb.globalKey()
+	members, err := lb.resolveMembers(lb.reader, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := lb.reader.Pipeline()
+	cmds := make([]*redis.IntCmd, len(userIDs))
+	for i, member := range members {
+		cmds[i] = pipe.ZRevRank(lb.ctx, globalKey, member)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get global ranks: %w", err)
+	}
+	return ranksFromCmds(userIDs, cmds), nil
+}
+
+// GetRanksEntity returns each user's rank within their own entity, like
+// GetRankEntity (PrimaryEntity first), using two pipelined round trips for
+// all users.
+// Users without entity or not on the leaderboard map to -1.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) GetRanksEntity(userIDs []string) (_ map[string]int, err error) {
+	defer lb.startOp("GetRanksEntity", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	pipe := lb.reader.Pipeline()
+	entitiesCmd := pipe.HMGet(lb.ctx, lb.entitiesKey(), userIDs...)
+	primaryCmds := make([]*redis.IntCmd, len(userIDs))
+	for i, userID := range userIDs {
+		primaryCmds[i] = lb.primaryRank(pipe, userID)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get user entities: %w", err)
+	}
+	entities := entitiesCmd.Val()
+
+	pipe = lb.reader.Pipeline()
+	cmds := make([]*redis.IntCmd, len(userIDs))
+	for i, userID := range userIDs {
+		if primaryCmds[i] != nil && primaryCmds[i].Err() == nil {
+			cmds[i] = primaryCmds[i]
+		} else if entity, _ := entities[i].(string); entity != "" {
+			cmds[i] = pipe.ZRevRank(lb.ctx, lb.entityKey(entity), userID)
+		}
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get entity ranks: %w", err)
+	}
+	return ranksFromCmds(userIDs, cmds), nil
+}
+
+// primaryRank queues the user's rank in PrimaryEntity on pipe, or returns
+// nil without PrimaryEntity. The command fails with redis.Nil if the user
+// isn't ranked there.
+func (lb *Leaderboard) primaryRank(pipe redis.Pipeliner, userID string) *redis.IntCmd {
+	if lb.config.PrimaryEntity == "" {
+		return nil
+	}
+	return pipe.ZRevRank(lb.ctx, lb.entityKey(lb.config.PrimaryEntity), userID)
+}
+
+// ranksFromCmds maps users to their pipelined ZREVRANK results; users
+// without a command or without a rank map to -1.
+func ranksFromCmds(userIDs []string, cmds []*redis.IntCmd) map[string]int {
+	ranks := make(map[string]int, len(userIDs))
+	for i, userID := range userIDs {
+		ranks[userID] = -1
+		if cmds[i] == nil {
+			continue
+		}
+		if rank, err := cmds[i].Result(); err == nil {
+			ranks[userID] = int(rank)
+		}
+	}
+	return ranks
+}
+
+// RankAtScore returns the rank a score would occupy globally, i.e. the
+// number of users with a strictly higher score (0-based, like GetRankGlobal).
+// Boundary is exclusive: users tied with score are not counted, so a user
+// holding exactly score has this rank.
+// Useful for tier cutoffs without enumerating the board.
+// Returns error if:
+// - score is NaN (ErrInvalidScore); infinities are valid bounds
+// - Redis operation fails
+func (lb *Leaderboard) RankAtScore(score float64) (_ int64, err error) {
+	defer lb.startOp("RankAtScore", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return 0, err
+	}
+	globalKey := lb.globalKey()
+	return lb.rankAtScore(lb.reader, globalKey, score)
+}
+
+// RankAtScoreEntity returns the rank a score would occupy within an entity,
+// i.e. the number of entity users with a strictly higher score.
+// Same exclusive boundary as RankAtScore.
+// Returns error if:
+// - score is NaN (ErrInvalidScore)
+// - Redis operation fails
+func (lb *Leaderboard) RankAtScoreEntity(entity string, score float64) (_ int64, err error) {
+	defer lb.startOp("RankAtScoreEntity", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	entityKey := lb.entityKey(entity)
+	return lb.rankAtScore(lb.entityReader(entity), entityKey, score)
+}
+
+// rankAtScore counts members of key on c scoring strictly above score.
+func (lb *Leaderboard) rankAtScore(c RedisCommands, key string, score float64) (int64, error) {
+	if math.IsNaN(score) {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidScore, score)
+	}
+	min := "(" + formatScore(score)
+	count, err := c.ZCount(lb.ctx, key, min, "+inf").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count scores above %v: %w", score, err)
+	}
+	return count, nil
+}
+
 // GetUserScore returns user's current score.
 // Returns error if:
-// - user not found
+// - user not found (ErrUserNotFound)
 // - Redis operation fails
-func (lb *Leaderboard) GetUserScore(userID string) (float64, error) {
-	globalKey := lb.config.Namespace + ":global"
-	score, err := lb.client.ZScore(lb.ctx, globalKey, userID).Result()
+func (lb *Leaderboard) GetUserScore(userID string) (_ float64, err error) {
+	defer lb.startOp("GetUserScore", userID, "").end(&err)
+	globalKey := lb.userGlobalKey(userID)
+	member, err := lb.resolveMember(lb.reader, userID)
+	if err != nil {
+		return 0, err
+	}
+	score, err := lb.reader.ZScore(lb.ctx, globalKey, member).Result()
 	if err == redis.Nil {
-		return 0, fmt.Errorf("user %s not found", userID)
+		return 0, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
 	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to get score: %w", err)
@@ -526,13 +2335,44 @@ func (lb *Leaderboard) GetUserScore(userID string) (float64, error) {
 	return score, nil
 }
 
+// GetUserScoreRounded returns user's current score rounded to decimals
+// places, for display of FloatScores boards where accumulated increments
+// leave values like 99.99999999. Only the returned value is rounded; the
+// stored score and rankings keep full precision.
+// Returns error if:
+// - decimals is negative
+// - user not found (ErrUserNotFound)
+// - Redis operation fails
+func (lb *Leaderboard) GetUserScoreRounded(userID string, decimals int) (_ float64, err error) {
+	defer lb.startOp("GetUserScoreRounded", userID, "").end(&err)
+	if decimals < 0 {
+		return 0, fmt.Errorf("invalid decimals: %d", decimals)
+	}
+	score, err := lb.GetUserScore(userID)
+	if err != nil {
+		return 0, err
+	}
+	return roundScore(score, decimals), nil
+}
+
+// roundScore rounds score half away from zero to decimals places.
+func roundScore(score float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	rounded := math.Round(score*p) / p
+	if math.IsInf(rounded, 0) || math.IsNaN(rounded) {
+		return score // too many decimals to scale; already exact enough
+	}
+	return rounded
+}
+
 // GetUserEntity returns user's entity identifier.
 // Returns empty string if:
 // - user not found
 // - user has no entity
 // Returns error if Redis operation fails.
-func (lb *Leaderboard) GetUserEntity(userID string) (string, error) {
-	entitiesKey := lb.config.Namespace + ":user:entities"
+func (lb *Leaderboard) GetUserEntity(userID string) (_ string, err error) {
+	defer lb.startOp("GetUserEntity", userID, "").end(&err)
+	entitiesKey := lb.entitiesKey()
 	entity, err := lb.client.HGet(lb.ctx, entitiesKey, userID).Result()
 	if err == redis.Nil {
 		return "", nil
@@ -543,11 +2383,36 @@ func (lb *Leaderboard) GetUserEntity(userID string) (string, error) {
 	return entity, nil
 }
 
+// GetUserEntities returns the entities of many users with a single HMGET,
+// keyed by user ID. Users not on the leaderboard are omitted; users without
+// entity map to an empty string.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) GetUserEntities(userIDs []string) (_ map[string]string, err error) {
+	defer lb.startOp("GetUserEntities", "", "").end(&err)
+	entities := make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return entities, nil
+	}
+
+	vals, err := lb.client.HMGet(lb.ctx, lb.entitiesKey(), userIDs...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user entities: %w", err)
+	}
+	for i, v := range vals {
+		if entity, ok := v.(string); ok {
+			entities[userIDs[i]] = entity
+		}
+	}
+	return entities, nil
+}
 
 // Clears entrie redis with namespace prefix
-// no return 
+// no return
 func (lb *Leaderboard) ForceClearLeaderBoardWithNamespacePrefix() {
-	prefix := lb.config.Namespace + "*"
+	defer lb.topKCache.invalidate()
+	defer lb.knownEntities.Clear()
+
+	prefix := lb.keyPattern()
 	maxRetry := 2
 
 	for attempt := 0; attempt < maxRetry; attempt++ {
@@ -1,21 +1,59 @@
 package redisboard
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 )
 
+// testRedisAddr is the Redis the tests run against: an in-process miniredis,
+// or the server at REDISBOARD_TEST_ADDR (e.g., localhost:6379).
+var testRedisAddr = os.Getenv("REDISBOARD_TEST_ADDR")
+
+func TestMain(m *testing.M) {
+	if testRedisAddr == "" {
+		mr, err := miniredis.Run()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "start miniredis:", err)
+			os.Exit(1)
+		}
+		testRedisAddr = mr.Addr()
+		code := m.Run()
+		mr.Close()
+		os.Exit(code)
+	}
+	os.Exit(m.Run())
+}
+
 func newTestLeaderboard(t *testing.T, cfg Config) *Leaderboard {
 	t.Helper()
 	if cfg.RedisAddr == "" {
-		cfg.RedisAddr = "localhost:6379"
+		cfg.RedisAddr = testRedisAddr
 	}
 	lb, err := New(cfg)
 	if err != nil {
 		t.Fatalf("create leaderboard: %v", err)
 	}
+	lb.ForceClearLeaderBoardWithNamespacePrefix()
 	return lb
 }
 
+// rawClient returns lb's go-redis connection, for commands the leaderboard
+// never sends itself.
+func rawClient(lb *Leaderboard) redis.UniversalClient {
+	return lb.client.(redis.UniversalClient)
+}
+
 func TestNew(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test"})
 	defer lb.Close()
@@ -28,6 +66,93 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithClient(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: testRedisAddr})
+	defer client.Close()
+
+	lb := newTestLeaderboard(t, Config{Namespace: "test", RedisAddr: "unused:1", Client: client})
+	lb.AddUser(User{ID: "u1", Score: 100})
+	if rank, err := lb.GetRankGlobal("u1"); err != nil || rank != 0 {
+		t.Errorf("expected rank 0, got %d, err: %v", rank, err)
+	}
+	lb.Close()
+
+	// the caller still owns the client
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Errorf("expected client left open, got %v", err)
+	}
+}
+
+func TestNewNamespaceConflict(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.client.Set(lb.ctx, "test:global", "not a zset", 0)
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr}); !errors.Is(err, ErrNamespaceConflict) {
+		t.Errorf("expected ErrNamespaceConflict, got %v", err)
+	}
+	lb.client.Del(lb.ctx, "test:global")
+
+	lb.client.Set(lb.ctx, "test:entity:US", "not a zset", 0)
+	if err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 1}); !errors.Is(err, ErrNamespaceConflict) {
+		t.Errorf("expected ErrNamespaceConflict on entity key, got %v", err)
+	}
+}
+
+func TestVerifyNamespace(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 1})
+	lb.AddUser(User{ID: "u2", Entity: "EU", Score: 2})
+	lb.AddUser(User{ID: "u3", Score: 3})
+	lb.AddUserMetric("u1", "US", "kills", 5)
+	other, info, err := NewWithInfo(Config{Namespace: "test", RedisAddr: testRedisAddr, VerifyNamespace: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.Close()
+	// global, mapping, 2 entities, metrics set, metric global and entity
+	if info.Users != 3 || info.Entities != 2 || info.Keys != 7 {
+		t.Errorf("unexpected namespace info: %+v", info)
+	}
+	other, info, err = NewWithInfo(Config{Namespace: "test", RedisAddr: testRedisAddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.Close()
+	if info.Users != 3 || info.Keys != 0 {
+		t.Errorf("expected only the user count without VerifyNamespace, got %+v", info)
+	}
+
+	// entity rankings are only checked with VerifyNamespace
+	lb.client.Set(lb.ctx, "test:entity:XX", "not a zset", 0)
+	if plain, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr}); err != nil {
+		t.Errorf("expected entity keys unchecked by default, got %v", err)
+	} else {
+		plain.Close()
+	}
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, VerifyNamespace: true}); !errors.Is(err, ErrNamespaceConflict) {
+		t.Errorf("expected ErrNamespaceConflict, got %v", err)
+	}
+}
+
+func TestKeySeparator(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", KeySeparator: "/"})
+	defer lb.Close()
+	defer lb.ForceClearLeaderBoardWithNamespacePrefix()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	for _, key := range []string{"test/global", "test/user/entities", "test/entity/US"} {
+		if n, err := lb.client.Exists(lb.ctx, key).Result(); err != nil || n != 1 {
+			t.Errorf("expected key %s to exist, err: %v", key, err)
+		}
+	}
+	if rank, err := lb.GetRankEntity("u1"); err != nil || rank != 0 {
+		t.Errorf("expected entity rank 0, got %d, err: %v", rank, err)
+	}
+}
+
 func TestAddUser(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test"})
 	defer lb.Close()
@@ -43,6 +168,86 @@ func TestAddUser(t *testing.T) {
 	}
 }
 
+func TestAddUserMetadata(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"name": "Alice"}})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 80})
+
+	topK, err := lb.GetTopKGlobal()
+	if err != nil {
+		t.Fatalf("GetTopKGlobal: %v", err)
+	}
+	if len(topK) != 2 || topK[0].Metadata["name"] != "Alice" || topK[1].Metadata != nil {
+		t.Errorf("unexpected topK metadata: %+v", topK)
+	}
+
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil {
+		t.Fatalf("GetUserLeaderboardData: %v", err)
+	}
+	if data.Metadata["name"] != "Alice" || data.TopKEntity[0].Metadata["name"] != "Alice" {
+		t.Errorf("unexpected data metadata: %+v", data)
+	}
+}
+
+func TestInvalidEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityMaxLength: 4})
+	defer lb.Close()
+
+	for _, entity := range []string{"U S", "US:global", "TOOLONG"} {
+		if err := lb.AddUser(User{ID: "u1", Entity: entity, Score: 100}); !errors.Is(err, ErrInvalidEntity) {
+			t.Errorf("AddUser(%q): expected ErrInvalidEntity, got %v", entity, err)
+		}
+		if err := lb.IncrementScore("u1", entity, 10); !errors.Is(err, ErrInvalidEntity) {
+			t.Errorf("IncrementScore(%q): expected ErrInvalidEntity, got %v", entity, err)
+		}
+	}
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if err := lb.UpdateEntityByUserID("u1", "U*K"); !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("UpdateEntityByUserID: expected ErrInvalidEntity, got %v", err)
+	}
+
+	custom := newTestLeaderboard(t, Config{Namespace: "test", EntityCharset: "ABC"})
+	defer custom.Close()
+	if err := custom.AddUser(User{ID: "u1", Entity: "AB", Score: 1}); err != nil {
+		t.Errorf("AddUser with custom charset: %v", err)
+	}
+	if err := custom.AddUser(User{ID: "u1", Entity: "AD", Score: 1}); !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("expected ErrInvalidEntity for custom charset, got %v", err)
+	}
+}
+
+func TestInvalidUserID(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", MaxUserIDLength: 8})
+	defer lb.Close()
+
+	if err := lb.AddUser(User{ID: strings.Repeat("a", 8), Score: 1}); err != nil {
+		t.Errorf("expected ID at the limit to be accepted, got %v", err)
+	}
+	for _, id := range []string{strings.Repeat("a", 9), "u\x001", "u\n1", "u\u00851"} {
+		if err := lb.AddUser(User{ID: id, Score: 1}); !errors.Is(err, ErrInvalidUserID) {
+			t.Errorf("AddUser(%q): expected ErrInvalidUserID, got %v", id, err)
+		}
+		if err := lb.IncrementScore(id, "", 1); !errors.Is(err, ErrInvalidUserID) {
+			t.Errorf("IncrementScore(%q): expected ErrInvalidUserID, got %v", id, err)
+		}
+		if err := lb.DecrementScore(id, "", 1); !errors.Is(err, ErrInvalidUserID) {
+			t.Errorf("DecrementScore(%q): expected ErrInvalidUserID, got %v", id, err)
+		}
+	}
+	var batchErr *BatchError
+	err := lb.IncrementScores([]ScoreUpdate{{UserID: strings.Repeat("a", 9), Delta: 1}})
+	if !errors.As(err, &batchErr) || !errors.Is(batchErr.Errors[0], ErrInvalidUserID) {
+		t.Errorf("IncrementScores: expected ErrInvalidUserID, got %v", err)
+	}
+	if top, _ := lb.GetTopKGlobal(); len(top) != 1 {
+		t.Errorf("expected only the valid user stored, got %+v", top)
+	}
+}
+
 func TestIncrementScore(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test"})
 	defer lb.Close()
@@ -83,8 +288,8 @@ func TestRemoveUser(t *testing.T) {
 	}
 
 	_, err := lb.GetUserScore("u1")
-	if err == nil {
-		t.Error("expected user not found error")
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
 	}
 }
 
@@ -103,6 +308,205 @@ func TestUpdateEntityByUserID(t *testing.T) {
 	}
 }
 
+func TestUpdateEntityByUserIDKeepsScore(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	// a non-integer score stored before FloatScores was turned off, or by
+	// another writer, must survive the move unrounded
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 99})
+	stored := 98.99999999999999
+	lb.client.ZAdd(lb.ctx, "test:global", redis.Z{Score: stored, Member: "u1"})
+	lb.client.ZAdd(lb.ctx, "test:entity:US", redis.Z{Score: stored, Member: "u1"})
+
+	if err := lb.UpdateEntityByUserID("u1", "UK"); err != nil {
+		t.Fatalf("UpdateEntityByUserID: %v", err)
+	}
+	if score, err := lb.GetUserScore("u1"); err != nil || score != stored {
+		t.Errorf("expected global score %v, got %v, err: %v", stored, score, err)
+	}
+	entityScore, err := lb.client.ZScore(lb.ctx, "test:entity:UK", "u1").Result()
+	if err != nil || entityScore != stored {
+		t.Errorf("expected entity score %v, got %v, err: %v", stored, entityScore, err)
+	}
+}
+
+func TestUpdateEntityByUserIDConcurrentIncrement(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := lb.IncrementScore("u1", "UK", 1); err != nil {
+				t.Errorf("IncrementScore: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		err := lb.UpdateEntityByUserID("u1", "UK")
+		for errors.Is(err, ErrConflict) {
+			err = lb.UpdateEntityByUserID("u1", "UK")
+		}
+		if err != nil {
+			t.Errorf("UpdateEntityByUserID: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	score, err := lb.GetUserScore("u1")
+	if err != nil || score != 150 {
+		t.Fatalf("expected global score 150, got %f, err: %v", score, err)
+	}
+	entityScore, err := lb.client.ZScore(lb.ctx, "test:entity:UK", "u1").Result()
+	if err != nil || entityScore != score {
+		t.Errorf("expected entity score %f, got %f, err: %v", score, entityScore, err)
+	}
+}
+
+func TestRemoveUserConcurrentEntityChange(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("u%d", i)
+		lb.AddUser(User{ID: id, Entity: "US", Score: 100})
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			err := lb.UpdateEntityByUserID(id, "UK")
+			if err != nil && !errors.Is(err, ErrUserNotFound) && !errors.Is(err, ErrConflict) {
+				t.Errorf("UpdateEntityByUserID: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			err := lb.RemoveUser(id)
+			for errors.Is(err, ErrConflict) {
+				err = lb.RemoveUser(id)
+			}
+			if err != nil {
+				t.Errorf("RemoveUser: %v", err)
+			}
+		}()
+		wg.Wait()
+
+		for _, key := range []string{"test:global", "test:entity:US", "test:entity:UK"} {
+			if err := lb.client.ZScore(lb.ctx, key, id).Err(); err != redis.Nil {
+				t.Errorf("expected %s removed from %s, got %v", id, key, err)
+			}
+		}
+		if n, _ := rawClient(lb).HExists(lb.ctx, "test:user:entities", id).Result(); n {
+			t.Errorf("expected %s's entity mapping removed", id)
+		}
+	}
+}
+
+func TestRemoveEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 80})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 90})
+
+	if err := lb.RemoveEntity("US", false); err != nil {
+		t.Fatalf("RemoveEntity: %v", err)
+	}
+	if _, err := lb.GetTopKEntity("US"); err == nil {
+		t.Error("expected entity US to be empty")
+	}
+	entity, err := lb.GetUserEntity("u1")
+	if err != nil || entity != "" {
+		t.Errorf("expected empty entity, got %s, err: %v", entity, err)
+	}
+	if score, err := lb.GetUserScore("u1"); err != nil || score != 100 {
+		t.Errorf("expected u1 to stay ranked globally, got %f, err: %v", score, err)
+	}
+
+	lb.AddUserMetric("u3", "UK", "kills", 7)
+	if err := lb.RemoveEntity("UK", true); err != nil {
+		t.Fatalf("RemoveEntity: %v", err)
+	}
+	if _, err := lb.GetUserScore("u3"); err == nil {
+		t.Error("expected u3 to be removed globally")
+	}
+	if n, _ := lb.client.ZCard(lb.ctx, lb.metricGlobalKey("kills")).Result(); n != 0 {
+		t.Errorf("expected u3 removed from metric boards, got %d members", n)
+	}
+	if n, _ := lb.client.ZCard(lb.ctx, lb.metricEntityKey("kills", "UK")).Result(); n != 0 {
+		t.Errorf("expected u3 removed from metric entity boards, got %d members", n)
+	}
+}
+
+// argsHook records the arguments of the commands named name.
+type argsHook struct {
+	name string
+	mu   sync.Mutex
+	args [][]interface{}
+}
+
+func (*argsHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *argsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Name() == h.name {
+			h.mu.Lock()
+			h.args = append(h.args, cmd.Args())
+			h.mu.Unlock()
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (*argsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestBulkOperationsBatchSize(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", BatchSize: 2})
+	defer lb.Close()
+	for i := 0; i < 5; i++ {
+		lb.AddUser(User{ID: fmt.Sprintf("u%d", i), Entity: "US", Score: float64(i)})
+	}
+
+	zscan := &argsHook{name: "zscan"}
+	rawClient(lb).AddHook(zscan)
+	if err := lb.ResetScores(true); err != nil {
+		t.Fatal(err)
+	}
+	if len(zscan.args) == 0 {
+		t.Error("expected ResetScores to ZSCAN")
+	}
+	for _, args := range zscan.args {
+		if fmt.Sprint(args[len(args)-1]) != "2" {
+			t.Errorf("expected ZSCAN COUNT 2, got %v", args)
+		}
+	}
+
+	zrange := &argsHook{name: "zrange"}
+	rawClient(lb).AddHook(zrange)
+	if err := lb.RemoveEntity("US", false); err != nil {
+		t.Fatal(err)
+	}
+	// three chunks of at most 2 members, then the empty read
+	if len(zrange.args) != 4 {
+		t.Errorf("expected 4 ZRANGE reads, got %v", zrange.args)
+	}
+	for _, args := range zrange.args {
+		if fmt.Sprint(args[2:]) != "[0 1]" {
+			t.Errorf("expected ZRANGE 0 1, got %v", args)
+		}
+	}
+}
+
 func TestGetUserLeaderboardData(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2})
 	defer lb.Close()
@@ -114,11 +518,26 @@ func TestGetUserLeaderboardData(t *testing.T) {
 	if err != nil {
 		t.Errorf("GetUserLeaderboardData: %v", err)
 	}
-	if data.UserID != "u1" || data.Score != 100 || data.Entity != "US" || data.GlobalRank != 0 || data.EntityRank != 0 {
+	if data.UserID != "u1" || !data.Exists || data.Score != 100 || data.Entity != "US" || data.GlobalRank != 0 || data.EntityRank != 0 {
 		t.Errorf("unexpected data: %+v", data)
 	}
 }
 
+func TestGetUserLeaderboardDataUnknownUser(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+
+	data, err := lb.GetUserLeaderboardData("ghost")
+	if err != nil {
+		t.Fatalf("GetUserLeaderboardData: %v", err)
+	}
+	if data.Exists || data.GlobalRank != -1 || data.EntityRank != -1 || data.Score != 0 {
+		t.Errorf("unexpected data for unknown user: %+v", data)
+	}
+}
+
 func TestGetTopKGlobal(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2})
 	defer lb.Close()
@@ -162,6 +581,67 @@ func TestGetRankGlobal(t *testing.T) {
 	}
 }
 
+func TestGetRankFraction(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	if f, err := lb.GetRankFraction("u1"); err != nil || f != 0 {
+		t.Errorf("expected single user at 0, got %v, err: %v", f, err)
+	}
+	lb.AddUser(User{ID: "u2", Score: 50})
+	lb.AddUser(User{ID: "u3", Score: 10})
+	for id, want := range map[string]float64{"u1": 0, "u2": 0.5, "u3": 1} {
+		if f, err := lb.GetRankFraction(id); err != nil || f != want {
+			t.Errorf("%s: expected %v, got %v, err: %v", id, want, f, err)
+		}
+	}
+	if _, err := lb.GetRankFraction("ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestGetRankAndTotal(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	if rank, total, err := lb.GetRankAndTotal("u1"); err != nil || rank != -1 || total != 0 {
+		t.Errorf("expected -1 of 0 on an empty board, got %d of %d, err: %v", rank, total, err)
+	}
+	lb.AddUser(User{ID: "u1", Score: 100})
+	lb.AddUser(User{ID: "u2", Score: 50})
+	lb.AddUser(User{ID: "u3", Score: 10})
+	if rank, total, err := lb.GetRankAndTotal("u2"); err != nil || rank != 1 || total != 3 {
+		t.Errorf("expected 1 of 3, got %d of %d, err: %v", rank, total, err)
+	}
+	if rank, total, err := lb.GetRankAndTotal("ghost"); err != nil || rank != -1 || total != 3 {
+		t.Errorf("expected -1 of 3 for unknown user, got %d of %d, err: %v", rank, total, err)
+	}
+}
+
+func TestGetRankInScoreRange(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	for id, score := range map[string]float64{"u1": 2500, "u2": 2000, "u3": 1500, "u4": 1000, "u5": 500} {
+		lb.AddUser(User{ID: id, Score: score})
+	}
+	for id, want := range map[string]int{"u1": -1, "u2": 0, "u3": 1, "u4": 2, "u5": -1, "ghost": -1} {
+		if rank, err := lb.GetRankInScoreRange(id, 1000, 2000); err != nil || rank != want {
+			t.Errorf("%s: expected rank %d in [1000, 2000], got %d, err: %v", id, want, rank, err)
+		}
+	}
+	if rank, err := lb.GetRankInScoreRange("u1", 0, math.Inf(1)); err != nil || rank != 0 {
+		t.Errorf("expected u1 at 0 in an open window, got %d, err: %v", rank, err)
+	}
+	if _, err := lb.GetRankInScoreRange("u1", 2000, 1000); err == nil {
+		t.Error("expected error for inverted window")
+	}
+	if _, err := lb.GetRankInScoreRange("u1", math.NaN(), 1000); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("expected ErrInvalidScore, got %v", err)
+	}
+}
+
 func TestGetRankEntity(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test"})
 	defer lb.Close()
@@ -173,6 +653,26 @@ func TestGetRankEntity(t *testing.T) {
 	}
 }
 
+func TestRankAtScore(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 300})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 100})
+
+	for score, want := range map[float64]int64{400: 0, 300: 0, 250: 1, 200: 1, 50: 3} {
+		rank, err := lb.RankAtScore(score)
+		if err != nil || rank != want {
+			t.Errorf("RankAtScore(%v): expected %d, got %d, err: %v", score, want, rank, err)
+		}
+	}
+	rank, err := lb.RankAtScoreEntity("US", 150)
+	if err != nil || rank != 2 {
+		t.Errorf("RankAtScoreEntity: expected 2, got %d, err: %v", rank, err)
+	}
+}
+
 func TestGetUserScore(t *testing.T) {
 	lb := newTestLeaderboard(t, Config{Namespace: "test"})
 	defer lb.Close()
@@ -228,3 +728,693 @@ func TestForceClearLeaderBoardWithNamespacePrefix(t *testing.T) {
 		t.Errorf("expected all keys to be cleared, found %d keys: %v", len(keys), keys)
 	}
 }
+
+func TestForceClearNamespacePrefix(t *testing.T) {
+	lb1 := newTestLeaderboard(t, Config{Namespace: "game1", K: 5})
+	defer lb1.Close()
+	lb10 := newTestLeaderboard(t, Config{Namespace: "game10", K: 5})
+	defer lb10.Close()
+
+	if err := lb10.AddUser(User{ID: "u1", Entity: "US", Score: 10}); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+	lb1.ForceClearLeaderBoardWithNamespacePrefix()
+
+	if _, err := lb10.GetUserScore("u1"); err != nil {
+		t.Fatalf("expected game10 to survive clearing game1, got %v", err)
+	}
+	lb10.ForceClearLeaderBoardWithNamespacePrefix()
+}
+
+func TestScoreOverflow(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+
+	if err := lb.AddUser(User{ID: "u1", Score: 1 << 54}); !errors.Is(err, ErrScoreOverflow) {
+		t.Errorf("expected ErrScoreOverflow, got %v", err)
+	}
+	if err := lb.IncrementScore("u1", "", -(1 << 54)); !errors.Is(err, ErrScoreOverflow) {
+		t.Errorf("expected ErrScoreOverflow for increment, got %v", err)
+	}
+	if err := lb.AddUser(User{ID: "u1", Score: 1 << 53}); err != nil {
+		t.Fatalf("AddUser at 2^53 failed: %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 1<<53 {
+		t.Errorf("expected exact score %d, got %v", int64(1<<53), score)
+	}
+
+	floats := newTestLeaderboard(t, Config{Namespace: "test", K: 5, FloatScores: true})
+	defer floats.Close()
+	if err := floats.AddUser(User{ID: "u2", Score: 1 << 54}); err != nil {
+		t.Errorf("expected float scores to be unbounded, got %v", err)
+	}
+}
+
+func TestInvalidScore(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5, AllowNegativeScores: true, FloatScores: true})
+	defer lb.Close()
+
+	for _, score := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if err := lb.AddUser(User{ID: "u1", Entity: "US", Score: score}); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("AddUser(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+		if err := lb.IncrementScore("u1", "US", score); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("IncrementScore(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+		if err := lb.DecrementScore("u1", "US", score); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("DecrementScore(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+		if err := lb.AddUserMetric("u1", "US", "kills", score); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("AddUserMetric(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+		var batchErr *BatchError
+		if err := lb.IncrementScores([]ScoreUpdate{{UserID: "u1", Entity: "US", Delta: score}}); !errors.As(err, &batchErr) || !errors.Is(batchErr.Errors[0], ErrInvalidScore) {
+			t.Errorf("IncrementScores(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+		if err := lb.IncrementScoreWeighted("u1", "US", 1, score); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("IncrementScoreWeighted(%v): expected ErrInvalidScore, got %v", score, err)
+		}
+	}
+	if _, err := lb.RankAtScore(math.NaN()); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("RankAtScore(NaN): expected ErrInvalidScore, got %v", err)
+	}
+	if _, err := lb.GetUserScore("u1"); err == nil {
+		t.Error("expected no user written")
+	}
+}
+
+func TestAllowNegativeScores(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+	if err := lb.AddUser(User{ID: "u1", Score: -50}); err == nil {
+		t.Errorf("expected negative score to be rejected by default")
+	}
+
+	lb = newTestLeaderboard(t, Config{Namespace: "test", K: 5, AllowNegativeScores: true})
+	defer lb.Close()
+
+	for _, u := range []User{
+		{ID: "neg", Entity: "US", Score: -50},
+		{ID: "zero", Entity: "US", Score: 0},
+		{ID: "pos", Entity: "US", Score: 50},
+	} {
+		if err := lb.AddUser(u); err != nil {
+			t.Fatalf("AddUser %s failed: %v", u.ID, err)
+		}
+	}
+	if err := lb.IncrementScore("zero", "US", 0); err != nil {
+		t.Errorf("expected zero increment to be a no-op, got %v", err)
+	}
+
+	for id, want := range map[string]int{"pos": 0, "zero": 1, "neg": 2} {
+		rank, err := lb.GetRankGlobal(id)
+		if err != nil || rank != want {
+			t.Errorf("expected %s at global rank %d, got %d (err: %v)", id, want, rank, err)
+		}
+		rank, err = lb.GetRankEntity(id)
+		if err != nil || rank != want {
+			t.Errorf("expected %s at entity rank %d, got %d (err: %v)", id, want, rank, err)
+		}
+	}
+	if score, _ := lb.GetUserScore("neg"); score != -50 {
+		t.Errorf("expected score -50, got %v", score)
+	}
+}
+
+func TestZeroDeltaNoop(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+
+	if err := lb.IncrementScore("ghost", "US", 0); err != nil {
+		t.Errorf("expected zero increment to be a no-op, got %v", err)
+	}
+	if err := lb.DecrementScore("ghost", "US", 0); err != nil {
+		t.Errorf("expected zero decrement to be a no-op, got %v", err)
+	}
+	if err := lb.IncrementScores([]ScoreUpdate{{UserID: "ghost", Entity: "US"}}); err != nil {
+		t.Errorf("expected zero batch delta to be skipped, got %v", err)
+	}
+	if _, err := lb.GetUserScore("ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected zero deltas not to create the user, got %v", err)
+	}
+	if err := lb.IncrementScore("", "US", 0); err == nil {
+		t.Errorf("expected empty user ID to be rejected")
+	}
+}
+
+func TestGetTopKGlobalRanked(t *testing.T) {
+	for _, oneBased := range []bool{false, true} {
+		lb := newTestLeaderboard(t, Config{Namespace: "test", K: 3, OneBasedRanks: oneBased})
+		lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+		lb.AddUser(User{ID: "u2", Entity: "UK", Score: 300})
+		lb.AddUser(User{ID: "u3", Entity: "US", Score: 200})
+
+		ranked, err := lb.GetTopKGlobalRanked()
+		if err != nil {
+			t.Fatalf("GetTopKGlobalRanked failed: %v", err)
+		}
+		base := 0
+		if oneBased {
+			base = 1
+		}
+		for i, want := range []string{"u2", "u3", "u1"} {
+			if ranked[i].ID != want || ranked[i].Rank != base+i {
+				t.Errorf("oneBased=%v: expected %s at rank %d, got %s at %d", oneBased, want, base+i, ranked[i].ID, ranked[i].Rank)
+			}
+		}
+		if ranked[0].Entity != "UK" {
+			t.Errorf("expected embedded user entity UK, got %s", ranked[0].Entity)
+		}
+		lb.Close()
+	}
+}
+
+func TestGetRanks(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 300})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u4", Score: 50})
+
+	ids := []string{"u1", "u2", "u3", "u4", "missing"}
+	global, err := lb.GetRanksGlobal(ids)
+	if err != nil {
+		t.Fatalf("GetRanksGlobal failed: %v", err)
+	}
+	entity, err := lb.GetRanksEntity(ids)
+	if err != nil {
+		t.Fatalf("GetRanksEntity failed: %v", err)
+	}
+	for _, id := range ids {
+		wantGlobal, _ := lb.GetRankGlobal(id)
+		wantEntity, _ := lb.GetRankEntity(id)
+		if global[id] != wantGlobal {
+			t.Errorf("expected global rank %d for %s, got %d", wantGlobal, id, global[id])
+		}
+		if entity[id] != wantEntity {
+			t.Errorf("expected entity rank %d for %s, got %d", wantEntity, id, entity[id])
+		}
+	}
+	if global["missing"] != -1 || entity["u4"] != -1 {
+		t.Errorf("expected -1 for missing users and users without entity, got %d and %d", global["missing"], entity["u4"])
+	}
+}
+
+func BenchmarkGetRanksGlobal(b *testing.B) {
+	lb, err := New(Config{Namespace: "bench", RedisAddr: testRedisAddr})
+	if err != nil {
+		b.Skipf("redis unavailable: %v", err)
+	}
+	defer lb.Close()
+	lb.ForceClearLeaderBoardWithNamespacePrefix()
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("u%d", i)
+		lb.AddUser(User{ID: ids[i], Entity: "US", Score: float64(i)})
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, id := range ids {
+				if _, err := lb.GetRankGlobal(id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := lb.GetRanksGlobal(ids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestReplicaReads(t *testing.T) {
+	// the test Redis doubles as its own "replica"; hooks tell the routes apart
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5, ReplicaAddr: testRedisAddr})
+	defer lb.Close()
+	primary, replica := &countingHook{}, &countingHook{}
+	rawClient(lb).AddHook(primary)
+	lb.reader.(redis.UniversalClient).AddHook(replica)
+
+	if err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 100}); err != nil {
+		t.Fatalf("AddUser failed: %v", err)
+	}
+	if n := replica.calls.Load(); n != 0 {
+		t.Errorf("expected writes to skip the replica, got %d replica calls", n)
+	}
+
+	writes := primary.calls.Load()
+	lb.GetTopKGlobal()
+	lb.GetTopKEntity("US")
+	lb.GetRankGlobal("u1")
+	lb.GetRankEntity("u1")
+	if score, err := lb.GetUserScore("u1"); err != nil || score != 100 {
+		t.Errorf("expected score 100 from replica, got %v (err: %v)", score, err)
+	}
+	if n := primary.calls.Load(); n != writes {
+		t.Errorf("expected reads to skip the primary, got %d primary calls", n-writes)
+	}
+	if replica.calls.Load() == 0 {
+		t.Errorf("expected reads to hit the replica")
+	}
+}
+
+func TestReplicaUnavailable(t *testing.T) {
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, ReplicaAddr: "localhost:1"}); err == nil {
+		t.Errorf("expected unreachable replica to fail New")
+	}
+}
+
+func TestGetUserLeaderboardDataForEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 300})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 200})
+
+	data, err := lb.GetUserLeaderboardDataForEntity("u1", "UK")
+	if err != nil {
+		t.Fatalf("GetUserLeaderboardDataForEntity failed: %v", err)
+	}
+	if data.Entity != "" || data.EntityRank != -1 || len(data.TopKEntity) != 2 || data.TopKEntity[0].ID != "u2" {
+		t.Errorf("unexpected data for user outside entity: %+v", data)
+	}
+
+	data, err = lb.GetUserLeaderboardDataForEntity("u3", "UK")
+	if err != nil || data.EntityRank != 1 || data.TopKEntity[1].Entity != "UK" {
+		t.Errorf("unexpected data for entity member: %+v (err: %v)", data, err)
+	}
+	if _, err := lb.GetUserLeaderboardDataForEntity("u1", "bad entity"); !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("expected ErrInvalidEntity, got %v", err)
+	}
+}
+
+func TestGetUserLeaderboardDataWithEntities(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 5})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 300})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u4", Entity: "FR", Score: 50})
+
+	data, err := lb.GetUserLeaderboardDataWithEntities("u1", []string{"UK", "FR", "DE"})
+	if err != nil {
+		t.Fatalf("GetUserLeaderboardDataWithEntities failed: %v", err)
+	}
+	if data.Entity != "US" || data.EntityRank != 0 || len(data.TopKEntity) != 1 || data.GlobalRank != 2 {
+		t.Errorf("unexpected own data: %+v", data)
+	}
+	if uk := data.TopKEntities["UK"]; len(uk) != 2 || uk[0].ID != "u2" || uk[1].Entity != "UK" {
+		t.Errorf("unexpected UK top-k: %+v", uk)
+	}
+	if fr := data.TopKEntities["FR"]; len(fr) != 1 || fr[0].ID != "u4" {
+		t.Errorf("unexpected FR top-k: %+v", fr)
+	}
+	if de, ok := data.TopKEntities["DE"]; !ok || len(de) != 0 {
+		t.Errorf("expected empty DE top-k, got %+v (present: %v)", de, ok)
+	}
+
+	if data, err := lb.GetUserLeaderboardDataWithEntities("u1", nil); err != nil || data.TopKEntities != nil {
+		t.Errorf("expected no extra entities, got %+v, %v", data.TopKEntities, err)
+	}
+	if _, err := lb.GetUserLeaderboardDataWithEntities("u1", []string{"bad entity"}); !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("expected ErrInvalidEntity, got %v", err)
+	}
+}
+
+func TestGetUserScoreRounded(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", FloatScores: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 0})
+	lb.AddUser(User{ID: "u2", Score: 100})
+	for i := 0; i < 1000; i++ {
+		lb.IncrementScore("u1", "", 0.1)
+	}
+
+	raw, _ := lb.GetUserScore("u1")
+	if raw == 100 {
+		t.Fatalf("expected float drift from accumulated increments, got exactly %v", raw)
+	}
+	score, err := lb.GetUserScoreRounded("u1", 2)
+	if err != nil || score != 100 {
+		t.Errorf("expected 100, got %v (err: %v)", score, err)
+	}
+	if score, _ := lb.GetUserScoreRounded("u1", 0); score != 100 {
+		t.Errorf("expected 100 with 0 decimals, got %v", score)
+	}
+
+	// ranking keeps full precision: the drifted score doesn't tie with u2
+	if raw2, _ := lb.GetUserScore("u1"); raw2 != raw {
+		t.Errorf("expected stored score %v untouched, got %v", raw, raw2)
+	}
+	rank, _ := lb.GetRankGlobal("u1")
+	want := 0
+	if raw < 100 {
+		want = 1
+	}
+	if rank != want {
+		t.Errorf("expected u1 at rank %d with raw score %v, got %d", want, raw, rank)
+	}
+
+	if _, err := lb.GetUserScoreRounded("u1", -1); err == nil {
+		t.Error("expected error for negative decimals")
+	}
+	if _, err := lb.GetUserScoreRounded("nobody", 2); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	// non-routable address: the dial hangs instead of being refused
+	start := time.Now()
+	_, err := New(Config{Namespace: "test", RedisAddr: "10.255.255.1:6379", ConnectTimeout: 200 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected unreachable Redis to fail New")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected New to give up after ConnectTimeout, took %v", elapsed)
+	}
+	if !strings.Contains(err.Error(), "10.255.255.1:6379") {
+		t.Errorf("expected error to name the address, got %v", err)
+	}
+}
+
+func TestGetTopKMinScore(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 500})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 1500})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 1000})
+	lb.AddUser(User{ID: "u4", Entity: "US", Score: 2000})
+
+	users, err := lb.GetTopKGlobalMinScore(1000)
+	if err != nil {
+		t.Fatalf("GetTopKGlobalMinScore: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != "u4" || users[1].ID != "u2" || users[1].Entity != "US" {
+		t.Errorf("expected u4, u2 capped at K, got %+v", users)
+	}
+	if users, _ := lb.GetTopKGlobalMinScore(1800); len(users) != 1 || users[0].ID != "u4" {
+		t.Errorf("expected only u4 above 1800, got %+v", users)
+	}
+	if users, err := lb.GetTopKGlobalMinScore(5000); err != nil || users == nil || len(users) != 0 {
+		t.Errorf("expected empty slice, got %+v, %v", users, err)
+	}
+
+	users, err = lb.GetTopKEntityMinScore("UK", 1000)
+	if err != nil || len(users) != 1 || users[0].ID != "u3" || users[0].Entity != "UK" {
+		t.Errorf("expected inclusive bound to keep u3, got %+v, %v", users, err)
+	}
+	if _, err := lb.GetTopKEntityMinScore("US", math.NaN()); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("expected ErrInvalidScore, got %v", err)
+	}
+}
+
+func TestGetTopKEntities(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2, EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"name": "Ann"}})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 300})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u4", Entity: "UK", Score: 50})
+
+	top, err := lb.GetTopKEntities([]string{"US", "UK", "FR", "US"})
+	if err != nil {
+		t.Fatalf("GetTopKEntities: %v", err)
+	}
+	if len(top) != 3 {
+		t.Fatalf("expected 3 entities, got %+v", top)
+	}
+	if us := top["US"]; len(us) != 2 || us[0].ID != "u2" || us[1].ID != "u3" || us[0].Entity != "US" {
+		t.Errorf("expected US top-2 [u2 u3], got %+v", us)
+	}
+	if uk := top["UK"]; len(uk) != 1 || uk[0].ID != "u4" {
+		t.Errorf("expected UK top [u4], got %+v", uk)
+	}
+	if fr, ok := top["FR"]; !ok || fr == nil || len(fr) != 0 {
+		t.Errorf("expected empty slice for FR, got %#v", fr)
+	}
+
+	// matches GetTopKEntity, metadata included
+	lb.AddUser(User{ID: "u5", Entity: "UK", Score: 10, Metadata: map[string]string{"name": "Bo"}})
+	top, _ = lb.GetTopKEntities([]string{"UK"})
+	single, _ := lb.GetTopKEntity("UK")
+	if len(top["UK"]) != len(single) || top["UK"][1].Metadata["name"] != "Bo" {
+		t.Errorf("expected %+v, got %+v", single, top["UK"])
+	}
+
+	if _, err := lb.GetTopKEntities([]string{"US", ""}); err == nil {
+		t.Error("expected error for empty entity")
+	}
+
+	// ties follow TieBreakField, also in the cache GetTopKEntity shares
+	tied := newTestLeaderboard(t, Config{Namespace: "test2", EnableMetadata: true, TieBreakField: "joined", TopKCacheTTL: time.Minute})
+	defer tied.Close()
+	defer tied.ForceClearLeaderBoardWithNamespacePrefix()
+	tied.AddUser(User{ID: "b", Entity: "US", Score: 100, Metadata: map[string]string{"joined": "2024-03-01"}})
+	tied.AddUser(User{ID: "a", Entity: "US", Score: 100, Metadata: map[string]string{"joined": "2024-01-15"}})
+	top, err = tied.GetTopKEntities([]string{"US"})
+	if err != nil || len(top["US"]) != 2 || top["US"][0].ID != "a" {
+		t.Errorf("expected US ties [a b], got %+v, %v", top["US"], err)
+	}
+	if single, _ := tied.GetTopKEntity("US"); len(single) != 2 || single[0].ID != "a" {
+		t.Errorf("expected cached US ties [a b], got %+v", single)
+	}
+}
+
+func TestGetTopKWithUser(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", K: 2, OneBasedRanks: true, EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 300})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 100, Metadata: map[string]string{"name": "Cy"}})
+
+	topK, self, err := lb.GetTopKWithUser("u3")
+	if err != nil {
+		t.Fatalf("GetTopKWithUser: %v", err)
+	}
+	if len(topK) != 2 || topK[0].ID != "u1" || topK[1].ID != "u2" {
+		t.Errorf("expected top-k [u1 u2], got %+v", topK)
+	}
+	if self.ID != "u3" || self.Rank != 3 || self.Score != 100 || self.Entity != "UK" || self.InTopK || self.Metadata["name"] != "Cy" {
+		t.Errorf("unexpected self row: %+v", self)
+	}
+
+	_, self, _ = lb.GetTopKWithUser("u2")
+	if self.Rank != 2 || !self.InTopK {
+		t.Errorf("expected u2 at rank 2 within top-k, got %+v", self)
+	}
+
+	topK, self, err = lb.GetTopKWithUser("nobody")
+	if err != nil || len(topK) != 2 || self.Rank != -1 || self.InTopK {
+		t.Errorf("expected unranked self with top-k, got %+v %+v (err: %v)", topK, self, err)
+	}
+	if _, _, err := lb.GetTopKWithUser(""); err == nil {
+		t.Error("expected error for empty user ID")
+	}
+}
+
+func TestPrimaryEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", PrimaryEntity: "EU"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "UK", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "EU", Score: 300})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 200})
+	// u1 also ranked in EU (e.g., incremented there), mapping still says UK
+	lb.client.ZAdd(lb.ctx, lb.entityKey("EU"), redis.Z{Score: 100, Member: "u1"})
+
+	if rank, _ := lb.GetRankEntity("u1"); rank != 1 {
+		t.Errorf("expected u1 ranked 1 in primary EU, got %d", rank)
+	}
+	if rank, _ := lb.GetRankEntity("u3"); rank != 0 {
+		t.Errorf("expected u3 outside EU to fall back to UK rank 0, got %d", rank)
+	}
+	ranks, _ := lb.GetRanksEntity([]string{"u1", "u3", "nobody"})
+	if ranks["u1"] != 1 || ranks["u3"] != 0 || ranks["nobody"] != -1 {
+		t.Errorf("unexpected ranks: %v", ranks)
+	}
+
+	data, _ := lb.GetUserLeaderboardData("u1")
+	if data.Entity != "UK" || data.EntityRank != 1 || len(data.TopKEntity) != 2 || data.TopKEntity[0].ID != "u2" {
+		t.Errorf("expected EU view with mapped entity UK, got %+v", data)
+	}
+
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, PrimaryEntity: "bad entity"}); !errors.Is(err, ErrInvalidEntity) {
+		t.Errorf("expected ErrInvalidEntity for invalid PrimaryEntity, got %v", err)
+	}
+}
+
+func TestIncrementScoreWeighted(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if err := lb.IncrementScoreWeighted("u1", "US", 15, 2); err != nil {
+		t.Fatalf("IncrementScoreWeighted: %v", err)
+	}
+	// 10 * 1.55 = 15.5, rounded like any integer increment
+	lb.IncrementScoreWeighted("u1", "US", 10, 1.55)
+	if score, _ := lb.GetUserScore("u1"); score != 145 {
+		t.Errorf("expected 145, got %v", score)
+	}
+	if members, _ := lb.GetTopKEntity("US"); len(members) != 1 || members[0].Score != 145 {
+		t.Errorf("expected entity ranking updated, got %+v", members)
+	}
+
+	for _, weight := range []float64{math.NaN(), math.Inf(1)} {
+		if err := lb.IncrementScoreWeighted("u1", "US", 10, weight); err == nil {
+			t.Errorf("expected error for weight %v", weight)
+		}
+	}
+	if err := lb.IncrementScoreWeighted("u1", "US", math.MaxFloat64, 2); err == nil {
+		t.Error("expected error for overflowing product")
+	}
+}
+
+func TestGetUserEntities(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Score: 20})
+
+	entities, err := lb.GetUserEntities([]string{"u1", "u2", "ghost"})
+	if err != nil {
+		t.Fatalf("GetUserEntities: %v", err)
+	}
+	if len(entities) != 2 || entities["u1"] != "US" || entities["u2"] != "" {
+		t.Errorf("unexpected entities: %v", entities)
+	}
+	if _, ok := entities["ghost"]; ok {
+		t.Error("expected unknown user omitted")
+	}
+	if entities, err := lb.GetUserEntities(nil); err != nil || len(entities) != 0 {
+		t.Errorf("expected empty map, got %v, %v", entities, err)
+	}
+}
+
+func TestRequireExistingUser(t *testing.T) {
+	lenient := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lenient.Close()
+	if err := lenient.IncrementScore("ghost", "US", 10); err != nil {
+		t.Fatalf("expected default IncrementScore to create the user, got %v", err)
+	}
+	if score, _ := lenient.GetUserScore("ghost"); score != 10 {
+		t.Errorf("expected created user at 10, got %v", score)
+	}
+
+	for _, inMember := range []bool{false, true} {
+		lb := newTestLeaderboard(t, Config{Namespace: "test", RequireExistingUser: true, EntityInMember: inMember})
+
+		if err := lb.IncrementScore("ghost", "US", 10); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("inMember=%v: expected ErrUserNotFound, got %v", inMember, err)
+		}
+		if err := lb.DecrementScore("ghost", "US", 10); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("inMember=%v: expected ErrUserNotFound for decrement, got %v", inMember, err)
+		}
+		if _, err := lb.GetUserScore("ghost"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("inMember=%v: expected ghost not created, got %v", inMember, err)
+		}
+		if entity, _ := lb.GetUserEntity("ghost"); entity != "" {
+			t.Errorf("inMember=%v: expected no mapping for ghost, got %q", inMember, entity)
+		}
+
+		lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+		if err := lb.IncrementScore("u1", "US", 20); err != nil {
+			t.Fatalf("inMember=%v: IncrementScore: %v", inMember, err)
+		}
+		if err := lb.DecrementScore("u1", "US", 5); err != nil {
+			t.Fatalf("inMember=%v: DecrementScore: %v", inMember, err)
+		}
+		if score, _ := lb.GetUserScore("u1"); score != 115 {
+			t.Errorf("inMember=%v: expected 115, got %v", inMember, score)
+		}
+		if top, _ := lb.GetTopKEntity("US"); len(top) != 1 || top[0].Score != 115 {
+			t.Errorf("inMember=%v: expected entity ranking updated, got %+v", inMember, top)
+		}
+		if top, _ := lb.GetTopKGlobal(); len(top) != 1 || top[0].Entity != "US" {
+			t.Errorf("inMember=%v: unexpected global ranking %+v", inMember, top)
+		}
+		lb.Close()
+	}
+}
+
+func TestUserNames(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableNames: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "7f3a", Entity: "US", Score: 200, Name: "Alice"})
+	lb.AddUser(User{ID: "9c1e", Entity: "US", Score: 100})
+
+	global, err := lb.GetTopKGlobal()
+	if err != nil || len(global) != 2 || global[0].Name != "Alice" || global[1].Name != "" {
+		t.Errorf("unexpected global names: %+v, %v", global, err)
+	}
+	if entity, _ := lb.GetTopKEntity("US"); len(entity) != 2 || entity[0].Name != "Alice" {
+		t.Errorf("unexpected entity names: %+v", entity)
+	}
+	data, err := lb.GetUserLeaderboardData("7f3a")
+	if err != nil || data.Name != "Alice" || data.TopKEntity[0].Name != "Alice" {
+		t.Errorf("unexpected leaderboard data: %+v, %v", data, err)
+	}
+
+	// updating without a name keeps the stored one
+	lb.AddUser(User{ID: "7f3a", Entity: "US", Score: 300})
+	if data, _ := lb.GetUserLeaderboardData("7f3a"); data.Name != "Alice" {
+		t.Errorf("expected name kept, got %q", data.Name)
+	}
+	lb.RemoveUser("7f3a")
+	if n, _ := rawClient(lb).HExists(lb.ctx, lb.namesKey(), "7f3a").Result(); n {
+		t.Error("expected name removed with the user")
+	}
+
+	plain := newTestLeaderboard(t, Config{Namespace: "test2"})
+	defer plain.Close()
+	defer plain.ForceClearLeaderBoardWithNamespacePrefix()
+	plain.AddUser(User{ID: "u1", Score: 10, Name: "Bob"})
+	if top, _ := plain.GetTopKGlobal(); len(top) != 1 || top[0].Name != "" {
+		t.Errorf("expected names ignored without EnableNames, got %+v", top)
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", KeyPrefix: "prod:"})
+	defer lb.Close()
+	plain := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer plain.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if n, _ := lb.client.Exists(lb.ctx, "prod:test:global", "prod:test:entity:US").Result(); n != 2 {
+		t.Errorf("expected prefixed keys, found %d", n)
+	}
+	if _, err := plain.GetUserScore("u1"); err == nil {
+		t.Error("expected unprefixed board not to see u1")
+	}
+
+	s2024, err := lb.Season("2024")
+	if err != nil {
+		t.Fatalf("Season: %v", err)
+	}
+	defer s2024.Close()
+	s2024.AddUser(User{ID: "u2", Score: 1})
+	if seasons, err := lb.ListSeasons(); err != nil || !reflect.DeepEqual(seasons, []string{"2024"}) {
+		t.Errorf("expected season 2024, got %v, %v", seasons, err)
+	}
+
+	lb.ForceClearLeaderBoardWithNamespacePrefix()
+	if n, _ := lb.client.Exists(lb.ctx, "prod:test:global", "prod:test:s2024:global").Result(); n != 0 {
+		t.Errorf("expected prefixed keys cleared, %d left", n)
+	}
+}
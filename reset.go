@@ -0,0 +1,98 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ResetScores starts a new season on the same board.
+// With keepMembers, every score of the global and entity rankings (and of
+// every metric board) is set to 0 in chunks of Config.BatchSize: users stay
+// ranked, with their entity mapping and metadata, e.g. to keep streaks or
+// history.
+// Otherwise the rankings are deleted along with the entity mapping,
+// metadata, names, activity records and best ranks, leaving an empty
+// board; unlike ForceClearLeaderBoardWithNamespacePrefix, metric names,
+// archives and other namespace state are kept.
+// Not atomic: writes during the reset may keep their score. Users removed
+// concurrently are not re-added.
+// Returns error if:
+// - entities are sharded (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) ResetScores(keepMembers bool) (err error) {
+	defer lb.startOp("ResetScores", "", "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	defer lb.topKCache.invalidate()
+
+	metrics, err := lb.client.SMembers(lb.ctx, lb.metricsKey()).Result()
+	if err != nil {
+		return fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+	metrics = append([]string{""}, metrics...) // default board first
+
+	var keys []string
+	keys = append(keys, lb.globalKeys()...)
+	for _, metric := range metrics {
+		if metric != "" {
+			keys = append(keys, lb.metricGlobalKey(metric))
+		}
+		iter := lb.client.Scan(lb.ctx, 0, lb.metricEntityKey(metric, "")+"*", 0).Iterator()
+		for iter.Next(lb.ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("failed to scan entities: %w", err)
+		}
+	}
+	if lb.config.EntityTotals {
+		keys = append(keys, lb.entityTotalsKey())
+	}
+
+	if !keepMembers {
+		keys = append(keys, lb.entitiesKey(), lb.metaKey(), lb.namesKey(), lb.activityKey(), lb.entityCodesKey(), lb.bestRanksKey())
+		lb.knownEntities.Clear()
+		for start := 0; start < len(keys); start += lb.config.BatchSize {
+			end := min(start+lb.config.BatchSize, len(keys))
+			if err := lb.client.Del(lb.ctx, keys[start:end]...).Err(); err != nil {
+				return fmt.Errorf("failed to delete rankings: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		if err := lb.zeroScores(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zeroScores sets every score of the ranking at key to 0, one ZSCAN batch
+// per pipeline. ZADD XX never re-adds members removed since the scan.
+func (lb *Leaderboard) zeroScores(key string) error {
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.ZScan(lb.ctx, key, cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", key, err)
+		}
+		if len(keys) > 0 {
+			members := make([]redis.Z, 0, len(keys)/2)
+			for i := 0; i < len(keys); i += 2 {
+				members = append(members, redis.Z{Score: 0, Member: keys[i]})
+			}
+			if err := lb.client.ZAddXX(lb.ctx, key, members...).Err(); err != nil {
+				return fmt.Errorf("failed to reset %s: %w", key, conflictErr(err))
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package redisboard
+
+import "testing"
+
+func TestResetScoresKeepMembers(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"streak": "7"}})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 200})
+	lb.AddUser(User{ID: "u3", Score: 300})
+	lb.AddUserMetric("u1", "US", "kills", 12)
+
+	if err := lb.ResetScores(true); err != nil {
+		t.Fatalf("ResetScores: %v", err)
+	}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if score, err := lb.GetUserScore(id); err != nil || score != 0 {
+			t.Errorf("expected %s kept at 0, got %v, %v", id, score, err)
+		}
+	}
+	if top, _ := lb.GetTopKEntity("US"); len(top) != 1 || top[0].ID != "u1" || top[0].Score != 0 {
+		t.Errorf("expected u1 kept in US at 0, got %+v", top)
+	}
+	if top, _ := lb.GetTopKMetric("kills"); len(top) != 1 || top[0].Score != 0 {
+		t.Errorf("expected metric board reset, got %+v", top)
+	}
+	data, _ := lb.GetUserLeaderboardData("u1")
+	if data.Entity != "US" || data.Metadata["streak"] != "7" {
+		t.Errorf("expected mapping and metadata kept, got %+v", data)
+	}
+}
+
+func TestResetScoresDelete(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Score: 200})
+
+	if err := lb.ResetScores(false); err != nil {
+		t.Fatalf("ResetScores: %v", err)
+	}
+	if _, err := lb.GetTopKGlobal(); err == nil {
+		t.Error("expected empty global ranking")
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "" {
+		t.Errorf("expected mapping deleted, got %q", entity)
+	}
+	if report, err := lb.Verify(); err != nil || !report.OK() {
+		t.Errorf("expected consistent empty board, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,134 @@
+package redisboard
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// userState is a user's stored state ahead of a write, for
+// RollbackPartialWrites to restore. Absent values are recorded as such, so
+// restoring deletes what the write created.
+type userState struct {
+	entity       string
+	mapped       bool // the entity mapping exists
+	score        float64
+	ranked       bool               // ranked globally
+	entityScores map[string]float64 // scores in the rankings of the old and new entity, if ranked there
+	meta, name   string
+	hasMeta      bool
+	hasName      bool
+	activity     float64
+	active       bool
+}
+
+// snapshotUser reads the state of userID that an AddUser moving them to
+// entity would overwrite.
+func (lb *Leaderboard) snapshotUser(userID, entity string) (userState, error) {
+	var state userState
+	pipe := lb.client.Pipeline()
+	entityCmd := pipe.HGet(lb.ctx, lb.entitiesKey(), userID)
+	var metaCmd, nameCmd *redis.StringCmd
+	if lb.config.EnableMetadata {
+		metaCmd = pipe.HGet(lb.ctx, lb.metaKey(), userID)
+	}
+	if lb.config.EnableNames {
+		nameCmd = pipe.HGet(lb.ctx, lb.namesKey(), userID)
+	}
+	var activityCmd *redis.FloatCmd
+	if lb.config.TrackActivity {
+		activityCmd = pipe.ZScore(lb.ctx, lb.activityKey(), userID)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return state, err
+	}
+	state.entity, state.mapped = entityCmd.Val(), entityCmd.Err() == nil
+	if metaCmd != nil {
+		state.meta, state.hasMeta = metaCmd.Val(), metaCmd.Err() == nil
+	}
+	if nameCmd != nil {
+		state.name, state.hasName = nameCmd.Val(), nameCmd.Err() == nil
+	}
+	if activityCmd != nil {
+		state.activity, state.active = activityCmd.Val(), activityCmd.Err() == nil
+	}
+
+	pipe = lb.client.Pipeline()
+	scoreCmd := pipe.ZScore(lb.ctx, lb.userGlobalKey(userID), lb.memberFor(userID, state.entity))
+	entityCmds := make(map[string]*redis.FloatCmd)
+	for _, e := range userEntities(state.entity, entity) {
+		entityCmds[e] = pipe.ZScore(lb.ctx, lb.entityKey(e), userID)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return state, err
+	}
+	state.score, state.ranked = scoreCmd.Val(), scoreCmd.Err() == nil
+	state.entityScores = make(map[string]float64)
+	for e, cmd := range entityCmds {
+		if cmd.Err() == nil {
+			state.entityScores[e] = cmd.Val()
+		}
+	}
+	return state, nil
+}
+
+// restoreUser writes back the state snapshotUser read before an AddUser of
+// user that only partly applied, undoing the commands that succeeded.
+func (lb *Leaderboard) restoreUser(state userState, user User) error {
+	pipe := lb.client.Pipeline()
+	globalKey := lb.userGlobalKey(user.ID)
+	oldMember, newMember := lb.memberFor(user.ID, state.entity), lb.memberFor(user.ID, user.Entity)
+	if !state.ranked || newMember != oldMember {
+		pipe.ZRem(lb.ctx, globalKey, newMember)
+	}
+	if state.ranked {
+		pipe.ZAdd(lb.ctx, globalKey, redis.Z{Score: state.score, Member: oldMember})
+	}
+	if state.mapped {
+		pipe.HSet(lb.ctx, lb.entitiesKey(), user.ID, state.entity)
+	} else {
+		pipe.HDel(lb.ctx, lb.entitiesKey(), user.ID)
+	}
+	for _, e := range userEntities(state.entity, user.Entity) {
+		if score, ok := state.entityScores[e]; ok {
+			lb.entityWrite(pipe, entitySet, e, user.ID, score)
+		} else {
+			lb.entityWrite(pipe, entityRem, e, user.ID, 0)
+		}
+	}
+	if lb.config.EnableMetadata {
+		lb.restoreField(pipe, lb.metaKey(), user.ID, state.meta, state.hasMeta)
+	}
+	if lb.config.EnableNames {
+		lb.restoreField(pipe, lb.namesKey(), user.ID, state.name, state.hasName)
+	}
+	if lb.config.TrackActivity {
+		if state.active {
+			pipe.ZAdd(lb.ctx, lb.activityKey(), redis.Z{Score: state.activity, Member: user.ID})
+		} else {
+			pipe.ZRem(lb.ctx, lb.activityKey(), user.ID)
+		}
+	}
+	_, err := pipe.Exec(lb.ctx)
+	return err
+}
+
+// restoreField queues setting field of the hash at key back to value, or
+// deleting it if it was absent.
+func (lb *Leaderboard) restoreField(pipe redis.Pipeliner, key, field, value string, present bool) {
+	if present {
+		pipe.HSet(lb.ctx, key, field, value)
+	} else {
+		pipe.HDel(lb.ctx, key, field)
+	}
+}
+
+// userEntities returns the distinct non-empty entities among old and new.
+func userEntities(old, new string) []string {
+	var entities []string
+	if old != "" {
+		entities = append(entities, old)
+	}
+	if new != "" && new != old {
+		entities = append(entities, new)
+	}
+	return entities
+}
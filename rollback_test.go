@@ -0,0 +1,99 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// failNthHook fails the nth command (0-based) of the first pipeline whose
+// first command is first, without sending it.
+type failNthHook struct {
+	first string
+	n     int
+	fired bool
+}
+
+func (*failNthHook) DialHook(next redis.DialHook) redis.DialHook          { return next }
+func (*failNthHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (h *failNthHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if h.fired || len(cmds) <= h.n || cmds[0].Name() != h.first {
+			return next(ctx, cmds)
+		}
+		h.fired = true
+		injected := errors.New("injected failure")
+		sent := append(append([]redis.Cmder{}, cmds[:h.n]...), cmds[h.n+1:]...)
+		if err := next(ctx, sent); err != nil {
+			return err
+		}
+		cmds[h.n].SetErr(injected)
+		return injected
+	}
+}
+
+func TestRollbackPartialWrites(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true, RollbackPartialWrites: true})
+	defer lb.Close()
+
+	// a new user: the mapping went through, the global ZADD failed
+	rawClient(lb).AddHook(&failNthHook{first: "hset", n: 1})
+	err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 100, Metadata: map[string]string{"tier": "gold"}})
+	if err == nil || errors.Is(err, ErrPartialWrite) {
+		t.Fatalf("expected a rolled back failure, got %v", err)
+	}
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Entity != "" || data.GlobalRank != -1 || data.EntityRank != -1 {
+		t.Errorf("expected u1 fully reverted, got %+v", data)
+	}
+	if meta, _ := rawClient(lb).HExists(lb.ctx, lb.metaKey(), "u1").Result(); meta {
+		t.Error("expected u1's metadata to be reverted")
+	}
+
+	// an existing user keeps their old score, entity and metadata
+	if err := lb.AddUser(User{ID: "u2", Entity: "US", Score: 50, Metadata: map[string]string{"tier": "silver"}}); err != nil {
+		t.Fatal(err)
+	}
+	rawClient(lb).AddHook(&failNthHook{first: "hset", n: 1})
+	if err := lb.AddUser(User{ID: "u2", Entity: "IN", Score: 100, Metadata: map[string]string{"tier": "gold"}}); err == nil {
+		t.Fatal("expected the write to fail")
+	}
+	data, err = lb.GetUserLeaderboardData("u2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Entity != "US" || data.Score != 50 || data.EntityRank != 0 {
+		t.Errorf("expected u2 back in US with 50, got %+v", data)
+	}
+	if !reflect.DeepEqual(data.Metadata, map[string]string{"tier": "silver"}) {
+		t.Errorf("expected u2's old metadata, got %v", data.Metadata)
+	}
+	if users, _ := lb.GetTopKEntity("IN"); len(users) != 0 {
+		t.Errorf("expected IN ranking reverted, got %v", users)
+	}
+	if report, _ := lb.Verify(); report.MissingEntityMembers != 0 {
+		t.Errorf("expected consistent rankings, got %+v", report)
+	}
+}
+
+func TestPartialWriteWithoutRollback(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	rawClient(lb).AddHook(&failNthHook{first: "hset", n: 1})
+	err := lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if !errors.Is(err, ErrPartialWrite) {
+		t.Fatalf("expected ErrPartialWrite, got %v", err)
+	}
+	// the mapping and entity ranking stay applied
+	if entity, _ := lb.client.HGet(lb.ctx, lb.entitiesKey(), "u1").Result(); entity != "US" {
+		t.Errorf("expected mapping to stay applied, got %q", entity)
+	}
+}
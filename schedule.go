@@ -0,0 +1,136 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errResetStopped is the Err of a ScheduledReset stopped before it ran.
+var errResetStopped = errors.New("scheduled reset stopped")
+
+// ScheduledReset is a reset pending on a timer, see ScheduleReset.
+type ScheduledReset struct {
+	At time.Time // when the reset runs
+
+	scheduler *resetScheduler
+	timer     *time.Timer
+	done      chan struct{}
+	err       error
+}
+
+// Done is closed once the reset ran, was stopped or its leaderboard closed.
+func (r *ScheduledReset) Done() <-chan struct{} {
+	return r.done
+}
+
+// Err returns the reset's error once Done is closed: nil if it succeeded,
+// the ResetScores error, an error if stopped, or ErrClosed if the
+// leaderboard closed first.
+func (r *ScheduledReset) Err() error {
+	<-r.done
+	return r.err
+}
+
+// Stop cancels the reset, reporting false if it already ran, is running or
+// was stopped.
+func (r *ScheduledReset) Stop() bool {
+	if !r.scheduler.take(r) {
+		return false
+	}
+	r.timer.Stop()
+	r.scheduler.finish(r, errResetStopped)
+	return true
+}
+
+// resetScheduler tracks a leaderboard's pending resets, so Close can stop
+// them and wait for one already running.
+type resetScheduler struct {
+	mu      sync.Mutex
+	pending map[*ScheduledReset]struct{}
+	closed  bool
+	running sync.WaitGroup
+}
+
+func newResetScheduler() *resetScheduler {
+	return &resetScheduler{pending: make(map[*ScheduledReset]struct{})}
+}
+
+// add arms r's timer to call run after d, reporting false once closed.
+func (s *resetScheduler) add(r *ScheduledReset, d time.Duration, run func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.pending[r] = struct{}{}
+	s.running.Add(1)
+	r.timer = time.AfterFunc(d, run)
+	return true
+}
+
+// take removes r from the pending resets, reporting whether it was there:
+// exactly one of the timer, Stop and close gets to finish r.
+func (s *resetScheduler) take(r *ScheduledReset) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[r]; !ok {
+		return false
+	}
+	delete(s.pending, r)
+	return true
+}
+
+// finish records r's outcome.
+func (s *resetScheduler) finish(r *ScheduledReset, err error) {
+	r.err = err
+	close(r.done)
+	s.running.Done()
+}
+
+// close fails the pending resets with ErrClosed and waits for running ones.
+func (s *resetScheduler) close() {
+	s.mu.Lock()
+	s.closed = true
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	for r := range pending {
+		r.timer.Stop()
+		s.finish(r, ErrClosed)
+	}
+	s.running.Wait()
+}
+
+// ScheduleReset empties the board with ResetScores(false) at a wall-clock
+// time, e.g. when a weekly event ends; a time in the past resets right
+// away. fn, if not nil, runs first, e.g. to archive the final standings.
+// For recurring resets, schedule the next one from fn.
+// The timer runs in this process only: every replica scheduling the same
+// reset runs it, and none runs it if the process stops. Schedule it on one
+// instance (e.g. an elected leader), or use external scheduling. Close
+// cancels pending resets, waiting for one already running.
+// Returns error if:
+// - at is zero
+// - the leaderboard is closed (ErrClosed)
+func (lb *Leaderboard) ScheduleReset(at time.Time, fn func()) (_ *ScheduledReset, err error) {
+	defer lb.startOp("ScheduleReset", "", "").end(&err)
+	if at.IsZero() {
+		return nil, fmt.Errorf("invalid reset time")
+	}
+	r := &ScheduledReset{At: at, scheduler: lb.resets, done: make(chan struct{})}
+	run := func() {
+		if !lb.resets.take(r) {
+			return // stopped meanwhile
+		}
+		if fn != nil {
+			fn()
+		}
+		lb.resets.finish(r, lb.ResetScores(false))
+	}
+	if !lb.resets.add(r, time.Until(at), run) {
+		return nil, ErrClosed
+	}
+	return r, nil
+}
@@ -0,0 +1,77 @@
+package redisboard
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleReset(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	var archived []User
+	r, err := lb.ScheduleReset(time.Now().Add(50*time.Millisecond), func() {
+		archived, _ = lb.GetTopKGlobal() // runs before the reset
+	})
+	if err != nil {
+		t.Fatalf("ScheduleReset: %v", err)
+	}
+	if _, err := lb.GetTopKGlobal(); err != nil {
+		t.Fatalf("expected board intact before the reset time: %v", err)
+	}
+
+	select {
+	case <-r.Done():
+	case <-time.After(time.Second):
+		t.Fatal("reset didn't run")
+	}
+	if err := r.Err(); err != nil {
+		t.Errorf("reset failed: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != "u1" {
+		t.Errorf("expected the callback to see the final standings, got %+v", archived)
+	}
+	if _, err := lb.GetTopKGlobal(); err == nil {
+		t.Error("expected an empty board after the reset")
+	}
+	if r.Stop() {
+		t.Error("expected Stop to fail after the reset ran")
+	}
+
+	if _, err := lb.ScheduleReset(time.Time{}, nil); err == nil {
+		t.Error("expected error for zero time")
+	}
+}
+
+func TestScheduleResetStop(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	lb.AddUser(User{ID: "u1", Score: 100})
+
+	stopped, _ := lb.ScheduleReset(time.Now().Add(50*time.Millisecond), nil)
+	if !stopped.Stop() {
+		t.Fatal("expected Stop to cancel the pending reset")
+	}
+	if stopped.Err() == nil {
+		t.Error("expected an error for a stopped reset")
+	}
+
+	// Close cancels pending resets
+	pending, _ := lb.ScheduleReset(time.Now().Add(time.Hour), nil)
+	lb.Close()
+	if err := pending.Err(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+	if _, err := lb.ScheduleReset(time.Now(), nil); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed scheduling on a closed board, got %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	check := newTestLeaderboard(t, Config{Namespace: "other"})
+	defer check.Close()
+	score, err := check.client.ZScore(check.ctx, "test:global", "u1").Result()
+	if err != nil || score != 100 {
+		t.Errorf("expected the stopped reset to leave u1, got %v, %v", score, err)
+	}
+}
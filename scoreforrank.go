@@ -0,0 +1,56 @@
+package redisboard
+
+import "fmt"
+
+// ScoreForRank returns the score currently held at targetRank globally,
+// 0-based like GetRankGlobal, e.g. 99 for "points needed for the top 100":
+// a user reaches that rank by matching it (ties keep Redis' tie order, so
+// beating it is the safe target). Subtract the user's own score for the
+// delta.
+// Returns error if:
+// - targetRank is negative
+// - targetRank is past the last user (board smaller than targetRank+1)
+// - GlobalShards is set (ErrShardingUnsupported)
+// - Redis operation fails
+func (lb *Leaderboard) ScoreForRank(targetRank int) (_ float64, err error) {
+	defer lb.startOp("ScoreForRank", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return 0, err
+	}
+	return lb.scoreForRank(lb.reader, lb.globalKey(), targetRank)
+}
+
+// ScoreForRankEntity returns the score currently held at targetRank within
+// entity, like ScoreForRank.
+// Returns error if:
+// - entity is invalid (ErrInvalidEntity)
+// - targetRank is negative
+// - targetRank is past the entity's last user
+// - Redis operation fails
+func (lb *Leaderboard) ScoreForRankEntity(entity string, targetRank int) (_ float64, err error) {
+	defer lb.startOp("ScoreForRankEntity", "", entity).end(&err)
+	entity = lb.normalizeEntity(entity)
+	if err := lb.validateEntity(entity); err != nil {
+		return 0, err
+	}
+	return lb.scoreForRank(lb.entityReader(entity), lb.entityKey(entity), targetRank)
+}
+
+// scoreForRank reads the score at rank of key on c, with its size to
+// report ranks past the end, in one round trip.
+func (lb *Leaderboard) scoreForRank(c RedisCommands, key string, rank int) (float64, error) {
+	if rank < 0 {
+		return 0, fmt.Errorf("invalid rank %d", rank)
+	}
+	pipe := c.Pipeline()
+	rangeCmd := pipe.ZRevRangeWithScores(lb.ctx, key, int64(rank), int64(rank))
+	sizeCmd := pipe.ZCard(lb.ctx, key)
+	if _, err := pipe.Exec(lb.ctx); err != nil {
+		return 0, fmt.Errorf("failed to get score at rank %d: %w", rank, err)
+	}
+	members := rangeCmd.Val()
+	if len(members) == 0 {
+		return 0, fmt.Errorf("rank %d exceeds board of %d users", rank, sizeCmd.Val())
+	}
+	return members[0].Score, nil
+}
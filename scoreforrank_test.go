@@ -0,0 +1,31 @@
+package redisboard
+
+import "testing"
+
+func TestScoreForRank(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 10})
+	lb.AddUser(User{ID: "u2", Entity: "IN", Score: 30})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 20})
+
+	for rank, want := range []float64{30, 20, 10} {
+		if score, err := lb.ScoreForRank(rank); err != nil || score != want {
+			t.Errorf("expected %v at rank %d, got %v, %v", want, rank, score, err)
+		}
+	}
+	if score, err := lb.ScoreForRankEntity("US", 1); err != nil || score != 10 {
+		t.Errorf("expected 10 at US rank 1, got %v, %v", score, err)
+	}
+
+	if _, err := lb.ScoreForRank(3); err == nil {
+		t.Error("expected rank past the board to fail")
+	}
+	if _, err := lb.ScoreForRankEntity("IN", 1); err == nil {
+		t.Error("expected rank past the entity to fail")
+	}
+	if _, err := lb.ScoreForRank(-1); err == nil {
+		t.Error("expected negative rank to fail")
+	}
+}
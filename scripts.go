@@ -0,0 +1,67 @@
+package redisboard
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// scriptLoader loads Lua scripts into Redis lazily, once per script.
+// Concurrent first uses share a single SCRIPT LOAD; after a NOSCRIPT reply
+// (e.g. Redis restarted and lost its script cache) the script is loaded
+// again and the call retried.
+type scriptLoader struct {
+	mu   sync.Mutex
+	shas map[string]string // script source -> SHA1 loaded on the server
+}
+
+// newScriptLoader returns an empty loader.
+func newScriptLoader() *scriptLoader {
+	return &scriptLoader{shas: make(map[string]string)}
+}
+
+// sha returns the SHA1 of src, loading it into Redis on first use.
+func (l *scriptLoader) sha(lb *Leaderboard, src string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sha, ok := l.shas[src]; ok {
+		return sha, nil
+	}
+	sha, err := lb.client.ScriptLoad(lb.ctx, src).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to load script: %w", err)
+	}
+	l.shas[src] = sha
+	return sha, nil
+}
+
+// forget drops the cached SHA of src so the next use reloads it.
+func (l *scriptLoader) forget(src string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.shas, src)
+}
+
+// evalScript runs the Lua script src with EVALSHA, loading it on first use
+// and reloading it once if Redis no longer knows it.
+func (lb *Leaderboard) evalScript(src string, keys []string, args ...any) (any, error) {
+	sha, err := lb.scripts.sha(lb, src)
+	if err != nil {
+		return nil, err
+	}
+	res, err := lb.client.EvalSha(lb.ctx, sha, keys, args...).Result()
+	if err == nil || !isNoScript(err) {
+		return res, err
+	}
+
+	lb.scripts.forget(src)
+	if sha, err = lb.scripts.sha(lb, src); err != nil {
+		return nil, err
+	}
+	return lb.client.EvalSha(lb.ctx, sha, keys, args...).Result()
+}
+
+// isNoScript reports whether err is Redis' NOSCRIPT reply.
+func isNoScript(err error) bool {
+	return strings.HasPrefix(err.Error(), "NOSCRIPT")
+}
@@ -0,0 +1,65 @@
+package redisboard
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scriptLoadHook counts SCRIPT LOAD commands sent to Redis.
+type scriptLoadHook struct {
+	loads atomic.Int64
+}
+
+func (h *scriptLoadHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *scriptLoadHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if args := cmd.Args(); len(args) > 1 && cmd.Name() == "script" && args[1] == "load" {
+			h.loads.Add(1)
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h *scriptLoadHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestEvalScriptConcurrentLoad(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+	hook := &scriptLoadHook{}
+	rawClient(lb).AddHook(hook)
+
+	const src = "return ARGV[1]"
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := lb.evalScript(src, nil, "ok")
+			if err != nil || res != "ok" {
+				t.Errorf("evalScript: got %v, err: %v", res, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := hook.loads.Load(); n != 1 {
+		t.Errorf("expected 1 SCRIPT LOAD, got %d", n)
+	}
+
+	// a flushed script cache (e.g. Redis restart) is recovered from
+	if err := rawClient(lb).ScriptFlush(lb.ctx).Err(); err != nil {
+		t.Fatalf("SCRIPT FLUSH: %v", err)
+	}
+	if res, err := lb.evalScript(src, nil, "again"); err != nil || res != "again" {
+		t.Errorf("evalScript after flush: got %v, err: %v", res, err)
+	}
+	if n := hook.loads.Load(); n != 2 {
+		t.Errorf("expected a reload after NOSCRIPT, got %d loads", n)
+	}
+}
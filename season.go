@@ -0,0 +1,75 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidSeason is returned when a season name is too long or contains
+// characters outside Config.EntityCharset. Season names become part of
+// Redis key names, just like entities.
+var ErrInvalidSeason = errors.New("invalid season")
+
+// seasonPrefix marks the season segment of season keys, so seasons can't
+// collide with the namespace's own keys ({namespace}:global, ...).
+const seasonPrefix = "s"
+
+// validateSeason checks season name length and characters against config.
+func (lb *Leaderboard) validateSeason(season string) error {
+	if err := lb.validateEntity(season); err != nil {
+		return fmt.Errorf("%w %q", ErrInvalidSeason, season)
+	}
+	return nil
+}
+
+// Season returns a leaderboard for season in the same namespace, sharing
+// lb's Redis connections: all its keys live under {namespace}:s{season}:,
+// e.g. lb.Season("2024").GetTopKGlobal(). The empty season is the default
+// one, whose keys are the namespace's own. Every other setting is lb's.
+// Close the returned leaderboard to stop its background loops; it never
+// closes the shared connections.
+// Returns error if:
+// - season is invalid (ErrInvalidSeason)
+// - the season's keys hold other data types (ErrNamespaceConflict)
+// - Redis operation fails
+func (lb *Leaderboard) Season(season string) (_ *Leaderboard, err error) {
+	defer lb.startOp("Season", "", "").end(&err)
+	if err := lb.validateSeason(season); err != nil {
+		return nil, err
+	}
+	cfg := lb.config
+	cfg.Season = season
+	return newLeaderboard(cfg, lb.client, lb.reader, false, false)
+}
+
+// ListSeasons returns the named seasons holding a global ranking in lb's
+// namespace, sorted. Seasons without users yet, and the default season,
+// are not listed. Uses SCAN, so it doesn't block Redis on large keyspaces.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) ListSeasons() (_ []string, err error) {
+	defer lb.startOp("ListSeasons", "", "").end(&err)
+	sep := lb.config.KeySeparator
+	prefix := lb.namespacePrefix() + sep + seasonPrefix
+	suffix := sep + "global"
+
+	found := make(map[string]bool)
+	iter := lb.client.Scan(lb.ctx, 0, prefix+"*"+suffix, int64(lb.config.BatchSize)).Iterator()
+	for iter.Next(lb.ctx) {
+		season := strings.TrimSuffix(strings.TrimPrefix(iter.Val(), prefix), suffix)
+		if !strings.Contains(season, sep) { // skips metric boards
+			found[season] = true
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan seasons: %w", err)
+	}
+
+	seasons := make([]string, 0, len(found))
+	for season := range found {
+		seasons = append(seasons, season)
+	}
+	sort.Strings(seasons)
+	return seasons, nil
+}
@@ -0,0 +1,62 @@
+package redisboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSeasons(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	s2023, err := lb.Season("2023")
+	if err != nil {
+		t.Fatalf("Season: %v", err)
+	}
+	defer s2023.Close()
+	s2024, err := lb.Season("2024")
+	if err != nil {
+		t.Fatalf("Season: %v", err)
+	}
+	defer s2024.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 1})
+	s2023.AddUser(User{ID: "u1", Entity: "US", Score: 50})
+	s2024.AddUser(User{ID: "u2", Entity: "EU", Score: 70})
+	s2024.AddUserMetric("u2", "EU", "kills", 3)
+
+	if top, _ := s2023.GetTopKGlobal(); len(top) != 1 || top[0].ID != "u1" || top[0].Score != 50 {
+		t.Errorf("unexpected 2023 top-k: %+v", top)
+	}
+	if top, _ := s2024.GetTopKGlobal(); len(top) != 1 || top[0].ID != "u2" {
+		t.Errorf("unexpected 2024 top-k: %+v", top)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 1 {
+		t.Errorf("expected default season untouched, got %v", score)
+	}
+
+	seasons, err := lb.ListSeasons()
+	if want := []string{"2023", "2024"}; err != nil || !reflect.DeepEqual(seasons, want) {
+		t.Errorf("expected %v, got %v, %v", want, seasons, err)
+	}
+
+	// clearing a season leaves the others
+	s2023.ForceClearLeaderBoardWithNamespacePrefix()
+	if seasons, _ := lb.ListSeasons(); !reflect.DeepEqual(seasons, []string{"2024"}) {
+		t.Errorf("expected only 2024 left, got %v", seasons)
+	}
+	if _, err := lb.GetUserScore("u1"); err != nil {
+		t.Errorf("expected default season kept, got %v", err)
+	}
+
+	// closing a season keeps the shared connection open
+	s2024.Close()
+	if _, err := lb.GetUserScore("u1"); err != nil {
+		t.Errorf("expected connection still open, got %v", err)
+	}
+
+	if _, err := lb.Season("20:24"); !errors.Is(err, ErrInvalidSeason) {
+		t.Errorf("expected ErrInvalidSeason, got %v", err)
+	}
+}
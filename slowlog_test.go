@@ -0,0 +1,76 @@
+package redisboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent log writes.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSlowThreshold(t *testing.T) {
+	var out lockedBuffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	client := redis.NewClient(&redis.Options{Addr: testRedisAddr})
+	defer client.Close()
+	// every call is slower than a nanosecond
+	lb := newTestLeaderboard(t, Config{Namespace: "test", Client: client, SlowThreshold: time.Nanosecond, Logger: logger})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.GetUserScore("u1")
+	lb.GetUserScore("missing")
+	client.Ping(lb.ctx) // the client's other users aren't timed
+
+	var ops []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		if rec["msg"] != "slow leaderboard operation" || rec["namespace"] != "test" || rec["duration"] == nil {
+			t.Errorf("unexpected record %v", rec)
+		}
+		op := fmt.Sprint(rec["op"], " ", rec["user"], " ", rec["entity"])
+		if rec["error"] != nil {
+			op += " error"
+		}
+		ops = append(ops, op)
+	}
+	want := []string{"AddUser u1 US", "GetUserScore u1 <nil>", "GetUserScore missing <nil> error"}
+	if !slices.Equal(ops, want) {
+		t.Errorf("expected %q logged, got %q", want, ops)
+	}
+
+	quiet := newTestLeaderboard(t, Config{Namespace: "test", SlowThreshold: time.Hour, Logger: slog.New(slog.NewJSONHandler(&out, nil))})
+	defer quiet.Close()
+	before := out.String()
+	quiet.AddUser(User{ID: "u2", Score: 1})
+	if out.String() != before {
+		t.Errorf("expected nothing logged under the threshold, got:\n%s", strings.TrimPrefix(out.String(), before))
+	}
+}
@@ -0,0 +1,61 @@
+package redisboard
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrEntityMismatch is returned with StrictEntity by increments naming an
+// entity other than the user's stored one.
+var ErrEntityMismatch = errors.New("entity mismatch")
+
+// checkEntity returns ErrEntityMismatch if StrictEntity is set and userID
+// has an entity other than entity. Users without one may get any.
+func (lb *Leaderboard) checkEntity(userID, entity string) error {
+	if !lb.config.StrictEntity {
+		return nil
+	}
+	stored, err := lb.client.HGet(lb.ctx, lb.entitiesKey(), userID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user entity: %w", err)
+	}
+	return entityMismatch(userID, stored, entity)
+}
+
+// checkEntities is checkEntity for a batch of updates in one round trip,
+// returning the errors by index.
+func (lb *Leaderboard) checkEntities(updates []ScoreUpdate) (map[int]error, error) {
+	if !lb.config.StrictEntity || len(updates) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]string, len(updates))
+	for i, u := range updates {
+		userIDs[i] = u.UserID
+	}
+	stored, err := lb.client.HMGet(lb.ctx, lb.entitiesKey(), userIDs...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user entities: %w", err)
+	}
+	failed := make(map[int]error)
+	for i, u := range updates {
+		entity, _ := stored[i].(string)
+		if err := entityMismatch(u.UserID, entity, lb.normalizeEntity(u.Entity)); err != nil {
+			failed[i] = err
+		}
+	}
+	return failed, nil
+}
+
+// entityMismatch reports a write of userID to entity while stored in
+// stored.
+func entityMismatch(userID, stored, entity string) error {
+	if stored == "" || stored == entity {
+		return nil
+	}
+	return fmt.Errorf("%w: user %s is in %q, not %q (move users with UpdateEntityByUserID)", ErrEntityMismatch, userID, stored, entity)
+}
@@ -0,0 +1,73 @@
+package redisboard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIncrementScoreWrongEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	// without StrictEntity a wrong entity remaps the user and leaves their
+	// old entity ranking stale
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	if err := lb.IncrementScore("u1", "UK", 10); err != nil {
+		t.Fatalf("IncrementScore: %v", err)
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "UK" {
+		t.Errorf("expected mapping moved to UK, got %s", entity)
+	}
+	if stale, err := lb.client.ZScore(lb.ctx, "test:entity:US", "u1").Result(); err != nil || stale != 100 {
+		t.Errorf("expected stale US score 100, got %v, %v", stale, err)
+	}
+	if uk, _ := lb.client.ZScore(lb.ctx, "test:entity:UK", "u1").Result(); uk != 10 {
+		t.Errorf("expected UK score 10 (the increment only), got %v", uk)
+	}
+}
+
+func TestStrictEntity(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", StrictEntity: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Score: 50})
+	for _, entity := range []string{"UK", ""} {
+		if err := lb.IncrementScore("u1", entity, 10); !errors.Is(err, ErrEntityMismatch) {
+			t.Errorf("IncrementScore to %q: expected ErrEntityMismatch, got %v", entity, err)
+		}
+		if err := lb.DecrementScore("u1", entity, 10); !errors.Is(err, ErrEntityMismatch) {
+			t.Errorf("DecrementScore to %q: expected ErrEntityMismatch, got %v", entity, err)
+		}
+	}
+	if entity, _ := lb.GetUserEntity("u1"); entity != "US" {
+		t.Errorf("expected mapping kept at US, got %s", entity)
+	}
+
+	// matching entities, new users and users without an entity pass
+	if err := lb.IncrementScore("u1", "US", 10); err != nil {
+		t.Errorf("IncrementScore: %v", err)
+	}
+	if err := lb.IncrementScore("u3", "UK", 10); err != nil {
+		t.Errorf("IncrementScore new user: %v", err)
+	}
+	if err := lb.IncrementScore("u2", "UK", 10); err != nil {
+		t.Errorf("IncrementScore user without entity: %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 110 {
+		t.Errorf("expected score 110, got %v", score)
+	}
+
+	err := lb.IncrementScores([]ScoreUpdate{{UserID: "u1", Entity: "UK", Delta: 5}, {UserID: "u3", Entity: "UK", Delta: 5}})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[0], ErrEntityMismatch) {
+		t.Errorf("expected only update 0 to fail with ErrEntityMismatch, got %v", err)
+	}
+	if score, _ := lb.GetUserScore("u3"); score != 15 {
+		t.Errorf("expected u3 at 15, got %v", score)
+	}
+
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, StrictEntity: true, CoalesceInterval: 10}); err == nil {
+		t.Error("expected StrictEntity with CoalesceInterval to be rejected")
+	}
+}
@@ -0,0 +1,103 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SwapScores exchanges two users' scores in the global and entity rankings,
+// e.g. after an admin overturns a disputed match. Entities, metadata and
+// metric boards are unchanged.
+// Runs as an optimistic WATCH/MULTI transaction over both users' global and
+// entity rankings and the entity mapping, retried on conflict, so a
+// concurrent score or entity change can't be overwritten with a stale score.
+// Returns error if:
+// - either user ID is empty
+// - either user doesn't exist (ErrUserNotFound)
+// - concurrent writes keep conflicting (ErrConflict)
+// - Redis operation fails
+func (lb *Leaderboard) SwapScores(userA, userB string) (err error) {
+	defer lb.startOp("SwapScores", userA, "").end(&err)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if userA == "" || userB == "" {
+		return fmt.Errorf("invalid user ID")
+	}
+	defer lb.topKCache.invalidate()
+
+	entitiesKey := lb.entitiesKey()
+	ids := []string{userA, userB}
+
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		// The entity keys to watch depend on the mapping, so read it first
+		// and abort the transaction if it changed by the time it runs.
+		entities, err := lb.userEntities(lb.client, ids)
+		if err != nil {
+			return err
+		}
+		keys := []string{entitiesKey, lb.userGlobalKey(userA), lb.userGlobalKey(userB)}
+		for _, entity := range entities {
+			if entity != "" {
+				keys = append(keys, lb.entityKey(entity))
+			}
+		}
+
+		swap := func(tx *redis.Tx) error {
+			current, err := lb.userEntities(tx, ids)
+			if err != nil {
+				return err
+			}
+			if current != entities {
+				return redis.TxFailedErr
+			}
+
+			var scores [2]float64
+			for i, id := range ids {
+				scores[i], err = tx.ZScore(lb.ctx, lb.userGlobalKey(id), lb.memberFor(id, entities[i])).Result()
+				if err == redis.Nil {
+					return fmt.Errorf("%w: %s", ErrUserNotFound, id)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to get user score: %w", err)
+				}
+			}
+
+			_, err = tx.TxPipelined(lb.ctx, func(pipe redis.Pipeliner) error {
+				for i, id := range ids {
+					score := scores[1-i]
+					pipe.ZAdd(lb.ctx, lb.userGlobalKey(id), redis.Z{Score: score, Member: lb.memberFor(id, entities[i])})
+					if entities[i] != "" {
+						lb.entityWrite(pipe, entitySet, entities[i], id, score)
+					}
+				}
+				return nil
+			})
+			if err != nil && err != redis.TxFailedErr {
+				return fmt.Errorf("failed to swap scores: %w", conflictErr(err))
+			}
+			return err
+		}
+
+		err = lb.client.Watch(lb.ctx, swap, keys...)
+		if err == redis.TxFailedErr {
+			continue // watched key changed, retry with fresh data
+		}
+		return err
+	}
+	return fmt.Errorf("failed to swap scores: %w after %d attempts", ErrConflict, maxTxRetries)
+}
+
+// userEntities reads the entity mapping of two users through c.
+func (lb *Leaderboard) userEntities(c RedisCommands, ids []string) ([2]string, error) {
+	var entities [2]string
+	vals, err := c.HMGet(lb.ctx, lb.entitiesKey(), ids...).Result()
+	if err != nil {
+		return entities, fmt.Errorf("failed to fetch user entities: %w", err)
+	}
+	for i, v := range vals {
+		entities[i], _ = v.(string)
+	}
+	return entities, nil
+}
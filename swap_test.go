@@ -0,0 +1,84 @@
+package redisboard
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestSwapScores(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Score: 300})
+
+	if err := lb.SwapScores("u1", "u2"); err != nil {
+		t.Fatalf("SwapScores: %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 300 {
+		t.Errorf("expected u1 score 300, got %v", score)
+	}
+	if score, _ := lb.GetUserScore("u2"); score != 100 {
+		t.Errorf("expected u2 score 100, got %v", score)
+	}
+	if top, _ := lb.GetTopKEntity("US"); len(top) != 1 || top[0].Score != 300 {
+		t.Errorf("expected u1 entity score 300, got %+v", top)
+	}
+	if top, _ := lb.GetTopKGlobal(); top[0].ID != "u1" {
+		t.Errorf("expected u1 on top after swap, got %+v", top)
+	}
+
+	if err := lb.SwapScores("u1", "ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+	if score, _ := lb.GetUserScore("u1"); score != 300 {
+		t.Errorf("expected failed swap to leave u1 at 300, got %v", score)
+	}
+}
+
+func TestSwapScoresConcurrentIncrement(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "UK", Score: 200})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := lb.IncrementScore("u1", "US", 1); err != nil {
+				t.Errorf("IncrementScore: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			err := lb.SwapScores("u1", "u2")
+			for errors.Is(err, ErrConflict) {
+				err = lb.SwapScores("u1", "u2")
+			}
+			if err != nil {
+				t.Errorf("SwapScores: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	// a swap never loses an increment, and entity rankings follow
+	s1, _ := lb.GetUserScore("u1")
+	s2, _ := lb.GetUserScore("u2")
+	if s1+s2 != 350 {
+		t.Errorf("expected scores to sum to 350, got %v + %v", s1, s2)
+	}
+	for id, entity := range map[string]string{"u1": "US", "u2": "UK"} {
+		global, _ := lb.GetUserScore(id)
+		entityScore, err := lb.client.ZScore(lb.ctx, lb.entityKey(entity), id).Result()
+		if err != nil || entityScore != global {
+			t.Errorf("%s: expected entity score %v, got %v, err: %v", id, global, entityScore, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package redisboard
+
+import "sort"
+
+// sortTies reorders runs of equal-score users, already sorted by score
+// descending, by their Config.TieBreakField metadata value, ascending.
+// Users without the field keep their Redis order after those with it.
+// Only the fetched users are reordered: a tied user ranked just past k
+// isn't pulled in, and ranks (GetRankGlobal, ...) keep the Redis order.
+func (lb *Leaderboard) sortTies(users []User) {
+	field := lb.config.TieBreakField
+	if field == "" {
+		return
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		va, oka := a.Metadata[field]
+		vb, okb := b.Metadata[field]
+		if oka != okb {
+			return oka
+		}
+		return va < vb
+	})
+}
@@ -0,0 +1,39 @@
+package redisboard
+
+import "testing"
+
+func TestTieBreakField(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EnableMetadata: true, TieBreakField: "joined"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "a", Entity: "US", Score: 100, Metadata: map[string]string{"joined": "2024-03-01"}})
+	lb.AddUser(User{ID: "b", Entity: "US", Score: 100, Metadata: map[string]string{"joined": "2024-01-15"}})
+	lb.AddUser(User{ID: "c", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "d", Entity: "US", Score: 200, Metadata: map[string]string{"joined": "2025-01-01"}})
+	lb.AddUser(User{ID: "e", Entity: "US", Score: 50, Metadata: map[string]string{"joined": "2020-01-01"}})
+
+	// earlier join wins ties; users without the field come last in their group
+	want := []string{"d", "b", "a", "c", "e"}
+	global, err := lb.GetTopKGlobal()
+	if err != nil {
+		t.Fatalf("GetTopKGlobal: %v", err)
+	}
+	entity, err := lb.GetTopKEntity("US")
+	if err != nil {
+		t.Fatalf("GetTopKEntity: %v", err)
+	}
+	for name, users := range map[string][]User{"global": global, "entity": entity} {
+		if len(users) != len(want) {
+			t.Fatalf("%s: expected %d users, got %+v", name, len(want), users)
+		}
+		for i, id := range want {
+			if users[i].ID != id {
+				t.Errorf("%s: position %d: expected %s, got %s", name, i, id, users[i].ID)
+			}
+		}
+	}
+
+	if _, err := New(Config{Namespace: "test", RedisAddr: testRedisAddr, TieBreakField: "joined"}); err == nil {
+		t.Error("expected error for TieBreakField without EnableMetadata")
+	}
+}
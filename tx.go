@@ -0,0 +1,252 @@
+package redisboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Batch groups mixed writes (add user A, increment B, remove C) that must
+// apply together or not at all. Build it with NewBatch and the chaining
+// methods, then run it with Exec. A Batch is not safe for concurrent use.
+type Batch struct {
+	lb  *Leaderboard
+	ops []batchOp
+}
+
+// batchOp is one queued Batch operation.
+type batchOp struct {
+	kind  batchKind
+	user  User    // ID and Entity for all kinds; Score for AddUser
+	delta float64 // Increment only
+}
+
+// batchKind tells the operation a batchOp performs.
+type batchKind int
+
+const (
+	batchAdd batchKind = iota
+	batchIncrement
+	batchRemove
+)
+
+// NewBatch returns an empty batch of operations on the leaderboard.
+func (lb *Leaderboard) NewBatch() *Batch {
+	return &Batch{lb: lb}
+}
+
+// AddUser queues an AddUser.
+func (b *Batch) AddUser(user User) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchAdd, user: user})
+	return b
+}
+
+// Increment queues an IncrementScore (negative delta to subtract).
+func (b *Batch) Increment(userID, entity string, delta float64) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchIncrement, user: User{ID: userID, Entity: entity}, delta: delta})
+	return b
+}
+
+// Remove queues a RemoveUser.
+func (b *Batch) Remove(userID string) *Batch {
+	b.ops = append(b.ops, batchOp{kind: batchRemove, user: User{ID: userID}})
+	return b
+}
+
+// Exec runs the queued operations, in order, in a single MULTI/EXEC
+// transaction. Every operation is validated as its method would first; if
+// any is invalid nothing is applied. The entity mapping (and metric list)
+// is WATCHed, so a concurrent entity change retries the transaction.
+// Operations behave like their methods, except that AddUser doesn't
+// support EvictionPolicy/MaxUsersPerEntity caps and increments bypass
+// CoalesceInterval. As with any Redis transaction, a command failing at
+// run time (e.g., WRONGTYPE) doesn't roll back the others.
+// Returns error if:
+// - an operation is invalid or fails (*BatchError keyed by operation index)
+// - concurrent writes keep conflicting (ErrConflict)
+// - Redis operation fails
+func (b *Batch) Exec(ctx context.Context) (err error) {
+	lb := b.lb
+	defer lb.startOp("Batch.Exec", "", "").end(&err)
+	ctx = lb.withCloseState(ctx)
+	if err := lb.unsharded(); err != nil {
+		return err
+	}
+	if len(b.ops) == 0 {
+		return nil
+	}
+
+	ops, meta, err := b.validate()
+	if err != nil {
+		return err
+	}
+	defer lb.topKCache.invalidate()
+
+	entitiesKey := lb.entitiesKey()
+	metricsKey := lb.metricsKey()
+	ids := make([]string, 0, len(ops))
+	for _, op := range ops {
+		ids = append(ids, op.user.ID)
+	}
+
+	var cmds [][]redis.Cmder
+	run := func(tx *redis.Tx) error {
+		// current entities, followed through the batch so a later op sees
+		// the earlier ones' entity changes
+		mapped, err := tx.HMGet(ctx, entitiesKey, ids...).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch entities: %w", err)
+		}
+		entities := make(map[string]string, len(ids))
+		for i, id := range ids {
+			if _, seen := entities[id]; !seen {
+				entities[id], _ = mapped[i].(string)
+			}
+		}
+		metrics, err := tx.SMembers(ctx, metricsKey).Result()
+		if err != nil {
+			return fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+
+		cmds = make([][]redis.Cmder, len(ops))
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, op := range ops {
+				cmds[i] = lb.queueBatchOp(pipe, op, meta[i], entities, metrics)
+			}
+			return nil
+		})
+		return err
+	}
+
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		err := lb.client.Watch(ctx, run, entitiesKey, metricsKey)
+		if err == redis.TxFailedErr {
+			continue // watched key changed, retry with fresh data
+		}
+		failed := make(map[int]error)
+		for i, opCmds := range cmds {
+			for _, cmd := range opCmds {
+				if cmdErr := cmd.Err(); cmdErr != nil && cmdErr != redis.Nil {
+					failed[i] = fmt.Errorf("failed to apply operation: %w", conflictErr(cmdErr))
+					break
+				}
+			}
+		}
+		if len(failed) > 0 {
+			return &BatchError{Errors: failed}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to execute batch: %w", conflictErr(err))
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to execute batch: %w after %d attempts", ErrConflict, maxTxRetries)
+}
+
+// validate checks every operation like its method would, returning the
+// operations with normalized scores and their encoded metadata.
+func (b *Batch) validate() ([]batchOp, [][]byte, error) {
+	lb := b.lb
+	ops := make([]batchOp, len(b.ops))
+	meta := make([][]byte, len(b.ops))
+	failed := make(map[int]error)
+	for i, op := range b.ops {
+		var err error
+		op.user.Entity = lb.normalizeEntity(op.user.Entity)
+		switch op.kind {
+		case batchAdd:
+			err = finiteScore(op.user.Score)
+			switch {
+			case err != nil:
+			case op.user.ID == "" || !lb.validScore(op.user.Score):
+				err = fmt.Errorf("invalid user ID or score")
+			case lb.capped(op.user.Entity):
+				err = fmt.Errorf("batch AddUser can't enforce user caps")
+			default:
+				err = lb.validateUserID(op.user.ID)
+			}
+			if err == nil {
+				err = lb.validateEntity(op.user.Entity)
+			}
+			if err == nil {
+				op.user.Score, err = lb.normalizeScore(op.user.Score)
+			}
+			if err == nil {
+				err = lb.admitEntity(op.user.Entity)
+			}
+			if err == nil && lb.config.EnableMetadata && len(op.user.Metadata) > 0 {
+				meta[i], err = json.Marshal(op.user.Metadata)
+			}
+		case batchIncrement:
+			if op.user.ID == "" {
+				err = fmt.Errorf("invalid user ID or score increment")
+			} else {
+				err = lb.validateUserID(op.user.ID)
+			}
+			if err == nil {
+				err = lb.validateEntity(op.user.Entity)
+			}
+			if err == nil {
+				op.delta, err = lb.normalizeScore(op.delta)
+			}
+			if err == nil {
+				err = lb.admitEntity(op.user.Entity)
+			}
+		case batchRemove:
+			if op.user.ID == "" {
+				err = fmt.Errorf("invalid user ID")
+			}
+		}
+		if err != nil {
+			failed[i] = err
+		}
+		ops[i] = op
+	}
+	if len(failed) > 0 {
+		return nil, nil, &BatchError{Errors: failed}
+	}
+	return ops, meta, nil
+}
+
+// queueBatchOp queues op on pipe and returns its commands. entities holds
+// each user's current entity and is updated to reflect op.
+func (lb *Leaderboard) queueBatchOp(pipe redis.Pipeliner, op batchOp, meta []byte, entities map[string]string, metrics []string) []redis.Cmder {
+	u := op.user
+	switch op.kind {
+	case batchRemove:
+		cmds := lb.queueRemove(pipe, pipe, u.ID, entities[u.ID], metrics)
+		entities[u.ID] = ""
+		return cmds
+	case batchIncrement:
+		if op.delta == 0 {
+			return nil
+		}
+	}
+
+	cmds := []redis.Cmder{lb.setEntity(pipe, u.ID, u.Entity)}
+	entities[u.ID] = u.Entity
+	member := lb.memberFor(u.ID, u.Entity)
+	if op.kind == batchAdd {
+		cmds = append(cmds, pipe.ZAdd(lb.ctx, lb.userGlobalKey(u.ID), redis.Z{Score: u.Score, Member: member}))
+		if u.Entity != "" {
+			cmds = append(cmds, lb.entityWrite(pipe, entitySet, u.Entity, u.ID, u.Score))
+		}
+		if meta != nil {
+			cmds = append(cmds, pipe.HSet(lb.ctx, lb.metaKey(), u.ID, meta))
+		}
+		if lb.config.EnableNames && u.Name != "" {
+			cmds = append(cmds, pipe.HSet(lb.ctx, lb.namesKey(), u.ID, u.Name))
+		}
+	} else {
+		cmds = append(cmds, pipe.ZIncrBy(lb.ctx, lb.userGlobalKey(u.ID), op.delta, member))
+		if u.Entity != "" {
+			cmds = append(cmds, lb.entityWrite(pipe, entityIncr, u.Entity, u.ID, op.delta))
+		}
+	}
+	if cmd := lb.touch(pipe, u.ID); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
@@ -0,0 +1,88 @@
+package redisboard
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBatchExec(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+
+	err := lb.NewBatch().
+		AddUser(User{ID: "u3", Entity: "UK", Score: 50}).
+		Increment("u1", "US", 150).
+		Remove("u2").
+		AddUser(User{ID: "u4", Entity: "UK", Score: 10}).
+		Remove("u4"). // sees the entity set earlier in the batch
+		Exec(context.Background())
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	if score, _ := lb.GetUserScore("u1"); score != 250 {
+		t.Errorf("expected u1 at 250, got %v", score)
+	}
+	if _, err := lb.GetUserScore("u2"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected u2 removed, got %v", err)
+	}
+	if members, _ := lb.GetEntityMembers("UK"); len(members) != 1 || members[0] != "u3" {
+		t.Errorf("expected UK members [u3], got %v", members)
+	}
+	if members, _ := lb.GetEntityMembers("US"); len(members) != 1 || members[0] != "u1" {
+		t.Errorf("expected US members [u1], got %v", members)
+	}
+}
+
+func TestBatchExecInvalid(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Score: 100})
+	err := lb.NewBatch().
+		Increment("u1", "", 5).
+		AddUser(User{ID: "u2", Entity: "bad entity", Score: 10}).
+		Remove("").
+		Increment("u1", "", math.NaN()).
+		Exec(context.Background())
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 3 {
+		t.Fatalf("expected BatchError with 3 failures, got %v", err)
+	}
+	if !errors.Is(batchErr.Errors[1], ErrInvalidEntity) || batchErr.Errors[2] == nil || !errors.Is(batchErr.Errors[3], ErrInvalidScore) {
+		t.Errorf("unexpected failures: %v", batchErr.Errors)
+	}
+	// nothing applied
+	if score, _ := lb.GetUserScore("u1"); score != 100 {
+		t.Errorf("expected u1 untouched at 100, got %v", score)
+	}
+
+	if err := lb.NewBatch().Exec(context.Background()); err != nil {
+		t.Errorf("expected empty batch to succeed, got %v", err)
+	}
+}
+
+func TestBatchExecEntityInMember(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test", EntityInMember: true})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	err := lb.NewBatch().
+		Increment("u1", "UK", 5).
+		AddUser(User{ID: "u2", Entity: "UK", Score: 50}).
+		Exec(context.Background())
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	topK, _ := lb.GetTopKGlobal()
+	if len(topK) != 2 || topK[0].ID != "u1" || topK[0].Entity != "UK" || topK[0].Score != 105 {
+		t.Errorf("expected u1 relocated to UK at 105, got %+v", topK)
+	}
+}
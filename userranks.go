@@ -0,0 +1,89 @@
+package redisboard
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserRanks is a user's global and entity rank, see GetUserRanks.
+type UserRanks struct {
+	GlobalRank int    `json:"globalRank"` // position across all users (0-based), -1 if not ranked
+	EntityRank int    `json:"entityRank"` // position within entity (0-based), -1 if not ranked there
+	Exists     bool   `json:"exists"`     // false if user is not on the leaderboard
+	Entity     string `json:"entity"`     // the user's mapped entity, empty if none
+}
+
+// GetUserRanks returns a user's live global and entity ranks together,
+// e.g. for a profile badge. The entity rank resolves like GetRankEntity
+// (PrimaryEntity first if the user is ranked in it); Entity is always the
+// mapped entity. Unknown users get Exists=false and -1 ranks rather than
+// errors.
+// Takes two pipelined round trips: the entity mapping first, then both
+// ranks. Entity rankings on a Sharder backend and GlobalShards partition
+// ranks cost one more each. Unlike GetRankGlobal, never reads the
+// RankSnapshotInterval snapshot.
+// Returns error if:
+// - user ID is empty
+// - Redis operation fails
+func (lb *Leaderboard) GetUserRanks(userID string) (_ UserRanks, err error) {
+	defer lb.startOp("GetUserRanks", userID, "").end(&err)
+	ranks := UserRanks{GlobalRank: -1, EntityRank: -1}
+	if userID == "" {
+		return ranks, fmt.Errorf("invalid user ID")
+	}
+
+	pipe := lb.reader.Pipeline()
+	entityCmd := pipe.HGet(lb.ctx, lb.entitiesKey(), userID)
+	primaryCmd := lb.primaryRank(pipe, userID)
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return ranks, fmt.Errorf("failed to get user entity: %w", err)
+	}
+	ranks.Entity = entityCmd.Val()
+
+	// The global rank and, on the same backend, the entity rank in one trip
+	globalKey := lb.userGlobalKey(userID)
+	member := lb.memberFor(userID, ranks.Entity)
+	pipe = lb.reader.Pipeline()
+	globalCmd := pipe.ZRevRank(lb.ctx, globalKey, member)
+	scoreCmd := pipe.ZScore(lb.ctx, globalKey, member)
+	var entityRankCmd *redis.IntCmd
+	primaryHit := primaryCmd != nil && primaryCmd.Err() == nil
+	sharded := lb.entityReader(ranks.Entity) != lb.reader
+	if !primaryHit && ranks.Entity != "" && !sharded {
+		entityRankCmd = pipe.ZRevRank(lb.ctx, lb.entityKey(ranks.Entity), userID)
+	}
+	if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+		return ranks, fmt.Errorf("failed to get user ranks: %w", err)
+	}
+
+	if globalCmd.Err() == nil {
+		ranks.Exists = true
+		ranks.GlobalRank = int(globalCmd.Val())
+		if lb.partitioned() {
+			rank, err := lb.partitionRank(lb.reader, userID, scoreCmd.Val(), globalCmd.Val())
+			if err != nil {
+				return ranks, err
+			}
+			ranks.GlobalRank = int(rank)
+		}
+	}
+
+	switch {
+	case primaryHit:
+		ranks.EntityRank = int(primaryCmd.Val())
+	case entityRankCmd != nil:
+		if entityRankCmd.Err() == nil {
+			ranks.EntityRank = int(entityRankCmd.Val())
+		}
+	case ranks.Entity != "":
+		rank, err := lb.entityReader(ranks.Entity).ZRevRank(lb.ctx, lb.entityKey(ranks.Entity), userID).Result()
+		if err != nil && err != redis.Nil {
+			return ranks, fmt.Errorf("failed to get entity rank: %w", err)
+		}
+		if err == nil {
+			ranks.EntityRank = int(rank)
+		}
+	}
+	return ranks, nil
+}
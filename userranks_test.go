@@ -0,0 +1,59 @@
+package redisboard
+
+import "testing"
+
+func TestGetUserRanks(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 80})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 90})
+	lb.AddUser(User{ID: "u4", Score: 10})
+
+	tests := []struct {
+		userID string
+		want   UserRanks
+	}{
+		{"u2", UserRanks{GlobalRank: 2, EntityRank: 1, Exists: true, Entity: "US"}},
+		{"u3", UserRanks{GlobalRank: 1, EntityRank: 0, Exists: true, Entity: "UK"}},
+		{"u4", UserRanks{GlobalRank: 3, EntityRank: -1, Exists: true}},
+		{"ghost", UserRanks{GlobalRank: -1, EntityRank: -1}},
+	}
+	for _, tt := range tests {
+		got, err := lb.GetUserRanks(tt.userID)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v, %v", tt.userID, tt.want, got, err)
+		}
+	}
+	if _, err := lb.GetUserRanks(""); err == nil {
+		t.Error("expected empty user ID to fail")
+	}
+}
+
+func TestGetUserRanksVariants(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"EntityInMember": {Namespace: "test", EntityInMember: true},
+		"GlobalShards":   {Namespace: "test", GlobalShards: 4},
+		"PrimaryEntity":  {Namespace: "test", PrimaryEntity: "EU"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			lb := newTestLeaderboard(t, cfg)
+			defer lb.Close()
+
+			for i, id := range []string{"u1", "u2", "u3", "u4"} {
+				lb.AddUser(User{ID: id, Entity: "US", Score: float64(100 - i)})
+			}
+			got, err := lb.GetUserRanks("u3")
+			if err != nil {
+				t.Fatal(err)
+			}
+			global, _ := lb.GetRankGlobal("u3")
+			entity, _ := lb.GetRankEntity("u3")
+			want := UserRanks{GlobalRank: global, EntityRank: entity, Exists: true, Entity: "US"}
+			if got != want || global != 2 {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package redisboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Report counts inconsistencies between the global ranking, the entity
+// mapping and the entity rankings, as found by Verify or Repair.
+type Report struct {
+	MissingMappings      int // users ranked globally without an entity mapping
+	OrphanMappings       int // entity mappings of users not ranked globally
+	StrayEntityMembers   int // entity ranking members whose mapping points elsewhere
+	MissingEntityMembers int // users missing from the entity ranking they are mapped to
+}
+
+// OK reports whether no inconsistency was found.
+func (r Report) OK() bool {
+	return r == Report{}
+}
+
+// Verify scans the leaderboard for inconsistencies left by crashes or
+// partially failed pipelines, without modifying anything.
+// Walks every ranking with SCAN, so it is safe on large boards, but it is
+// not a snapshot: writes running concurrently may show up as false
+// positives. Run it during low traffic.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) Verify() (_ Report, err error) {
+	defer lb.startOp("Verify", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return Report{}, err
+	}
+	if err := lb.unsharded(); err != nil {
+		return Report{}, err
+	}
+	return lb.verify(false)
+}
+
+// Repair runs Verify and fixes what it finds:
+// - users ranked globally without mapping are mapped to no entity, or to
+// the member's entity with EntityInMember
+// - orphan mappings are deleted
+// - stray entity ranking members are removed from that entity
+// - users missing from their mapped entity are added with their global score
+// The returned report counts the fixed inconsistencies.
+// Returns error if Redis operation fails.
+func (lb *Leaderboard) Repair() (_ Report, err error) {
+	defer lb.startOp("Repair", "", "").end(&err)
+	if err := lb.unpartitioned(); err != nil {
+		return Report{}, err
+	}
+	if err := lb.unsharded(); err != nil {
+		return Report{}, err
+	}
+	defer lb.topKCache.invalidate()
+	report, err := lb.verify(true)
+	if err == nil && lb.config.EntityTotals && !report.OK() {
+		err = lb.RebuildEntityTotals()
+	}
+	return report, err
+}
+
+// verify implements Verify and Repair.
+func (lb *Leaderboard) verify(repair bool) (Report, error) {
+	var report Report
+	if err := lb.verifyGlobal(&report, repair); err != nil {
+		return report, err
+	}
+	if err := lb.verifyMappings(&report, repair); err != nil {
+		return report, err
+	}
+	if err := lb.verifyEntities(&report, repair); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// verifyGlobal checks that every globally ranked user has a mapping and is
+// ranked in the entity it is mapped to.
+func (lb *Leaderboard) verifyGlobal(report *Report, repair bool) error {
+	globalKey := lb.globalKey()
+	entitiesKey := lb.entitiesKey()
+
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.ZScan(lb.ctx, globalKey, cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan users: %w", err)
+		}
+		// members carry the entity with EntityInMember, which is then the
+		// mapping a repair restores
+		users := make([]User, 0, len(keys)/2)
+		for i := 0; i+1 < len(keys); i += 2 {
+			score, err := strconv.ParseFloat(keys[i+1], 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse score of %s: %w", keys[i], err)
+			}
+			id, entity := lb.parseMember(keys[i])
+			users = append(users, User{ID: id, Entity: entity, Score: score})
+		}
+
+		if len(users) > 0 {
+			ids := make([]string, len(users))
+			for i, u := range users {
+				ids[i] = u.ID
+			}
+			mapped, err := lb.client.HMGet(lb.ctx, entitiesKey, ids...).Result()
+			if err != nil {
+				return fmt.Errorf("failed to fetch entities: %w", err)
+			}
+
+			pipe := lb.client.Pipeline()
+			rankCmds := make([]*redis.FloatCmd, len(users))
+			for i, u := range users {
+				entity, ok := mapped[i].(string)
+				if !ok {
+					report.MissingMappings++
+					if repair {
+						pipe.HSet(lb.ctx, entitiesKey, u.ID, u.Entity)
+					}
+					continue
+				}
+				users[i].Entity = entity
+				if entity != "" {
+					rankCmds[i] = pipe.ZScore(lb.ctx, lb.entityKey(entity), u.ID)
+				}
+			}
+			if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+				return fmt.Errorf("failed to check entity rankings: %w", err)
+			}
+
+			pipe = lb.client.Pipeline()
+			for i, u := range users {
+				if rankCmds[i] != nil && rankCmds[i].Err() == redis.Nil {
+					report.MissingEntityMembers++
+					if repair {
+						pipe.ZAdd(lb.ctx, lb.entityKey(u.Entity), redis.Z{Score: u.Score, Member: u.ID})
+					}
+				}
+			}
+			if _, err := pipe.Exec(lb.ctx); err != nil {
+				return fmt.Errorf("failed to repair users: %w", err)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// verifyMappings checks that every mapped user is ranked globally.
+func (lb *Leaderboard) verifyMappings(report *Report, repair bool) error {
+	globalKey := lb.globalKey()
+	entitiesKey := lb.entitiesKey()
+
+	var cursor uint64
+	for {
+		keys, next, err := lb.client.HScan(lb.ctx, entitiesKey, cursor, "", int64(lb.config.BatchSize)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan entity mapping: %w", err)
+		}
+
+		pipe := lb.client.Pipeline()
+		ids := make([]string, 0, len(keys)/2)
+		cmds := make([]*redis.FloatCmd, 0, len(keys)/2)
+		for i := 0; i+1 < len(keys); i += 2 {
+			ids = append(ids, keys[i])
+			cmds = append(cmds, pipe.ZScore(lb.ctx, globalKey, lb.memberFor(keys[i], keys[i+1])))
+		}
+		if _, err := pipe.Exec(lb.ctx); err != nil && err != redis.Nil {
+			return fmt.Errorf("failed to check global ranking: %w", err)
+		}
+
+		var orphans []string
+		for i, cmd := range cmds {
+			if cmd.Err() == redis.Nil {
+				orphans = append(orphans, ids[i])
+			}
+		}
+		report.OrphanMappings += len(orphans)
+		if repair && len(orphans) > 0 {
+			if err := lb.client.HDel(lb.ctx, entitiesKey, orphans...).Err(); err != nil {
+				return fmt.Errorf("failed to delete orphan mappings: %w", err)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// verifyEntities checks that every entity ranking member is mapped to
+// that entity.
+func (lb *Leaderboard) verifyEntities(report *Report, repair bool) error {
+	prefix := lb.entityKey("")
+	iter := lb.client.Scan(lb.ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(lb.ctx) {
+		entityKey := iter.Val()
+		entity := strings.TrimPrefix(entityKey, prefix)
+
+		var cursor uint64
+		for {
+			keys, next, err := lb.client.ZScan(lb.ctx, entityKey, cursor, "", int64(lb.config.BatchSize)).Result()
+			if err != nil {
+				return fmt.Errorf("failed to scan entity %s: %w", entity, err)
+			}
+
+			ids := make([]string, 0, len(keys)/2)
+			for i := 0; i+1 < len(keys); i += 2 {
+				ids = append(ids, keys[i])
+			}
+			if len(ids) > 0 {
+				mapped, err := lb.client.HMGet(lb.ctx, lb.entitiesKey(), ids...).Result()
+				if err != nil {
+					return fmt.Errorf("failed to fetch entities: %w", err)
+				}
+				var strays []any
+				for i, id := range ids {
+					if e, _ := mapped[i].(string); e != entity {
+						strays = append(strays, id)
+					}
+				}
+				report.StrayEntityMembers += len(strays)
+				if repair && len(strays) > 0 {
+					if err := lb.client.ZRem(lb.ctx, entityKey, strays...).Err(); err != nil {
+						return fmt.Errorf("failed to remove stray members of %s: %w", entity, err)
+					}
+				}
+			}
+
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan entities: %w", err)
+	}
+	return nil
+}
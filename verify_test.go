@@ -0,0 +1,46 @@
+package redisboard
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestVerifyRepair(t *testing.T) {
+	lb := newTestLeaderboard(t, Config{Namespace: "test"})
+	defer lb.Close()
+
+	lb.AddUser(User{ID: "u1", Entity: "US", Score: 100})
+	lb.AddUser(User{ID: "u2", Entity: "US", Score: 200})
+	lb.AddUser(User{ID: "u3", Entity: "UK", Score: 300})
+	lb.AddUser(User{ID: "u4", Score: 400})
+	if report, err := lb.Verify(); err != nil || !report.OK() {
+		t.Fatalf("expected consistent board, got %+v (err: %v)", report, err)
+	}
+
+	// simulate partial writes
+	lb.client.HDel(lb.ctx, lb.entitiesKey(), "u4")                               // missing mapping
+	lb.client.HSet(lb.ctx, lb.entitiesKey(), "ghost", "US")                      // orphan mapping
+	lb.client.ZAdd(lb.ctx, lb.entityKey("UK"), redis.Z{Score: 50, Member: "u1"}) // stray member
+	lb.client.ZRem(lb.ctx, lb.entityKey("US"), "u2")                             // missing entity member
+
+	want := Report{MissingMappings: 1, OrphanMappings: 1, StrayEntityMembers: 1, MissingEntityMembers: 1}
+	report, err := lb.Verify()
+	if err != nil || report != want {
+		t.Fatalf("expected %+v, got %+v (err: %v)", want, report, err)
+	}
+	// Verify doesn't mutate
+	if again, _ := lb.Verify(); again != want {
+		t.Fatalf("expected Verify to be read-only, got %+v", again)
+	}
+
+	if report, err := lb.Repair(); err != nil || report != want {
+		t.Fatalf("expected Repair to fix %+v, got %+v (err: %v)", want, report, err)
+	}
+	if report, err := lb.Verify(); err != nil || !report.OK() {
+		t.Errorf("expected consistent board after repair, got %+v (err: %v)", report, err)
+	}
+	if rank, _ := lb.GetRankEntity("u2"); rank != 0 {
+		t.Errorf("expected u2 back in US at rank 0, got %d", rank)
+	}
+}